@@ -290,13 +290,22 @@ type ZarfContainerTarget struct {
 	// The namespace to target for data injection.
 	Namespace string `json:"namespace"`
 	// The K8s selector to target for data injection.
-	Selector string `json:"selector" jsonschema:"example=app=data-injection"`
+	Selector string `json:"selector,omitempty" jsonschema:"example=app=data-injection"`
 	// The container name to target for data injection.
-	Container string `json:"container"`
-	// The path within the container to copy the data into.
+	Container string `json:"container,omitempty"`
+	// The PersistentVolumeClaim to inject the data into through a short-lived helper pod instead of a running container, used in place of selector and container.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	// The image of the helper pod for a PersistentVolumeClaim target, it must be one of the component's images and provide tar and sha256sum.
+	Image string `json:"image,omitempty"`
+	// The path within the container, or within the PersistentVolumeClaim, to copy the data into.
 	Path string `json:"path"`
 }
 
+// IsPersistentVolumeClaim returns whether the data is injected into a PersistentVolumeClaim.
+func (t ZarfContainerTarget) IsPersistentVolumeClaim() bool {
+	return t.PersistentVolumeClaim != ""
+}
+
 // ZarfDataInjection is a data-injection definition.
 type ZarfDataInjection struct {
 	// Either a path to a local folder/file or a remote URL of a file to inject into the given target pod + container.
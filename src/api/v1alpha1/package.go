@@ -37,6 +37,9 @@ const (
 	APIVersion string = "zarf.dev/v1alpha1"
 )
 
+// ZarfPackageArchMulti is the architecture of packages that contain images for several architectures.
+const ZarfPackageArchMulti = "multi"
+
 // ZarfPackage the top-level structure of a Zarf config file.
 type ZarfPackage struct {
 	// The API version of the Zarf package.
@@ -60,6 +63,29 @@ func (pkg ZarfPackage) IsInitConfig() bool {
 	return pkg.Kind == ZarfInitConfig
 }
 
+// IsMultiArch returns whether a Zarf package contains images for several architectures.
+func (pkg ZarfPackage) IsMultiArch() bool {
+	return pkg.Metadata.Architecture == ZarfPackageArchMulti
+}
+
+// ComponentKey returns the unique name that the directory and tarball of a component are stored under in the package.
+// Architecture variants of a component in a multi-architecture package share its name, so they are stored under
+// <name>-<arch>.
+func (pkg ZarfPackage) ComponentKey(component ZarfComponent) string {
+	if pkg.IsMultiArch() && component.Only.Cluster.Architecture != "" {
+		return component.Name + "-" + component.Only.Cluster.Architecture
+	}
+	return component.Name
+}
+
+// SupportedArchitectures returns the cluster architectures a Zarf package can be deployed to.
+func (pkg ZarfPackage) SupportedArchitectures() []string {
+	if pkg.IsMultiArch() {
+		return pkg.Metadata.Architectures
+	}
+	return []string{pkg.Metadata.Architecture}
+}
+
 // HasImages returns true if one of the components contains an image.
 func (pkg ZarfPackage) HasImages() bool {
 	for _, component := range pkg.Components {
@@ -149,7 +175,9 @@ type ZarfMetadata struct {
 	// Disable compression of this package.
 	Uncompressed bool `json:"uncompressed,omitempty"`
 	// The target cluster architecture for this package.
-	Architecture string `json:"architecture,omitempty" jsonschema:"example=arm64,example=amd64"`
+	Architecture string `json:"architecture,omitempty" jsonschema:"example=arm64,example=amd64,example=multi"`
+	// The architectures a multi-architecture package contains images for (architecture is set to multi when these are set).
+	Architectures []string `json:"architectures,omitempty" jsonschema:"example=amd64,example=arm64"`
 	// Yaml OnLy Online (YOLO): True enables deploying a Zarf package without first running zarf init against the cluster. This is ideal for connected environments where you want to use existing VCS and container registries.
 	YOLO bool `json:"yolo,omitempty"`
 	// Comma-separated list of package authors (including contact info).
@@ -162,6 +190,16 @@ type ZarfMetadata struct {
 	Vendor string `json:"vendor,omitempty"`
 	// Checksum of a checksums.txt file that contains checksums all the layers within the package.
 	AggregateChecksum string `json:"aggregateChecksum,omitempty"`
+	// Packages that must be deployed to the cluster before this package can be deployed.
+	DependsOn []ZarfPackageDependency `json:"dependsOn,omitempty"`
+}
+
+// ZarfPackageDependency is a package that must be deployed to the cluster before the package that declares it.
+type ZarfPackageDependency struct {
+	// The name of the package that must be deployed.
+	Name string `json:"name" jsonschema:"pattern=^[a-z0-9][a-z0-9\\-]*$"`
+	// A semver constraint the version of the deployed package must satisfy.
+	Version string `json:"version,omitempty" jsonschema:"example=>= 1.2.0,example=~1.2"`
 }
 
 // ZarfBuildData is written during the packager.Create() operation to track details of the created package.
@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
@@ -51,6 +53,16 @@ const (
 	//nolint:revive //ignore
 	PkgValidateErrInitNoYOLO = "sorry, you can't YOLO an init package"
 	//nolint:revive //ignore
+	PkgValidateErrInitNoMultiArch = "sorry, init packages can't be multi-architecture"
+	//nolint:revive //ignore
+	PkgValidateErrMultiArchNoArchitectures = "multi-architecture packages must list at least two architectures"
+	//nolint:revive //ignore
+	PkgValidateErrArchitecturesNotMultiArch = "architectures can only be listed in multi-architecture packages"
+	//nolint:revive //ignore
+	PkgValidateErrArchitectureInvalid = "architecture %q is not a valid image architecture"
+	//nolint:revive //ignore
+	PkgValidateErrArchitectureNotUnique = "architecture %q is not unique"
+	//nolint:revive //ignore
 	PkgValidateErrConstant = "invalid package constant: %w"
 	//nolint:revive //ignore
 	PkgValidateErrYOLONoOCI = "OCI images not allowed in YOLO"
@@ -63,6 +75,8 @@ const (
 	//nolint:revive //ignore
 	PkgValidateErrComponentNameNotUnique = "component name %q is not unique"
 	//nolint:revive //ignore
+	PkgValidateErrComponentKeyNotUnique = "component %q is stored as %q in the package, which is not unique"
+	//nolint:revive //ignore
 	PkgValidateErrComponentReqDefault = "component %q cannot be both required and default"
 	//nolint:revive //ignore
 	PkgValidateErrComponentReqGrouped = "component %q cannot be both required and grouped"
@@ -100,6 +114,18 @@ const (
 	PkgValidateErrManifestNameLength = "manifest %q exceed the maximum length of %d characters"
 	//nolint:revive //ignore
 	PkgValidateErrVariable = "invalid package variable: %w"
+	//nolint:revive //ignore
+	PkgValidateErrDataInjectionTarget = "data injection %q must target either a persistentVolumeClaim or a selector and container"
+	//nolint:revive //ignore
+	PkgValidateErrDataInjectionImage = "data injection %q into a persistentVolumeClaim must set an image from the component's images"
+	//nolint:revive //ignore
+	PkgValidateErrDependencyName = "package dependency %q must be a valid package name"
+	//nolint:revive //ignore
+	PkgValidateErrDependencySelf = "package %q cannot depend on itself"
+	//nolint:revive //ignore
+	PkgValidateErrDependencyNotUnique = "package dependency %q is not unique"
+	//nolint:revive //ignore
+	PkgValidateErrDependencyVersion = "package dependency %q has an invalid version constraint: %w"
 )
 
 // Validate runs all validation checks on the package.
@@ -109,13 +135,54 @@ func (pkg ZarfPackage) Validate() error {
 		err = errors.Join(err, fmt.Errorf(PkgValidateErrInitNoYOLO))
 	}
 
+	if pkg.IsMultiArch() {
+		if pkg.Kind == ZarfInitConfig {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrInitNoMultiArch))
+		}
+		if len(pkg.Metadata.Architectures) < 2 {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrMultiArchNoArchitectures))
+		}
+	} else if len(pkg.Metadata.Architectures) > 0 {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrArchitecturesNotMultiArch))
+	}
+	uniqueArchitectures := make(map[string]bool)
+	for _, arch := range pkg.Metadata.Architectures {
+		if arch == "" || arch == ZarfPackageArchMulti {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrArchitectureInvalid, arch))
+		}
+		if uniqueArchitectures[arch] {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrArchitectureNotUnique, arch))
+		}
+		uniqueArchitectures[arch] = true
+	}
+
 	for _, constant := range pkg.Constants {
 		if varErr := constant.Validate(); varErr != nil {
 			err = errors.Join(err, fmt.Errorf(PkgValidateErrConstant, varErr))
 		}
 	}
 
+	uniqueDependencyNames := make(map[string]bool)
+	for _, dependency := range pkg.Metadata.DependsOn {
+		if !IsLowercaseNumberHyphenNoStartHyphen(dependency.Name) {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrDependencyName, dependency.Name))
+		}
+		if dependency.Name == pkg.Metadata.Name {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrDependencySelf, dependency.Name))
+		}
+		if uniqueDependencyNames[dependency.Name] {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrDependencyNotUnique, dependency.Name))
+		}
+		uniqueDependencyNames[dependency.Name] = true
+		if dependency.Version != "" {
+			if _, versionErr := semver.NewConstraint(dependency.Version); versionErr != nil {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrDependencyVersion, dependency.Name, versionErr))
+			}
+		}
+	}
+
 	uniqueComponentNames := make(map[string]bool)
+	uniqueComponentKeys := make(map[string]string)
 	groupDefault := make(map[string]string)
 	groupedComponents := make(map[string][]string)
 
@@ -140,11 +207,32 @@ func (pkg ZarfPackage) Validate() error {
 	}
 
 	for _, component := range pkg.Components {
-		// ensure component name is unique
-		if _, ok := uniqueComponentNames[component.Name]; ok {
-			err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentNameNotUnique, component.Name))
+		// ensure component name is unique, the components of a multi-architecture package only need to be unique
+		// within their architecture as the components of the other architectures are filtered on deploy
+		nameKeys := []string{component.Name}
+		if pkg.IsMultiArch() {
+			nameKeys = []string{}
+			for _, arch := range pkg.Metadata.Architectures {
+				if component.Only.Cluster.Architecture == "" || component.Only.Cluster.Architecture == arch {
+					nameKeys = append(nameKeys, component.Name+"/"+arch)
+				}
+			}
+		}
+		for _, key := range nameKeys {
+			if uniqueComponentNames[key] {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentNameNotUnique, component.Name))
+				break
+			}
+		}
+		for _, key := range nameKeys {
+			uniqueComponentNames[key] = true
+		}
+		// ensure the name the component is stored under does not collide with another component
+		componentKey := pkg.ComponentKey(component)
+		if name, ok := uniqueComponentKeys[componentKey]; ok && name != component.Name {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentKeyNotUnique, component.Name, componentKey))
 		}
-		uniqueComponentNames[component.Name] = true
+		uniqueComponentKeys[componentKey] = component.Name
 
 		if component.IsRequired() {
 			if component.Default {
@@ -181,6 +269,16 @@ func (pkg ZarfPackage) Validate() error {
 			}
 		}
 
+		for _, data := range component.DataInjections {
+			hasPodTarget := data.Target.Selector != "" && data.Target.Container != ""
+			if data.Target.IsPersistentVolumeClaim() == hasPodTarget {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrDataInjectionTarget, data.Source))
+			}
+			if data.Target.IsPersistentVolumeClaim() && !slices.Contains(component.Images, data.Target.Image) {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrDataInjectionImage, data.Source))
+			}
+		}
+
 		if actionsErr := component.Actions.validate(); actionsErr != nil {
 			err = errors.Join(err, fmt.Errorf("%q: %w", component.Name, actionsErr))
 		}
@@ -5,6 +5,7 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -56,6 +57,13 @@ func TestZarfPackageValidate(t *testing.T) {
 							{Name: "manifest1", Files: []string{"file1"}},
 							{Name: "manifest1", Files: []string{"file2"}},
 						},
+						Images: []string{"busybox:1.36"},
+						DataInjections: []ZarfDataInjection{
+							{Source: "both", Target: ZarfContainerTarget{Selector: "app=a", Container: "a", PersistentVolumeClaim: "pvc", Image: "busybox:1.36", Path: "/data"}},
+							{Source: "neither", Target: ZarfContainerTarget{Selector: "app=a", Path: "/data"}},
+							{Source: "pvc", Target: ZarfContainerTarget{PersistentVolumeClaim: "pvc", Image: "busybox:1.36", Path: "/data"}},
+							{Source: "pvc-without-image", Target: ZarfContainerTarget{PersistentVolumeClaim: "pvc", Path: "/data"}},
+						},
 					},
 					{
 						Name:            "required-in-group",
@@ -92,12 +100,131 @@ func TestZarfPackageValidate(t *testing.T) {
 				fmt.Sprintf(PkgValidateErrComponentReqDefault, "invalid"),
 				fmt.Sprintf(PkgValidateErrChartNameNotUnique, "chart1"),
 				fmt.Sprintf(PkgValidateErrManifestNameNotUnique, "manifest1"),
+				fmt.Sprintf(PkgValidateErrDataInjectionTarget, "both"),
+				fmt.Sprintf(PkgValidateErrDataInjectionTarget, "neither"),
+				fmt.Sprintf(PkgValidateErrDataInjectionImage, "pvc-without-image"),
 				fmt.Sprintf(PkgValidateErrComponentReqGrouped, "required-in-group"),
 				fmt.Sprintf(PkgValidateErrComponentNameNotUnique, "duplicate"),
 				fmt.Sprintf(PkgValidateErrGroupOneComponent, "a-group", "required-in-group"),
 				fmt.Sprintf(PkgValidateErrGroupMultipleDefaults, "multi-default", "multi-default", "multi-default-2"),
 			},
 		},
+		{
+			name: "invalid dependencies",
+			pkg: ZarfPackage{
+				Kind: ZarfPackageConfig,
+				Metadata: ZarfMetadata{
+					Name: "apps",
+					DependsOn: []ZarfPackageDependency{
+						{Name: "core", Version: ">= 1.0.0"},
+						{Name: "core"},
+						{Name: "apps"},
+						{Name: "-init"},
+						{Name: "gitea", Version: "not a version"},
+					},
+				},
+				Components: []ZarfComponent{
+					{
+						Name: "component1",
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrDependencyNotUnique, "core"),
+				fmt.Sprintf(PkgValidateErrDependencySelf, "apps"),
+				fmt.Sprintf(PkgValidateErrDependencyName, "-init"),
+				fmt.Errorf(PkgValidateErrDependencyVersion, "gitea", errors.New("improper constraint: not a version")).Error(),
+			},
+		},
+		{
+			name: "invalid multi-architecture init",
+			pkg: ZarfPackage{
+				Kind: ZarfInitConfig,
+				Metadata: ZarfMetadata{
+					Name:          "init",
+					Architecture:  ZarfPackageArchMulti,
+					Architectures: []string{"amd64", "arm64", "amd64", ZarfPackageArchMulti},
+				},
+				Components: []ZarfComponent{
+					{
+						Name: "component1",
+					},
+				},
+			},
+			expectedErrs: []string{
+				PkgValidateErrInitNoMultiArch,
+				fmt.Sprintf(PkgValidateErrArchitectureNotUnique, "amd64"),
+				fmt.Sprintf(PkgValidateErrArchitectureInvalid, ZarfPackageArchMulti),
+			},
+		},
+		{
+			name: "multi-architecture component names",
+			pkg: ZarfPackage{
+				Kind: ZarfPackageConfig,
+				Metadata: ZarfMetadata{
+					Name:          "apps",
+					Architecture:  ZarfPackageArchMulti,
+					Architectures: []string{"amd64", "arm64"},
+				},
+				Components: []ZarfComponent{
+					{
+						Name: "per-arch",
+						Only: ZarfComponentOnlyTarget{Cluster: ZarfComponentOnlyCluster{Architecture: "amd64"}},
+					},
+					{
+						Name: "per-arch",
+						Only: ZarfComponentOnlyTarget{Cluster: ZarfComponentOnlyCluster{Architecture: "arm64"}},
+					},
+					{
+						Name: "shared",
+					},
+					{
+						Name: "shared",
+						Only: ZarfComponentOnlyTarget{Cluster: ZarfComponentOnlyCluster{Architecture: "arm64"}},
+					},
+					{
+						Name: "per-arch-amd64",
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrComponentNameNotUnique, "shared"),
+				fmt.Sprintf(PkgValidateErrComponentKeyNotUnique, "per-arch-amd64", "per-arch-amd64"),
+			},
+		},
+		{
+			name: "multi-architecture without architectures",
+			pkg: ZarfPackage{
+				Kind: ZarfPackageConfig,
+				Metadata: ZarfMetadata{
+					Name:         "apps",
+					Architecture: ZarfPackageArchMulti,
+				},
+				Components: []ZarfComponent{
+					{
+						Name: "component1",
+					},
+				},
+			},
+			expectedErrs: []string{PkgValidateErrMultiArchNoArchitectures},
+		},
+		{
+			name: "architectures without multi-architecture",
+			pkg: ZarfPackage{
+				Kind: ZarfPackageConfig,
+				Metadata: ZarfMetadata{
+					Name:          "apps",
+					Architecture:  "amd64",
+					Architectures: []string{"amd64", "arm64"},
+				},
+				Components: []ZarfComponent{
+					{
+						Name: "component1",
+					},
+				},
+			},
+			expectedErrs: []string{PkgValidateErrArchitecturesNotMultiArch},
+		},
 		{
 			name: "invalid yolo",
 			pkg: ZarfPackage{
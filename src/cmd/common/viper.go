@@ -29,10 +29,14 @@ const (
 	VTmpDir       = "tmp_dir"
 	VInsecure     = "insecure"
 
+	// VStateEncryptionKey is only read from the config file or ZARF_STATE_ENCRYPTION_KEY to keep the key out of shell history
+	VStateEncryptionKey = "state_encryption_key"
+
 	// Init config keys
 
-	VInitComponents   = "init.components"
-	VInitStorageClass = "init.storage_class"
+	VInitComponents    = "init.components"
+	VInitStorageClass  = "init.storage_class"
+	VInitInjectionMode = "init.injection_mode"
 
 	// Init Git config keys
 
@@ -58,6 +62,14 @@ const (
 	VInitArtifactPushUser  = "init.artifact.push_username"
 	VInitArtifactPushToken = "init.artifact.push_token"
 
+	VInitImageVerificationKey  = "init.image_verification.key"
+	VInitImageVerificationMode = "init.image_verification.mode"
+
+	VInitAgentExcludeNamespaces = "init.agent_exclusions.namespaces"
+	VInitAgentExcludeImages     = "init.agent_exclusions.images"
+	VInitAgentExcludeRegistries = "init.agent_exclusions.registries"
+	VInitAgentCRDMutations      = "init.agent_crd_mutations"
+
 	// Package config keys
 
 	VPkgOCIConcurrency = "package.oci_concurrency"
@@ -76,16 +88,19 @@ const (
 	VPkgCreateDifferential       = "package.create.differential"
 	VPkgCreateRegistryOverride   = "package.create.registry_override"
 	VPkgCreateFlavor             = "package.create.flavor"
+	VPkgCreateImageSources       = "package.create.image_sources"
 
 	// Package deploy config keys
 
-	VPkgDeploySet          = "package.deploy.set"
-	VPkgDeployComponents   = "package.deploy.components"
-	VPkgDeployShasum       = "package.deploy.shasum"
-	VPkgDeploySget         = "package.deploy.sget"
-	VPkgDeploySkipWebhooks = "package.deploy.skip_webhooks"
-	VPkgDeployTimeout      = "package.deploy.timeout"
-	VPkgRetries            = "package.deploy.retries"
+	VPkgDeploySet               = "package.deploy.set"
+	VPkgDeployComponents        = "package.deploy.components"
+	VPkgDeployShasum            = "package.deploy.shasum"
+	VPkgDeploySget              = "package.deploy.sget"
+	VPkgDeploySkipWebhooks      = "package.deploy.skip_webhooks"
+	VPkgDeployTimeout           = "package.deploy.timeout"
+	VPkgDeployRollbackOnFailure = "package.deploy.rollback_on_failure"
+	VPkgRetries                 = "package.deploy.retries"
+	VPkgPushConcurrency         = "package.deploy.push_concurrency"
 
 	// Package publish config keys
 
@@ -186,6 +201,10 @@ func setDefaults() {
 	// Package defaults that are non-zero values
 	v.SetDefault(VPkgOCIConcurrency, 3)
 	v.SetDefault(VPkgRetries, config.ZarfDefaultRetries)
+	v.SetDefault(VPkgPushConcurrency, config.ZarfDefaultPushConcurrency)
+
+	// Create opts that are non-zero values
+	v.SetDefault(VPkgCreateImageSources, config.ZarfDefaultImageSources)
 
 	// Deploy opts that are non-zero values
 	v.SetDefault(VPkgDeployTimeout, config.ZarfDefaultTimeout)
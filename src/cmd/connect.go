@@ -6,6 +6,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -16,8 +17,9 @@ import (
 )
 
 var (
-	cliOnly bool
-	zt      cluster.TunnelInfo
+	cliOnly           bool
+	zt                cluster.TunnelInfo
+	connectListOutput = message.OutputTable
 )
 var connectCmd = &cobra.Command{
 	Use:     "connect { REGISTRY | GIT | connect-name }",
@@ -79,8 +81,8 @@ var connectCmd = &cobra.Command{
 		case <-ctx.Done():
 			spinner.Successf(lang.CmdConnectTunnelClosed, tunnel.FullURL())
 			return nil
-		case err = <-tunnel.ErrChan():
-			return fmt.Errorf("lost connection to the service: %w", err)
+		case <-tunnel.Done():
+			return fmt.Errorf("lost connection to the service: %w", tunnel.Err())
 		}
 	},
 }
@@ -98,6 +100,9 @@ var connectListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if connectListOutput != message.OutputTable {
+			return message.PrintStructured(os.Stdout, connectListOutput, connections)
+		}
 		message.PrintConnectStringTable(connections)
 		return nil
 	},
@@ -107,6 +112,8 @@ func init() {
 	rootCmd.AddCommand(connectCmd)
 	connectCmd.AddCommand(connectListCmd)
 
+	connectListCmd.Flags().VarP(&connectListOutput, "output", "o", lang.CmdConnectListFlagOutput)
+
 	connectCmd.Flags().StringVar(&zt.ResourceName, "name", "", lang.CmdConnectFlagName)
 	connectCmd.Flags().StringVar(&zt.Namespace, "namespace", cluster.ZarfNamespaceName, lang.CmdConnectFlagNamespace)
 	connectCmd.Flags().StringVar(&zt.ResourceType, "type", cluster.SvcResource, lang.CmdConnectFlagType)
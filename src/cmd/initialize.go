@@ -6,6 +6,7 @@ package cmd
 
 import (
 	"context"
+	"crypto"
 	"errors"
 	"fmt"
 	"os"
@@ -19,6 +20,7 @@ import (
 	"github.com/zarf-dev/zarf/src/cmd/common"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager"
 	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
@@ -26,9 +28,15 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
 
+	sigs "github.com/sigstore/cosign/v2/pkg/signature"
 	"github.com/spf13/cobra"
 )
 
+var (
+	imageVerificationKeyPath string
+	agentCRDMutationsPath    string
+)
+
 // initCmd represents the init command.
 var initCmd = &cobra.Command{
 	Use:     "init",
@@ -44,6 +52,28 @@ var initCmd = &cobra.Command{
 			return fmt.Errorf("invalid command flags were provided: %w", err)
 		}
 
+		if imageVerificationKeyPath != "" {
+			publicKey, err := os.ReadFile(imageVerificationKeyPath)
+			if err != nil {
+				return fmt.Errorf("unable to read the image verification key: %w", err)
+			}
+			if _, err := sigs.LoadPublicKeyRaw(publicKey, crypto.SHA256); err != nil {
+				return fmt.Errorf("unable to load the image verification key: %w", err)
+			}
+			pkgConfig.InitOpts.ImageVerification.PublicKey = string(publicKey)
+		}
+
+		if agentCRDMutationsPath != "" {
+			if err := utils.ReadYaml(agentCRDMutationsPath, &pkgConfig.InitOpts.AgentCRDMutations); err != nil {
+				return fmt.Errorf("unable to read the agent custom resource mutations: %w", err)
+			}
+			for _, mutation := range pkgConfig.InitOpts.AgentCRDMutations {
+				if err := mutation.Validate(); err != nil {
+					return err
+				}
+			}
+		}
+
 		// Continue running package deploy for all components like any other package
 		initPackageName := sources.GetInitPackageName()
 		pkgConfig.PkgOpts.PackageSource = initPackageName
@@ -173,6 +203,16 @@ func validateInitFlags() error {
 			return fmt.Errorf(lang.CmdInitErrValidateArtifact)
 		}
 	}
+
+	injectionMode := pkgConfig.InitOpts.InjectionMode
+	if injectionMode != cluster.InjectionModeConfigMap && injectionMode != cluster.InjectionModePortForward {
+		return fmt.Errorf(lang.CmdInitErrValidateInjectionMode, injectionMode)
+	}
+
+	imageVerificationMode := pkgConfig.InitOpts.ImageVerification.Mode
+	if imageVerificationMode != types.ImageVerificationEnforce && imageVerificationMode != types.ImageVerificationWarn {
+		return fmt.Errorf(lang.CmdInitErrValidateImageVerificationMode, imageVerificationMode)
+	}
 	return nil
 }
 
@@ -185,6 +225,8 @@ func init() {
 	// NOTE: these are not in common.setDefaults so that zarf tools update-creds does not erroneously update values back to the default
 	v.SetDefault(common.VInitGitPushUser, types.ZarfGitPushUser)
 	v.SetDefault(common.VInitRegistryPushUser, types.ZarfRegistryPushUser)
+	v.SetDefault(common.VInitImageVerificationMode, types.ImageVerificationEnforce)
+	v.SetDefault(common.VInitInjectionMode, cluster.InjectionModeConfigMap)
 
 	// Init package set variable flags
 	initCmd.Flags().StringToStringVar(&pkgConfig.PkgOpts.SetVariables, "set", v.GetStringMapString(common.VPkgDeploySet), lang.CmdInitFlagSet)
@@ -193,6 +235,7 @@ func init() {
 	initCmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdInitFlagConfirm)
 	initCmd.Flags().StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(common.VInitComponents), lang.CmdInitFlagComponents)
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.StorageClass, "storage-class", v.GetString(common.VInitStorageClass), lang.CmdInitFlagStorageClass)
+	initCmd.Flags().StringVar(&pkgConfig.InitOpts.InjectionMode, "injection-mode", v.GetString(common.VInitInjectionMode), lang.CmdInitFlagInjectionMode)
 
 	// Flags for using an external Git server
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.GitServer.Address, "git-url", v.GetString(common.VInitGitURL), lang.CmdInitFlagGitURL)
@@ -215,6 +258,16 @@ func init() {
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.ArtifactServer.PushUsername, "artifact-push-username", v.GetString(common.VInitArtifactPushUser), lang.CmdInitFlagArtifactPushUser)
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.ArtifactServer.PushToken, "artifact-push-token", v.GetString(common.VInitArtifactPushToken), lang.CmdInitFlagArtifactPushToken)
 
+	// Flags for the image signature verification performed by the agent
+	initCmd.Flags().StringVar(&imageVerificationKeyPath, "image-verification-key", v.GetString(common.VInitImageVerificationKey), lang.CmdInitFlagImageVerificationKey)
+	initCmd.Flags().StringVar(&pkgConfig.InitOpts.ImageVerification.Mode, "image-verification-mode", v.GetString(common.VInitImageVerificationMode), lang.CmdInitFlagImageVerificationMode)
+
+	// Flags for the resources and images the agent leaves untouched
+	initCmd.Flags().StringSliceVar(&pkgConfig.InitOpts.AgentExclusions.Namespaces, "agent-exclude-namespaces", v.GetStringSlice(common.VInitAgentExcludeNamespaces), lang.CmdInitFlagAgentExcludeNamespaces)
+	initCmd.Flags().StringSliceVar(&pkgConfig.InitOpts.AgentExclusions.Images, "agent-exclude-images", v.GetStringSlice(common.VInitAgentExcludeImages), lang.CmdInitFlagAgentExcludeImages)
+	initCmd.Flags().StringSliceVar(&pkgConfig.InitOpts.AgentExclusions.Registries, "agent-internal-registries", v.GetStringSlice(common.VInitAgentExcludeRegistries), lang.CmdInitFlagAgentInternalRegistries)
+	initCmd.Flags().StringVar(&agentCRDMutationsPath, "agent-crd-mutations", v.GetString(common.VInitAgentCRDMutations), lang.CmdInitFlagAgentCRDMutations)
+
 	// Flags that control how a deployment proceeds
 	// Always require adopt-existing-resources flag (no viper)
 	initCmd.Flags().BoolVar(&pkgConfig.DeployOpts.AdoptExistingResources, "adopt-existing-resources", false, lang.CmdPackageDeployFlagAdoptExistingResources)
@@ -222,6 +275,7 @@ func init() {
 	initCmd.Flags().DurationVar(&pkgConfig.DeployOpts.Timeout, "timeout", v.GetDuration(common.VPkgDeployTimeout), lang.CmdPackageDeployFlagTimeout)
 
 	initCmd.Flags().IntVar(&pkgConfig.PkgOpts.Retries, "retries", v.GetInt(common.VPkgRetries), lang.CmdPackageFlagRetries)
+	initCmd.Flags().IntVar(&pkgConfig.PkgOpts.PushConcurrency, "push-concurrency", v.GetInt(common.VPkgPushConcurrency), lang.CmdPackageFlagPushConcurrency)
 	initCmd.Flags().StringVarP(&pkgConfig.PkgOpts.PublicKeyPath, "key", "k", v.GetString(common.VPkgPublicKey), lang.CmdPackageFlagFlagPublicKey)
 
 	initCmd.Flags().SortFlags = true
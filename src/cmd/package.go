@@ -8,9 +8,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/zarf-dev/zarf/src/cmd/common"
 	"github.com/zarf-dev/zarf/src/config/lang"
@@ -29,6 +33,12 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/packager"
 )
 
+var (
+	packageListOutput    = message.OutputTable
+	packageStatusOutput  = message.OutputTable
+	packageInspectOutput = message.OutputTable
+)
+
 var packageCmd = &cobra.Command{
 	Use:     "package",
 	Aliases: []string{"p"},
@@ -68,30 +78,40 @@ var packageCreateCmd = &cobra.Command{
 }
 
 var packageDeployCmd = &cobra.Command{
-	Use:     "deploy [ PACKAGE_SOURCE ]",
+	Use:     "deploy [ PACKAGE_SOURCE... ]",
 	Aliases: []string{"d"},
 	Short:   lang.CmdPackageDeployShort,
 	Long:    lang.CmdPackageDeployLong,
-	Args:    cobra.MaximumNArgs(1),
+	Example: lang.CmdPackageDeployExample,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		v := common.GetViper()
+		pkgConfig.PkgOpts.SetVariables = helpers.TransformAndMergeMap(
+			v.GetStringMapString(common.VPkgDeploySet), pkgConfig.PkgOpts.SetVariables, strings.ToUpper)
+
+		ctx := cmd.Context()
+
+		if len(args) > 1 {
+			if pkgConfig.PkgOpts.OptionalComponents != "" || pkgConfig.PkgOpts.Shasum != "" || pkgConfig.DeployOpts.DryRun {
+				return errors.New(lang.CmdPackageDeployErrMultipleFlags)
+			}
+			if err := packager.DeployPackages(ctx, &pkgConfig, args); err != nil {
+				return fmt.Errorf("failed to deploy packages: %w", err)
+			}
+			return nil
+		}
+
 		packageSource, err := choosePackage(args)
 		if err != nil {
 			return err
 		}
 		pkgConfig.PkgOpts.PackageSource = packageSource
 
-		v := common.GetViper()
-		pkgConfig.PkgOpts.SetVariables = helpers.TransformAndMergeMap(
-			v.GetStringMapString(common.VPkgDeploySet), pkgConfig.PkgOpts.SetVariables, strings.ToUpper)
-
 		pkgClient, err := packager.New(&pkgConfig)
 		if err != nil {
 			return err
 		}
 		defer pkgClient.ClearTempPaths()
 
-		ctx := cmd.Context()
-
 		if err := pkgClient.Deploy(ctx); err != nil {
 			return fmt.Errorf("failed to deploy package: %w", err)
 		}
@@ -136,6 +156,7 @@ var packageInspectCmd = &cobra.Command{
 			return err
 		}
 		pkgConfig.PkgOpts.PackageSource = packageSource
+		pkgConfig.InspectOpts.OutputFormat = string(packageInspectOutput)
 		src, err := identifyAndFallbackToClusterSource()
 		if err != nil {
 			return err
@@ -171,6 +192,16 @@ var packageListCmd = &cobra.Command{
 			return fmt.Errorf("unable to get the packages deployed to the cluster: %w", err)
 		}
 
+		if packageListOutput != message.OutputTable {
+			if err := message.PrintStructured(os.Stdout, packageListOutput, deployedZarfPackages); err != nil {
+				return err
+			}
+			if err != nil {
+				return fmt.Errorf("unable to read all of the packages deployed to the cluster: %w", err)
+			}
+			return nil
+		}
+
 		// Populate a matrix of all the deployed packages
 		packageData := [][]string{}
 
@@ -197,6 +228,124 @@ var packageListCmd = &cobra.Command{
 	},
 }
 
+var packageHistoryCmd = &cobra.Command{
+	Use:               "history PACKAGE_NAME",
+	Short:             lang.CmdPackageHistoryShort,
+	Long:              lang.CmdPackageHistoryLong,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: getPackageCompletionArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timeoutCtx, cancel := context.WithTimeout(cmd.Context(), cluster.DefaultTimeout)
+		defer cancel()
+		c, err := cluster.NewClusterWithWait(timeoutCtx)
+		if err != nil {
+			return err
+		}
+
+		history, err := c.GetPackageHistory(cmd.Context(), args[0])
+		if err != nil {
+			return fmt.Errorf("unable to get the history of package %s: %w", args[0], err)
+		}
+
+		// Populate a matrix of all the recorded revisions
+		historyData := [][]string{}
+
+		for _, revision := range history {
+			var components []string
+			for _, component := range revision.DeployedComponents {
+				components = append(components, fmt.Sprintf("%s (%s)", component.Name, component.Status))
+			}
+
+			var variables []string
+			for name, value := range revision.Variables {
+				variables = append(variables, fmt.Sprintf("%s=%s", name, value))
+			}
+			slices.Sort(variables)
+
+			historyData = append(historyData, []string{
+				strconv.Itoa(revision.Generation),
+				revision.Timestamp.Local().Format(time.RFC1123),
+				revision.Data.Metadata.Version,
+				revision.CLIVersion,
+				strings.Join(components, ", "),
+				strings.Join(variables, ", "),
+			})
+		}
+
+		header := []string{"Generation", "Deployed", "Version", "CLI Version", "Components", "Variables"}
+		message.Table(header, historyData)
+		return nil
+	},
+}
+
+var packageRollbackCmd = &cobra.Command{
+	Use:               "rollback PACKAGE_NAME",
+	Short:             lang.CmdPackageRollbackShort,
+	Long:              lang.CmdPackageRollbackLong,
+	Example:           lang.CmdPackageRollbackExample,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: getPackageCompletionArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pkgConfig.PkgOpts.PackageSource = args[0]
+		src, err := sources.NewClusterSource(&pkgConfig.PkgOpts)
+		if err != nil {
+			return err
+		}
+		pkgClient, err := packager.New(&pkgConfig, packager.WithSource(src))
+		if err != nil {
+			return err
+		}
+		defer pkgClient.ClearTempPaths()
+		if err := pkgClient.Rollback(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to roll back package: %w", err)
+		}
+		return nil
+	},
+}
+
+var packageStatusCmd = &cobra.Command{
+	Use:               "status PACKAGE_NAME",
+	Short:             lang.CmdPackageStatusShort,
+	Long:              lang.CmdPackageStatusLong,
+	Example:           lang.CmdPackageStatusExample,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: getPackageCompletionArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pkgConfig.PkgOpts.PackageSource = args[0]
+		src, err := sources.NewClusterSource(&pkgConfig.PkgOpts)
+		if err != nil {
+			return err
+		}
+		pkgClient, err := packager.New(&pkgConfig, packager.WithSource(src))
+		if err != nil {
+			return err
+		}
+		defer pkgClient.ClearTempPaths()
+		packageStatus, err := pkgClient.Status(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to check the status of package: %w", err)
+		}
+
+		if packageStatusOutput != message.OutputTable {
+			if err := message.PrintStructured(os.Stdout, packageStatusOutput, packageStatus); err != nil {
+				return err
+			}
+		} else {
+			statusData := [][]string{}
+			for _, result := range packageStatus.Results {
+				statusData = append(statusData, []string{result.Component, result.Kind, result.Name, result.Status, result.Message})
+			}
+			header := []string{"Component", "Kind", "Name", "Status", "Message"}
+			message.Table(header, statusData)
+		}
+
+		if !packageStatus.Healthy {
+			return fmt.Errorf(lang.CmdPackageStatusUnhealthy, packageStatus.Name)
+		}
+		return nil
+	},
+}
+
 var packageRemoveCmd = &cobra.Command{
 	Use:     "remove { PACKAGE_SOURCE | PACKAGE_NAME } --confirm",
 	Aliases: []string{"u", "rm"},
@@ -285,6 +434,31 @@ var packagePullCmd = &cobra.Command{
 	},
 }
 
+var packageDiffCmd = &cobra.Command{
+	Use:     "diff OLD_PACKAGE_SOURCE NEW_PACKAGE_SOURCE",
+	Short:   lang.CmdPackageDiffShort,
+	Long:    lang.CmdPackageDiffLong,
+	Example: lang.CmdPackageDiffExample,
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pkgConfig.DiffOpts.BasePackageSource = args[0]
+		pkgConfig.PkgOpts.PackageSource = args[1]
+		pkgClient, err := packager.New(&pkgConfig)
+		if err != nil {
+			return err
+		}
+		defer pkgClient.ClearTempPaths()
+		diff, err := pkgClient.Diff(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to diff packages: %w", err)
+		}
+		if pkgConfig.DiffOpts.ExitCode && diff.HasChanges() {
+			return errors.New(lang.CmdPackageDiffErrDifferences)
+		}
+		return nil
+	},
+}
+
 func choosePackage(args []string) (string, error) {
 	if len(args) > 0 {
 		return args[0], nil
@@ -355,15 +529,23 @@ func init() {
 	packageCmd.AddCommand(packageListCmd)
 	packageCmd.AddCommand(packagePublishCmd)
 	packageCmd.AddCommand(packagePullCmd)
+	packageCmd.AddCommand(packageDiffCmd)
+	packageCmd.AddCommand(packageHistoryCmd)
+	packageCmd.AddCommand(packageRollbackCmd)
+	packageCmd.AddCommand(packageStatusCmd)
 
 	bindPackageFlags(v)
 	bindCreateFlags(v)
 	bindDeployFlags(v)
 	bindMirrorFlags(v)
 	bindInspectFlags(v)
+	bindListFlags(v)
 	bindRemoveFlags(v)
 	bindPublishFlags(v)
 	bindPullFlags(v)
+	bindDiffFlags(v)
+	bindRollbackFlags(v)
+	bindStatusFlags(v)
 }
 
 func bindPackageFlags(v *viper.Viper) {
@@ -394,6 +576,7 @@ func bindCreateFlags(v *viper.Viper) {
 	createFlags.IntVarP(&pkgConfig.CreateOpts.MaxPackageSizeMB, "max-package-size", "m", v.GetInt(common.VPkgCreateMaxPackageSize), lang.CmdPackageCreateFlagMaxPackageSize)
 	createFlags.StringToStringVar(&pkgConfig.CreateOpts.RegistryOverrides, "registry-override", v.GetStringMapString(common.VPkgCreateRegistryOverride), lang.CmdPackageCreateFlagRegistryOverride)
 	createFlags.StringVarP(&pkgConfig.CreateOpts.Flavor, "flavor", "f", v.GetString(common.VPkgCreateFlavor), lang.CmdPackageCreateFlagFlavor)
+	createFlags.StringSliceVar(&pkgConfig.CreateOpts.ImageSources, "image-source", v.GetStringSlice(common.VPkgCreateImageSources), lang.CmdPackageCreateFlagImageSource)
 
 	createFlags.StringVar(&pkgConfig.CreateOpts.SigningKeyPath, "signing-key", v.GetString(common.VPkgCreateSigningKey), lang.CmdPackageCreateFlagSigningKey)
 	createFlags.StringVar(&pkgConfig.CreateOpts.SigningKeyPassword, "signing-key-pass", v.GetString(common.VPkgCreateSigningKeyPassword), lang.CmdPackageCreateFlagSigningKeyPassword)
@@ -416,10 +599,13 @@ func bindDeployFlags(v *viper.Viper) {
 
 	// Always require adopt-existing-resources flag (no viper)
 	deployFlags.BoolVar(&pkgConfig.DeployOpts.AdoptExistingResources, "adopt-existing-resources", false, lang.CmdPackageDeployFlagAdoptExistingResources)
+	deployFlags.BoolVar(&pkgConfig.DeployOpts.RollbackOnFailure, "rollback-on-failure", v.GetBool(common.VPkgDeployRollbackOnFailure), lang.CmdPackageDeployFlagRollbackOnFailure)
+	deployFlags.BoolVar(&pkgConfig.DeployOpts.DryRun, "dry-run", false, lang.CmdPackageDeployFlagDryRun)
 	deployFlags.BoolVar(&pkgConfig.DeployOpts.SkipWebhooks, "skip-webhooks", v.GetBool(common.VPkgDeploySkipWebhooks), lang.CmdPackageDeployFlagSkipWebhooks)
 	deployFlags.DurationVar(&pkgConfig.DeployOpts.Timeout, "timeout", v.GetDuration(common.VPkgDeployTimeout), lang.CmdPackageDeployFlagTimeout)
 
 	deployFlags.IntVar(&pkgConfig.PkgOpts.Retries, "retries", v.GetInt(common.VPkgRetries), lang.CmdPackageFlagRetries)
+	deployFlags.IntVar(&pkgConfig.PkgOpts.PushConcurrency, "push-concurrency", v.GetInt(common.VPkgPushConcurrency), lang.CmdPackageFlagPushConcurrency)
 	deployFlags.StringToStringVar(&pkgConfig.PkgOpts.SetVariables, "set", v.GetStringMapString(common.VPkgDeploySet), lang.CmdPackageDeployFlagSet)
 	deployFlags.StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(common.VPkgDeployComponents), lang.CmdPackageDeployFlagComponents)
 	deployFlags.StringVar(&pkgConfig.PkgOpts.Shasum, "shasum", v.GetString(common.VPkgDeployShasum), lang.CmdPackageDeployFlagShasum)
@@ -442,6 +628,7 @@ func bindMirrorFlags(v *viper.Viper) {
 	mirrorFlags.BoolVar(&pkgConfig.MirrorOpts.NoImgChecksum, "no-img-checksum", false, lang.CmdPackageMirrorFlagNoChecksum)
 
 	mirrorFlags.IntVar(&pkgConfig.PkgOpts.Retries, "retries", v.GetInt(common.VPkgRetries), lang.CmdPackageFlagRetries)
+	mirrorFlags.IntVar(&pkgConfig.PkgOpts.PushConcurrency, "push-concurrency", v.GetInt(common.VPkgPushConcurrency), lang.CmdPackageFlagPushConcurrency)
 	mirrorFlags.StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(common.VPkgDeployComponents), lang.CmdPackageMirrorFlagComponents)
 
 	// Flags for using an external Git server
@@ -460,12 +647,19 @@ func bindInspectFlags(_ *viper.Viper) {
 	inspectFlags.BoolVarP(&pkgConfig.InspectOpts.ViewSBOM, "sbom", "s", false, lang.CmdPackageInspectFlagSbom)
 	inspectFlags.StringVar(&pkgConfig.InspectOpts.SBOMOutputDir, "sbom-out", "", lang.CmdPackageInspectFlagSbomOut)
 	inspectFlags.BoolVar(&pkgConfig.InspectOpts.ListImages, "list-images", false, lang.CmdPackageInspectFlagListImages)
+	inspectFlags.VarP(&packageInspectOutput, "output", "o", lang.CmdPackageInspectFlagOutput)
+}
+
+func bindListFlags(_ *viper.Viper) {
+	listFlags := packageListCmd.Flags()
+	listFlags.VarP(&packageListOutput, "output", "o", lang.CmdPackageListFlagOutput)
 }
 
 func bindRemoveFlags(v *viper.Viper) {
 	removeFlags := packageRemoveCmd.Flags()
 	removeFlags.BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdPackageRemoveFlagConfirm)
 	removeFlags.StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(common.VPkgDeployComponents), lang.CmdPackageRemoveFlagComponents)
+	removeFlags.BoolVar(&pkgConfig.RemoveOpts.Force, "force", false, lang.CmdPackageRemoveFlagForce)
 	_ = packageRemoveCmd.MarkFlagRequired("confirm")
 }
 
@@ -479,3 +673,20 @@ func bindPullFlags(v *viper.Viper) {
 	pullFlags := packagePullCmd.Flags()
 	pullFlags.StringVarP(&pkgConfig.PullOpts.OutputDirectory, "output-directory", "o", v.GetString(common.VPkgPullOutputDir), lang.CmdPackagePullFlagOutputDirectory)
 }
+
+func bindDiffFlags(_ *viper.Viper) {
+	diffFlags := packageDiffCmd.Flags()
+	diffFlags.StringVarP(&pkgConfig.DiffOpts.OutputFormat, "output", "o", packager.DiffOutputText, lang.CmdPackageDiffFlagOutput)
+	diffFlags.BoolVar(&pkgConfig.DiffOpts.ExitCode, "exit-code", false, lang.CmdPackageDiffFlagExitCode)
+}
+
+func bindStatusFlags(_ *viper.Viper) {
+	statusFlags := packageStatusCmd.Flags()
+	statusFlags.VarP(&packageStatusOutput, "output", "o", lang.CmdPackageStatusFlagOutput)
+}
+
+func bindRollbackFlags(_ *viper.Viper) {
+	rollbackFlags := packageRollbackCmd.Flags()
+
+	rollbackFlags.IntVar(&pkgConfig.RollbackOpts.Generation, "to", 0, lang.CmdPackageRollbackFlagTo)
+}
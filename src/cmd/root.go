@@ -18,6 +18,7 @@ import (
 	"github.com/zarf-dev/zarf/src/cmd/tools"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/encryption"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/types"
@@ -110,6 +111,10 @@ func init() {
 		return
 	}
 
+	// Register the KMS providers that state encryption keys can reference
+	encryption.RegisterKMS(encryption.AWSKMSScheme, encryption.NewAWSKMSProvider)
+	encryption.RegisterKMS(encryption.HashiVaultScheme, encryption.NewHashiVaultProvider)
+
 	v := common.InitViper()
 
 	rootCmd.PersistentFlags().StringVarP(&LogLevelCLI, "log-level", "l", v.GetString(common.VLogLevel), lang.RootCmdFlagLogLevel)
@@ -120,4 +125,6 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.CachePath, "zarf-cache", v.GetString(common.VZarfCache), lang.RootCmdFlagCachePath)
 	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.TempDirectory, "tmpdir", v.GetString(common.VTmpDir), lang.RootCmdFlagTempDir)
 	rootCmd.PersistentFlags().BoolVar(&config.CommonOptions.Insecure, "insecure", v.GetBool(common.VInsecure), lang.RootCmdFlagInsecure)
+
+	config.CommonOptions.StateEncryptionKey = v.GetString(common.VStateEncryptionKey)
 }
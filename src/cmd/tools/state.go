@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package tools contains the CLI commands for Zarf.
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+)
+
+var (
+	stateNewKey       string
+	stateDecrypt      bool
+	stateShowOutput   = message.OutputYAML
+	stateShowRedact   bool
+	stateExportRedact bool
+)
+
+var stateCmd = &cobra.Command{
+	Use:   "state",
+	Short: lang.CmdToolsStateShort,
+	Long:  lang.CmdToolsStateLong,
+}
+
+var stateShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: lang.CmdToolsStateShowShort,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+		defer cancel()
+		c, err := cluster.NewClusterWithWait(timeoutCtx)
+		if err != nil {
+			return err
+		}
+
+		state, err := c.LoadZarfState(ctx)
+		if err != nil {
+			return err
+		}
+		if stateShowRedact {
+			cluster.SanitizeZarfState(state)
+		}
+		return message.PrintStructured(os.Stdout, stateShowOutput, state)
+	},
+}
+
+var stateExportCmd = &cobra.Command{
+	Use:     "export [ FILE ]",
+	Short:   lang.CmdToolsStateExportShort,
+	Long:    lang.CmdToolsStateExportLong,
+	Example: lang.CmdToolsStateExportExample,
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+		defer cancel()
+		c, err := cluster.NewClusterWithWait(timeoutCtx)
+		if err != nil {
+			return err
+		}
+
+		backup, err := c.ExportZarfState(ctx, stateExportRedact)
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			return message.PrintStructured(os.Stdout, message.OutputYAML, backup)
+		}
+
+		format := message.OutputYAML
+		if filepath.Ext(args[0]) == ".json" {
+			format = message.OutputJSON
+		}
+		// The backup holds every Zarf credential so keep it private to the current user.
+		f, err := os.OpenFile(args[0], os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := message.PrintStructured(f, format, backup); err != nil {
+			return err
+		}
+		message.Successf(lang.CmdToolsStateExportSuccess, len(backup.DeployedPackages), args[0])
+		return nil
+	},
+}
+
+var stateImportCmd = &cobra.Command{
+	Use:     "import FILE",
+	Short:   lang.CmdToolsStateImportShort,
+	Long:    lang.CmdToolsStateImportLong,
+	Example: lang.CmdToolsStateImportExample,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		backup, err := cluster.ReadZarfStateBackup(data)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+		defer cancel()
+		c, err := cluster.NewClusterWithWait(timeoutCtx)
+		if err != nil {
+			return err
+		}
+
+		oldState, err := c.LoadZarfState(ctx)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		if oldState != nil {
+			services := []string{message.RegistryKey, message.GitKey, message.ArtifactKey, message.AgentKey}
+			message.PrintCredentialUpdates(oldState, &backup.State, services)
+			if !confirmStateChange(lang.CmdToolsUpdateCredsConfirmContinue) {
+				return nil
+			}
+			// Applying the credentials saves the state from the backup
+			if err := applyCredentialUpdates(ctx, c, oldState, &backup.State, services); err != nil {
+				return err
+			}
+			if err := c.ImportDeployedPackages(ctx, backup); err != nil {
+				return err
+			}
+		} else if err := c.ImportZarfState(ctx, backup); err != nil {
+			return err
+		}
+		message.Successf(lang.CmdToolsStateImportSuccess, len(backup.DeployedPackages))
+		return nil
+	},
+}
+
+var stateSetCmd = &cobra.Command{
+	Use:     "set PATH=VALUE...",
+	Short:   lang.CmdToolsStateSetShort,
+	Long:    lang.CmdToolsStateSetLong,
+	Example: lang.CmdToolsStateSetExample,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values := map[string]string{}
+		for _, arg := range args {
+			path, value, ok := strings.Cut(arg, "=")
+			if !ok || path == "" {
+				return fmt.Errorf(lang.CmdToolsStateSetErrInvalid, arg)
+			}
+			values[path] = value
+		}
+
+		ctx := cmd.Context()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+		defer cancel()
+		c, err := cluster.NewClusterWithWait(timeoutCtx)
+		if err != nil {
+			return err
+		}
+
+		state, err := c.LoadZarfState(ctx)
+		if err != nil {
+			return err
+		}
+		newState, err := cluster.SetZarfStateValues(state, values)
+		if err != nil {
+			return err
+		}
+		if !confirmStateChange(lang.CmdToolsStateSetConfirm) {
+			return nil
+		}
+		if err := c.SaveZarfState(ctx, newState); err != nil {
+			return err
+		}
+		message.Success(lang.CmdToolsStateSetSuccess)
+		return nil
+	},
+}
+
+// confirmStateChange returns true when --confirm was provided or the user accepts the prompt.
+func confirmStateChange(prompt string) bool {
+	confirm := config.CommonOptions.Confirm
+	if confirm {
+		message.Note(lang.CmdToolsUpdateCredsConfirmProvided)
+		return true
+	}
+	if err := survey.AskOne(&survey.Confirm{Message: prompt}, &confirm); err != nil {
+		return false
+	}
+	return confirm
+}
+
+var stateMigrateEncryptionCmd = &cobra.Command{
+	Use:     "migrate-encryption",
+	Short:   lang.CmdToolsStateMigrateEncryptionShort,
+	Long:    lang.CmdToolsStateMigrateEncryptionLong,
+	Example: lang.CmdToolsStateMigrateEncryptionExample,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if stateNewKey == "" && !stateDecrypt {
+			return errors.New(lang.CmdToolsStateMigrateEncryptionErrNoTarget)
+		}
+
+		ctx := cmd.Context()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+		defer cancel()
+		c, err := cluster.NewClusterWithWait(timeoutCtx)
+		if err != nil {
+			return err
+		}
+
+		if err := c.MigrateZarfStateEncryption(ctx, stateNewKey); err != nil {
+			return err
+		}
+		if stateDecrypt {
+			message.Success(lang.CmdToolsStateMigrateEncryptionDecrypted)
+			return nil
+		}
+		message.Success(lang.CmdToolsStateMigrateEncryptionEncrypted)
+		return nil
+	},
+}
+
+func init() {
+	toolsCmd.AddCommand(stateCmd)
+
+	stateCmd.AddCommand(stateShowCmd)
+	stateShowCmd.Flags().VarP(&stateShowOutput, "output", "o", lang.CmdToolsStateShowFlagOutput)
+	stateShowCmd.Flags().BoolVar(&stateShowRedact, "redact", true, lang.CmdToolsStateShowFlagRedact)
+
+	stateCmd.AddCommand(stateExportCmd)
+	stateExportCmd.Flags().BoolVar(&stateExportRedact, "redact", false, lang.CmdToolsStateExportFlagRedact)
+
+	stateCmd.AddCommand(stateImportCmd)
+	stateImportCmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdToolsStateImportConfirmFlag)
+
+	stateCmd.AddCommand(stateSetCmd)
+	stateSetCmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdToolsStateSetConfirmFlag)
+
+	stateCmd.AddCommand(stateMigrateEncryptionCmd)
+	stateMigrateEncryptionCmd.Flags().StringVar(&stateNewKey, "new-key", "", lang.CmdToolsStateMigrateEncryptionFlagNewKey)
+	stateMigrateEncryptionCmd.Flags().BoolVar(&stateDecrypt, "decrypt", false, lang.CmdToolsStateMigrateEncryptionFlagDecrypt)
+	stateMigrateEncryptionCmd.MarkFlagsMutuallyExclusive("new-key", "decrypt")
+}
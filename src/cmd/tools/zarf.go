@@ -8,8 +8,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"os"
+	"path/filepath"
 	"slices"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
@@ -23,11 +26,14 @@ import (
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/gitea"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/images"
 	"github.com/zarf-dev/zarf/src/internal/packager/template"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/pkg/pki"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -35,6 +41,14 @@ import (
 var subAltNames []string
 var outputDirectory string
 var updateCredsInitOpts types.ZarfInitOptions
+var getCredsOutput = message.OutputTable
+var rotateBefore time.Duration
+var rotateLifetime time.Duration
+var rotateForce bool
+var rotateSchedule string
+var rotateUnschedule bool
+var clearCacheImages bool
+var clearCacheOlderThan time.Duration
 
 var deprecatedGetGitCredsCmd = &cobra.Command{
 	Use:    "get-git-password",
@@ -73,11 +87,25 @@ var getCredsCmd = &cobra.Command{
 			return errors.New("Zarf state secret did not load properly")
 		}
 
+		if getCredsOutput != message.OutputTable {
+			if len(args) > 0 {
+				credential, err := message.GetComponentCredential(state, args[0])
+				if err != nil {
+					return err
+				}
+				return message.PrintCredentialsStructured(os.Stdout, getCredsOutput, credential)
+			}
+			return message.PrintCredentialsStructured(os.Stdout, getCredsOutput, message.GetCredentials(state, nil))
+		}
+
 		if len(args) > 0 {
 			// If a component name is provided, only show that component's credentials
 			message.PrintComponentCredential(state, args[0])
 		} else {
 			message.PrintCredentialTable(state, nil)
+			if expiresAt, err := state.AgentTLS.ExpiresAt(); err == nil {
+				message.Notef(lang.CmdToolsGetCredsAgentExpiration, expiresAt.Format(time.DateOnly))
+			}
 		}
 		return nil
 	},
@@ -139,109 +167,231 @@ var updateCredsCmd = &cobra.Command{
 		}
 
 		if confirm {
-			// Update registry and git pull secrets
-			if slices.Contains(args, message.RegistryKey) {
-				c.UpdateZarfManagedImageSecrets(ctx, newState)
+			return applyCredentialUpdates(ctx, c, oldState, newState, args)
+		}
+		return nil
+	},
+}
+
+var rotateCmd = &cobra.Command{
+	Use:     "rotate",
+	Short:   lang.CmdToolsRotateShort,
+	Long:    lang.CmdToolsRotateLong,
+	Example: lang.CmdToolsRotateExample,
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validKeys := []string{message.RegistryKey, message.GitKey, message.AgentKey}
+		services := validKeys
+		if len(args) > 0 {
+			if !slices.Contains(validKeys, args[0]) {
+				cmd.Help()
+				return fmt.Errorf(lang.CmdToolsRotateErrInvalidKey, message.RegistryKey, message.GitKey, message.AgentKey)
+			}
+			services = args
+		}
+
+		ctx := cmd.Context()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+		defer cancel()
+		c, err := cluster.NewClusterWithWait(timeoutCtx)
+		if err != nil {
+			return err
+		}
+
+		if rotateUnschedule {
+			if err := c.UnscheduleCredentialRotation(ctx); err != nil {
+				return err
 			}
-			if slices.Contains(args, message.GitKey) {
-				c.UpdateZarfManagedGitSecrets(ctx, newState)
+			message.Success(lang.CmdToolsRotateUnscheduled)
+			return nil
+		}
+		if rotateSchedule != "" {
+			jobArgs := []string{"--confirm", "--no-log-file", "--before=" + rotateBefore.String(), "--lifetime=" + rotateLifetime.String()}
+			if rotateForce {
+				jobArgs = append(jobArgs, "--force")
 			}
+			if err := c.ScheduleCredentialRotation(ctx, rotateSchedule, append(jobArgs, args...)); err != nil {
+				return err
+			}
+			message.Successf(lang.CmdToolsRotateScheduled, rotateSchedule, cluster.ZarfNamespaceName)
+			return nil
+		}
 
-			// Update artifact token (if internal)
-			if slices.Contains(args, message.ArtifactKey) && newState.ArtifactServer.PushToken == "" && newState.ArtifactServer.IsInternal() {
-				tunnel, err := c.NewTunnel(cluster.ZarfNamespaceName, cluster.SvcResource, cluster.ZarfGitServerName, "", 0, cluster.ZarfGitServerPort)
-				if err != nil {
-					return err
-				}
-				_, err = tunnel.Connect(cmd.Context())
-				if err != nil {
-					return err
-				}
-				defer tunnel.Close()
-				tunnelURL := tunnel.HTTPEndpoint()
-				giteaClient, err := gitea.NewClient(tunnelURL, oldState.GitServer.PushUsername, oldState.GitServer.PushPassword)
-				if err != nil {
-					return err
-				}
-				err = tunnel.Wrap(func() error {
-					tokenSha1, err := giteaClient.CreatePackageRegistryToken(ctx)
-					if err != nil {
-						return err
-					}
-					newState.ArtifactServer.PushToken = tokenSha1
-					return nil
-				})
-				if err != nil {
-					// Warn if we couldn't actually update the git server (it might not be installed and we should try to continue)
-					message.Warnf(lang.CmdToolsUpdateCredsUnableCreateToken, err.Error())
-				}
+		oldState, err := c.LoadZarfState(ctx)
+		if err != nil {
+			return err
+		}
+		// TODO: Determine if this is actually needed.
+		if oldState.Distro == "" {
+			return errors.New("Zarf state secret did not load properly")
+		}
+
+		deadline := time.Now().Add(rotateBefore)
+		if rotateForce {
+			// Every generated credential expires before the furthest representable deadline
+			deadline = time.Now().Add(time.Duration(math.MaxInt64))
+		}
+		due, err := cluster.CredentialsDueForRotation(oldState, services, deadline)
+		if err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			message.Notef(lang.CmdToolsRotateNothingDue, deadline.Format(time.DateOnly))
+			return nil
+		}
+
+		newState, err := cluster.MergeZarfState(oldState, types.ZarfInitOptions{}, due)
+		if err != nil {
+			return fmt.Errorf("unable to rotate Zarf credentials: %w", err)
+		}
+		cluster.SetCredentialLifetime(newState, due, time.Now().Add(rotateLifetime))
+
+		message.PrintCredentialUpdates(oldState, newState, due)
+
+		confirm := config.CommonOptions.Confirm
+
+		if confirm {
+			message.Note(lang.CmdToolsUpdateCredsConfirmProvided)
+		} else {
+			prompt := &survey.Confirm{
+				Message: lang.CmdToolsUpdateCredsConfirmContinue,
 			}
+			if err := survey.AskOne(prompt, &confirm); err != nil {
+				return fmt.Errorf("confirm selection canceled: %w", err)
+			}
+		}
+
+		if confirm {
+			return applyCredentialUpdates(ctx, c, oldState, newState, due)
+		}
+		return nil
+	},
+}
 
-			// Save the final Zarf State
-			err = c.SaveZarfState(ctx, newState)
+// applyCredentialUpdates saves the new credentials and rolls them out to the Zarf managed pull secrets, the internal
+// git server and the Zarf Helm releases.
+func applyCredentialUpdates(ctx context.Context, c *cluster.Cluster, oldState, newState *types.ZarfState, services []string) error {
+	// Update registry and git pull secrets
+	if slices.Contains(services, message.RegistryKey) {
+		c.UpdateZarfManagedImageSecrets(ctx, newState)
+	}
+	if slices.Contains(services, message.GitKey) {
+		c.UpdateZarfManagedGitSecrets(ctx, newState)
+	}
+
+	// Update artifact token (if internal)
+	if slices.Contains(services, message.ArtifactKey) && newState.ArtifactServer.PushToken == "" && newState.ArtifactServer.IsInternal() {
+		tunnel, err := c.NewTunnel(cluster.ZarfNamespaceName, cluster.SvcResource, cluster.ZarfGitServerName, "", 0, cluster.ZarfGitServerPort)
+		if err != nil {
+			return err
+		}
+		_, err = tunnel.Connect(ctx)
+		if err != nil {
+			return err
+		}
+		defer tunnel.Close()
+		tunnelURL := tunnel.HTTPEndpoint()
+		giteaClient, err := gitea.NewClient(tunnelURL, oldState.GitServer.PushUsername, oldState.GitServer.PushPassword)
+		if err != nil {
+			return err
+		}
+		err = tunnel.Wrap(func() error {
+			tokenSha1, err := giteaClient.CreatePackageRegistryToken(ctx)
 			if err != nil {
-				return fmt.Errorf("failed to save the Zarf State to the cluster: %w", err)
+				return err
 			}
+			newState.ArtifactServer.PushToken = tokenSha1
+			return nil
+		})
+		if err != nil {
+			// Warn if we couldn't actually update the git server (it might not be installed and we should try to continue)
+			message.Warnf(lang.CmdToolsUpdateCredsUnableCreateToken, err.Error())
+		}
+	}
 
-			// Update Zarf 'init' component Helm releases if present
-			h := helm.NewClusterOnly(&types.PackagerConfig{}, template.GetZarfVariableConfig(), newState, c)
+	// Save the final Zarf State
+	err := c.SaveZarfState(ctx, newState)
+	if err != nil {
+		return fmt.Errorf("failed to save the Zarf State to the cluster: %w", err)
+	}
 
-			if slices.Contains(args, message.RegistryKey) && newState.RegistryInfo.IsInternal() {
-				err = h.UpdateZarfRegistryValues(ctx)
-				if err != nil {
-					// Warn if we couldn't actually update the registry (it might not be installed and we should try to continue)
-					message.Warnf(lang.CmdToolsUpdateCredsUnableUpdateRegistry, err.Error())
-				}
-			}
-			if slices.Contains(args, message.GitKey) && newState.GitServer.IsInternal() {
-				tunnel, err := c.NewTunnel(cluster.ZarfNamespaceName, cluster.SvcResource, cluster.ZarfGitServerName, "", 0, cluster.ZarfGitServerPort)
-				if err != nil {
-					return err
-				}
-				_, err = tunnel.Connect(cmd.Context())
-				if err != nil {
-					return err
-				}
-				defer tunnel.Close()
-				tunnelURL := tunnel.HTTPEndpoint()
-				giteaClient, err := gitea.NewClient(tunnelURL, oldState.GitServer.PushUsername, oldState.GitServer.PushPassword)
-				if err != nil {
-					return err
-				}
-				err = tunnel.Wrap(func() error {
-					err := giteaClient.UpdateGitUser(ctx, newState.GitServer.PullUsername, newState.GitServer.PullPassword)
-					if err != nil {
-						return err
-					}
-					err = giteaClient.UpdateGitUser(ctx, newState.GitServer.PushUsername, newState.GitServer.PushPassword)
-					if err != nil {
-						return err
-					}
-					return nil
-				})
-				if err != nil {
-					// Warn if we couldn't actually update the git server (it might not be installed and we should try to continue)
-					message.Warnf(lang.CmdToolsUpdateCredsUnableUpdateGit, err.Error())
-				}
+	// Update Zarf 'init' component Helm releases if present
+	h := helm.NewClusterOnly(&types.PackagerConfig{}, template.GetZarfVariableConfig(), newState, c)
+
+	if slices.Contains(services, message.RegistryKey) && newState.RegistryInfo.IsInternal() {
+		err = h.UpdateZarfRegistryValues(ctx)
+		if err != nil {
+			// Warn if we couldn't actually update the registry (it might not be installed and we should try to continue)
+			message.Warnf(lang.CmdToolsUpdateCredsUnableUpdateRegistry, err.Error())
+		}
+	}
+	if slices.Contains(services, message.GitKey) && newState.GitServer.IsInternal() {
+		tunnel, err := c.NewTunnel(cluster.ZarfNamespaceName, cluster.SvcResource, cluster.ZarfGitServerName, "", 0, cluster.ZarfGitServerPort)
+		if err != nil {
+			return err
+		}
+		_, err = tunnel.Connect(ctx)
+		if err != nil {
+			return err
+		}
+		defer tunnel.Close()
+		tunnelURL := tunnel.HTTPEndpoint()
+		giteaClient, err := gitea.NewClient(tunnelURL, oldState.GitServer.PushUsername, oldState.GitServer.PushPassword)
+		if err != nil {
+			return err
+		}
+		err = tunnel.Wrap(func() error {
+			err := giteaClient.UpdateGitUser(ctx, newState.GitServer.PullUsername, newState.GitServer.PullPassword)
+			if err != nil {
+				return err
 			}
-			if slices.Contains(args, message.AgentKey) {
-				err = h.UpdateZarfAgentValues(ctx)
-				if err != nil {
-					// Warn if we couldn't actually update the agent (it might not be installed and we should try to continue)
-					message.Warnf(lang.CmdToolsUpdateCredsUnableUpdateAgent, err.Error())
-				}
+			err = giteaClient.UpdateGitUser(ctx, newState.GitServer.PushUsername, newState.GitServer.PushPassword)
+			if err != nil {
+				return err
 			}
+			return nil
+		})
+		if err != nil {
+			// Warn if we couldn't actually update the git server (it might not be installed and we should try to continue)
+			message.Warnf(lang.CmdToolsUpdateCredsUnableUpdateGit, err.Error())
 		}
-		return nil
-	},
+	}
+	if slices.Contains(services, message.AgentKey) {
+		err = h.UpdateZarfAgentValues(ctx)
+		if err != nil {
+			// Warn if we couldn't actually update the agent (it might not be installed and we should try to continue)
+			message.Warnf(lang.CmdToolsUpdateCredsUnableUpdateAgent, err.Error())
+		}
+	}
+	return nil
 }
 
 var clearCacheCmd = &cobra.Command{
 	Use:     "clear-cache",
 	Aliases: []string{"c"},
 	Short:   lang.CmdToolsClearCacheShort,
-	RunE: func(_ *cobra.Command, _ []string) error {
+	RunE: func(cmd *cobra.Command, _ []string) error {
 		message.Notef(lang.CmdToolsClearCacheDir, config.GetAbsCachePath())
+		if cmd.Flags().Changed("older-than") && !clearCacheImages {
+			return errors.New(lang.CmdToolsClearCacheErrOlderThan)
+		}
+		if clearCacheImages {
+			imageCachePath := filepath.Join(config.GetAbsCachePath(), layout.ImagesDir)
+			if clearCacheOlderThan > 0 {
+				removed, freed, err := images.PruneImageCache(imageCachePath, clearCacheOlderThan)
+				if err != nil {
+					return err
+				}
+				message.Successf(lang.CmdToolsClearCacheImagesPruned, removed, utils.ByteFormat(float64(freed), 2), imageCachePath)
+				return nil
+			}
+			if err := os.RemoveAll(imageCachePath); err != nil {
+				return fmt.Errorf("unable to clear the image cache %s: %w", imageCachePath, err)
+			}
+			message.Successf(lang.CmdToolsClearCacheSuccess, imageCachePath)
+			return nil
+		}
 		if err := os.RemoveAll(config.GetAbsCachePath()); err != nil {
 			return fmt.Errorf("unable to clear the cache directory %s: %w", config.GetAbsCachePath(), err)
 		}
@@ -369,6 +519,7 @@ func init() {
 
 	toolsCmd.AddCommand(deprecatedGetGitCredsCmd)
 	toolsCmd.AddCommand(getCredsCmd)
+	getCredsCmd.Flags().VarP(&getCredsOutput, "output", "o", lang.CmdToolsGetCredsFlagOutput)
 
 	toolsCmd.AddCommand(updateCredsCmd)
 
@@ -396,8 +547,21 @@ func init() {
 
 	updateCredsCmd.Flags().SortFlags = true
 
+	toolsCmd.AddCommand(rotateCmd)
+
+	// Always require confirm flag (no viper)
+	rotateCmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdToolsUpdateCredsConfirmFlag)
+	rotateCmd.Flags().DurationVar(&rotateBefore, "before", 30*24*time.Hour, lang.CmdToolsRotateFlagBefore)
+	rotateCmd.Flags().DurationVar(&rotateLifetime, "lifetime", cluster.DefaultCredentialLifetime, lang.CmdToolsRotateFlagLifetime)
+	rotateCmd.Flags().BoolVar(&rotateForce, "force", false, lang.CmdToolsRotateFlagForce)
+	rotateCmd.Flags().StringVar(&rotateSchedule, "schedule", "", lang.CmdToolsRotateFlagSchedule)
+	rotateCmd.Flags().BoolVar(&rotateUnschedule, "unschedule", false, lang.CmdToolsRotateFlagUnschedule)
+	rotateCmd.MarkFlagsMutuallyExclusive("schedule", "unschedule")
+
 	toolsCmd.AddCommand(clearCacheCmd)
 	clearCacheCmd.Flags().StringVar(&config.CommonOptions.CachePath, "zarf-cache", config.ZarfDefaultCachePath, lang.CmdToolsClearCacheFlagCachePath)
+	clearCacheCmd.Flags().BoolVar(&clearCacheImages, "images", false, lang.CmdToolsClearCacheFlagImages)
+	clearCacheCmd.Flags().DurationVar(&clearCacheOlderThan, "older-than", 0, lang.CmdToolsClearCacheFlagOlderThan)
 
 	toolsCmd.AddCommand(downloadInitCmd)
 	downloadInitCmd.Flags().StringVarP(&outputDirectory, "output-directory", "o", "", lang.CmdToolsDownloadInitFlagOutputDirectory)
@@ -38,6 +38,13 @@ const (
 	UnsetCLIVersion = "unset-development-only"
 )
 
+// Names of the local image sources that images are loaded from when they cannot be pulled from a registry.
+const (
+	DockerImageSource     = "docker"
+	PodmanImageSource     = "podman"
+	ContainerdImageSource = "containerd"
+)
+
 // Zarf Global Configuration Variables.
 var (
 	// CLIVersion track the version of the CLI
@@ -69,6 +76,13 @@ var (
 	// Default Time Vars
 	ZarfDefaultTimeout = 15 * time.Minute
 	ZarfDefaultRetries = 3
+
+	// ZarfDefaultPushConcurrency is the number of images pushed to the registry at the same time
+	ZarfDefaultPushConcurrency = 3
+
+	// ZarfDefaultImageSources are the local image sources that images are loaded from, in order, when they cannot be
+	// pulled from a registry
+	ZarfDefaultImageSources = []string{DockerImageSource, PodmanImageSource, ContainerdImageSource}
 )
 
 // GetArch returns the arch based on a priority list with options for overriding.
@@ -71,7 +71,8 @@ const (
 		"to whatever resource you are trying to connect to."
 
 	// zarf connect list
-	CmdConnectListShort = "Lists all available connection shortcuts"
+	CmdConnectListShort      = "Lists all available connection shortcuts"
+	CmdConnectListFlagOutput = "Output format of the connection shortcuts (table|json|yaml)"
 
 	CmdConnectFlagName       = "Specify the resource name.  E.g. name=unicorns or name=unicorn-pod-7448499f4d-b5bk6. Ignored if connect-name is supplied."
 	CmdConnectFlagNamespace  = "Specify the namespace.  E.g. namespace=default. Ignored if connect-name is supplied."
@@ -141,6 +142,9 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 	CmdInitErrValidateRegistry = "the 'registry-push-username' and 'registry-push-password' flags must be provided if the 'registry-url' flag is provided"
 	CmdInitErrValidateArtifact = "the 'artifact-push-username' and 'artifact-push-token' flags must be provided if the 'artifact-url' flag is provided"
 
+	CmdInitErrValidateImageVerificationMode = "invalid image verification mode %q, must be 'enforce' or 'warn'"
+	CmdInitErrValidateInjectionMode         = "invalid injection mode %q, must be 'configmap' or 'port-forward'"
+
 	CmdInitPullAsk       = "It seems the init package could not be found locally, but can be pulled from oci://%s"
 	CmdInitPullNote      = "Note: This will require an internet connection."
 	CmdInitPullConfirm   = "Do you want to pull this init package?"
@@ -148,9 +152,18 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 
 	CmdInitFlagSet = "Specify deployment variables to set on the command line (KEY=value)"
 
-	CmdInitFlagConfirm      = "Confirms package deployment without prompting. ONLY use with packages you trust. Skips prompts to review SBOM, configure variables, select optional components and review potential breaking changes."
-	CmdInitFlagComponents   = "Specify which optional components to install.  E.g. --components=git-server"
-	CmdInitFlagStorageClass = "Specify the storage class to use for the registry and git server.  E.g. --storage-class=standard"
+	CmdInitFlagConfirm       = "Confirms package deployment without prompting. ONLY use with packages you trust. Skips prompts to review SBOM, configure variables, select optional components and review potential breaking changes."
+	CmdInitFlagComponents    = "Specify which optional components to install.  E.g. --components=git-server"
+	CmdInitFlagStorageClass  = "Specify the storage class to use for the registry and git server.  E.g. --storage-class=standard"
+	CmdInitFlagInjectionMode = "How to deliver the seed registry image to the injector pod (configmap|port-forward). 'port-forward' uploads it through a port-forward instead of splitting it across ConfigMaps and falls back to 'configmap' when the upload fails. Requires a zarf-injector of version 0.6.0 or newer, older injectors always fall back"
+
+	CmdInitFlagImageVerificationKey  = "Path to a cosign public key the Zarf agent verifies pod image signatures against. Signatures must be pushed to the Zarf registry alongside their images"
+	CmdInitFlagImageVerificationMode = "Whether the Zarf agent rejects pods with images that fail signature verification or admits them with a warning (enforce|warn)"
+
+	CmdInitFlagAgentExcludeNamespaces  = "Glob patterns of namespaces whose resources the Zarf agent does not mutate (e.g. 'team-*')"
+	CmdInitFlagAgentExcludeImages      = "Glob patterns of image references the Zarf agent does not rewrite, '*' also matches across '/' (e.g. 'docker.io/library/*')"
+	CmdInitFlagAgentInternalRegistries = "Registries, optionally with a path prefix, already considered internal. The Zarf agent does not redirect images or repositories hosted on them (e.g. 'mirror.example.com')"
+	CmdInitFlagAgentCRDMutations       = "Path to a YAML list of custom resources (group, version, kind, resource) and the JSONPaths of their image and git URL fields for the Zarf agent to rewrite"
 
 	CmdInitFlagGitURL      = "External git server url to use for this Zarf cluster"
 	CmdInitFlagGitPushUser = "Username to access to the git server Zarf is configured to use. User must be able to create repositories via 'git push'"
@@ -210,10 +223,11 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 	CmdInternalCrc32Short = "Generates a decimal CRC32 for the given text"
 
 	// zarf package
-	CmdPackageShort             = "Zarf package commands for creating, deploying, and inspecting packages"
-	CmdPackageFlagConcurrency   = "Number of concurrent layer operations to perform when interacting with a remote package."
-	CmdPackageFlagFlagPublicKey = "Path to public key file for validating signed packages"
-	CmdPackageFlagRetries       = "Number of retries to perform for Zarf deploy operations like git/image pushes or Helm installs"
+	CmdPackageShort               = "Zarf package commands for creating, deploying, and inspecting packages"
+	CmdPackageFlagConcurrency     = "Number of concurrent layer operations to perform when interacting with a remote package."
+	CmdPackageFlagFlagPublicKey   = "Path to public key file for validating signed packages"
+	CmdPackageFlagRetries         = "Number of retries to perform for Zarf deploy operations like git/image pushes or Helm installs"
+	CmdPackageFlagPushConcurrency = "Number of images to push to the Zarf registry at the same time"
 
 	CmdPackageCreateShort = "Creates a Zarf package from a given directory or the current directory"
 	CmdPackageCreateLong  = "Builds an archive of resources and dependencies defined by the 'zarf.yaml' in the specified directory.\n" +
@@ -222,7 +236,17 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 
 	CmdPackageDeployShort = "Deploys a Zarf package from a local file or URL (runs offline)"
 	CmdPackageDeployLong  = "Unpacks resources and dependencies from a Zarf package archive and deploys them onto the target system.\n" +
-		"Kubernetes clusters are accessed via credentials in your current kubecontext defined in '~/.kube/config'"
+		"Kubernetes clusters are accessed via credentials in your current kubecontext defined in '~/.kube/config'\n\n" +
+		"When several packages are provided they are deployed in the order of the dependencies declared in their 'metadata.dependsOn' " +
+		"after a single confirmation, and a combined summary is printed."
+	CmdPackageDeployExample = `
+# Deploy a single package
+$ zarf package deploy zarf-package-apps-amd64-1.0.0.tar.zst
+
+# Deploy a layered platform, packages are reordered to satisfy their dependencies
+$ zarf package deploy oci://ghcr.io/my-org/apps:1.0.0 oci://ghcr.io/my-org/core:1.4.2 --confirm
+`
+	CmdPackageDeployErrMultipleFlags = "--components, --shasum and --dry-run cannot be used when deploying multiple packages"
 
 	CmdPackageMirrorShort = "Mirrors a Zarf package's internal resources to specified image registries and git repositories"
 	CmdPackageMirrorLong  = "Unpacks resources and dependencies from a Zarf package archive and mirrors them into the specified\n" +
@@ -252,6 +276,7 @@ $ zarf package mirror-resources <your-package.tar.zst> \
 
 	CmdPackageListShort         = "Lists out all of the packages that have been deployed to the cluster (runs offline)"
 	CmdPackageListNoPackageWarn = "Unable to get the packages deployed to the cluster"
+	CmdPackageListFlagOutput    = "Output format of the deployed packages (table|json|yaml)"
 
 	CmdPackageCreateFlagConfirm               = "Confirm package creation without prompting"
 	CmdPackageCreateFlagSet                   = "Specify package variables to set on the command line (KEY=value)"
@@ -267,6 +292,7 @@ $ zarf package mirror-resources <your-package.tar.zst> \
 	CmdPackageCreateFlagDifferential          = "[beta] Build a package that only contains the differential changes from local resources and differing remote resources from the specified previously built package"
 	CmdPackageCreateFlagRegistryOverride      = "Specify a map of domains to override on package create when pulling images (e.g. --registry-override docker.io=dockerio-reg.enterprise.intranet)"
 	CmdPackageCreateFlagFlavor                = "The flavor of components to include in the resulting package (i.e. have a matching or empty \"only.flavor\" key)"
+	CmdPackageCreateFlagImageSource           = "The local image sources to load images from, in order, when they cannot be pulled from a registry (docker, podman, containerd), set to an empty list to only pull from registries"
 	CmdPackageCreateCleanPathErr              = "Invalid characters in Zarf cache path, defaulting to %s"
 
 	CmdPackageDeployFlagConfirm                        = "Confirms package deployment without prompting. ONLY use with packages you trust. Skips prompts to review SBOM, configure variables, select optional components and review potential breaking changes."
@@ -276,6 +302,8 @@ $ zarf package mirror-resources <your-package.tar.zst> \
 	CmdPackageDeployFlagShasum                         = "Shasum of the package to deploy. Required if deploying a remote package and \"--insecure\" is not provided"
 	CmdPackageDeployFlagSget                           = "[Deprecated] Path to public sget key file for remote packages signed via cosign. This flag will be removed in v1.0.0 please use the --key flag instead."
 	CmdPackageDeployFlagSkipWebhooks                   = "[alpha] Skip waiting for external webhooks to execute as each package component is deployed"
+	CmdPackageDeployFlagRollbackOnFailure              = "Roll back the Helm charts and package record of every component deployed in this run to the previously deployed package generation if the deployment fails"
+	CmdPackageDeployFlagDryRun                         = "Report the images, repos, namespaces, actions and rendered manifests that a deployment would apply without making any changes to the cluster"
 	CmdPackageDeployFlagTimeout                        = "Timeout for Helm operations such as installs and rollbacks"
 	CmdPackageDeployValidateArchitectureErr            = "this package architecture is %s, but the target cluster only has the %s architecture(s). These architectures must be compatible when \"images\" are present"
 	CmdPackageDeployValidateLastNonBreakingVersionWarn = "The version of this Zarf binary '%s' is less than the LastNonBreakingVersion of '%s'. You may need to upgrade your Zarf version to at least '%s' to deploy this package"
@@ -287,9 +315,11 @@ $ zarf package mirror-resources <your-package.tar.zst> \
 	CmdPackageInspectFlagSbom       = "View SBOM contents while inspecting the package"
 	CmdPackageInspectFlagSbomOut    = "Specify an output directory for the SBOMs from the inspected Zarf package"
 	CmdPackageInspectFlagListImages = "List images in the package (prints to stdout)"
+	CmdPackageInspectFlagOutput     = "Output format of the package definition or image list. The table format prints the package definition as colorized YAML (table|json|yaml)"
 
 	CmdPackageRemoveShort          = "Removes a Zarf package that has been deployed already (runs offline)"
 	CmdPackageRemoveFlagConfirm    = "REQUIRED. Confirm the removal action to prevent accidental deletions"
+	CmdPackageRemoveFlagForce      = "Remove the package even if other deployed packages depend on it"
 	CmdPackageRemoveFlagComponents = "Comma-separated list of components to remove.  This list will be respected regardless of a component's 'required' or 'default' status.  Globbing component names with '*' and deselecting components with a leading '-' are also supported."
 
 	CmdPackagePublishShort   = "Publishes a Zarf package to a remote registry"
@@ -315,6 +345,48 @@ $ zarf package pull oci://ghcr.io/defenseunicorns/packages/dos-games:1.0.0 -a ar
 $ zarf package pull oci://ghcr.io/defenseunicorns/packages/dos-games:1.0.0 -a skeleton`
 	CmdPackagePullFlagOutputDirectory = "Specify the output directory for the pulled Zarf package"
 
+	CmdPackageDiffShort   = "Compares two Zarf packages and reports the differences between them"
+	CmdPackageDiffLong    = "Loads the metadata of an older and a newer Zarf package (from local archives or OCI references) and reports added, removed and changed components, images, repos, charts, files, variables and constants. Images are compared by the digests of their manifests in the packages and chart values files by the digests of their contents, so changes under the same tag or values file path are reported."
+	CmdPackageDiffExample = `
+# Compare two local packages
+$ zarf package diff zarf-package-dos-games-amd64-1.0.0.tar.zst zarf-package-dos-games-amd64-1.1.0.tar.zst
+
+# Compare two published packages and output the report as JSON
+$ zarf package diff oci://ghcr.io/defenseunicorns/packages/dos-games:1.0.0 oci://ghcr.io/defenseunicorns/packages/dos-games:1.1.0 -o json
+
+# Fail an approval gate when the packages differ
+$ zarf package diff zarf-package-dos-games-amd64-1.0.0.tar.zst zarf-package-dos-games-amd64-1.1.0.tar.zst --exit-code`
+	CmdPackageDiffFlagOutput     = "Output format of the report (text or json)"
+	CmdPackageDiffFlagExitCode   = "Exit with a non-zero status when the packages differ, like git diff --exit-code"
+	CmdPackageDiffErrDifferences = "the packages differ"
+
+	CmdPackageHistoryShort = "Lists the recorded generations of a package deployed to the cluster"
+	CmdPackageHistoryLong  = "Lists the generations of a deployed package recorded in the cluster along with the CLI version, components and variables (with sensitive values redacted) of each generation. Up to the last 10 generations are kept."
+
+	CmdPackageRollbackShort   = "Rolls back a package deployed to the cluster to a previous generation"
+	CmdPackageRollbackLong    = "Rolls back the Helm charts of a deployed package to the revisions that were deployed at the given generation and records the result as a new generation, in the same way as 'helm rollback'."
+	CmdPackageRollbackExample = `
+# Roll back a package to the previous generation
+$ zarf package rollback dos-games
+
+# Roll back a package to a specific generation from 'zarf package history'
+$ zarf package rollback dos-games --to 3
+`
+	CmdPackageRollbackFlagTo = "Generation of the package to roll back to (defaults to the previous generation)"
+
+	CmdPackageStatusShort = "Checks a package deployed to the cluster for drift"
+	CmdPackageStatusLong  = "Compares a deployed package with the cluster: its Helm releases must exist and be deployed, the workloads they rendered must be ready " +
+		"and its images must still resolve in the Zarf registry to the digests that pods are running. Exits with a non-zero code when anything has drifted or is missing."
+	CmdPackageStatusExample = `
+# Check a package for drift
+$ zarf package status dos-games
+
+# Output the report as JSON for a monitoring system
+$ zarf package status dos-games -o json
+`
+	CmdPackageStatusFlagOutput = "Output format of the report (table|json|yaml)"
+	CmdPackageStatusUnhealthy  = "package %s has drifted from its deployment"
+
 	CmdPackageChoose                = "Choose or type the package file"
 	CmdPackageClusterSourceFallback = "%q does not satisfy any current sources, assuming it is a package deployed to a cluster"
 	CmdPackageInvalidSource         = "Unable to identify source from %q: %s"
@@ -354,6 +426,7 @@ $ zarf package pull oci://ghcr.io/defenseunicorns/packages/dos-games:1.0.0 -a sk
 	CmdDevFlagFindImagesRegistry   = "Override the ###ZARF_REGISTRY### value"
 	CmdDevFlagFindImagesWhy        = "Prints the source manifest for the specified image"
 	CmdDevFlagFindImagesSkipCosign = "Skip searching for cosign artifacts related to discovered images"
+	CmdDevFlagFindImagesOutput     = "Output format of the found images. The table format prints a zarf.yaml snippet and the json and yaml formats print the images keyed by component name (table|json|yaml)"
 
 	CmdDevLintShort = "Lints the given package for valid schema and recommended practices"
 	CmdDevLintLong  = "Verifies the package schema, checks if any variables won't be evaluated, and checks for unpinned images/repos/files"
@@ -486,6 +559,10 @@ zarf tools yq e '.a.b = "cool"' -i file.yaml
 	CmdToolsClearCacheDir           = "Cache directory set to: %s"
 	CmdToolsClearCacheSuccess       = "Successfully cleared the cache from %s"
 	CmdToolsClearCacheFlagCachePath = "Specify the location of the Zarf artifact cache (images and git repositories)"
+	CmdToolsClearCacheFlagImages    = "Only clear the image cache"
+	CmdToolsClearCacheFlagOlderThan = "Only remove cached image blobs that were not used by a package create within this duration (e.g. 720h), requires --images"
+	CmdToolsClearCacheImagesPruned  = "Removed %d cached image blobs (%s) from %s"
+	CmdToolsClearCacheErrOlderThan  = "--older-than can only be used with --images"
 
 	CmdToolsDownloadInitShort               = "Downloads the init package for the current Zarf version into the specified directory"
 	CmdToolsDownloadInitFlagOutputDirectory = "Specify a directory to place the init package in."
@@ -543,7 +620,12 @@ $ zarf tools get-creds registry-readonly
 $ zarf tools get-creds git
 $ zarf tools get-creds git-readonly
 $ zarf tools get-creds artifact
+
+# Print all Zarf credentials as JSON:
+$ zarf tools get-creds -o json
 `
+	CmdToolsGetCredsFlagOutput      = "Output format of the credentials (table|json|yaml)"
+	CmdToolsGetCredsAgentExpiration = "The Zarf agent TLS certificate expires on %s. Run 'zarf tools rotate agent' to renew it."
 
 	CmdToolsUpdateCredsShort   = "Updates the credentials for deployed Zarf services. Pass a service key to update credentials for a single service"
 	CmdToolsUpdateCredsLong    = "Updates the credentials for deployed Zarf services. Pass a service key to update credentials for a single service. i.e. 'zarf tools update-creds registry'"
@@ -582,6 +664,106 @@ $ zarf tools update-creds artifact --artifact-push-username={USERNAME} --artifac
 	CmdToolsUpdateCredsUnableUpdateAgent    = "Unable to update Zarf Agent TLS secrets: %s"
 	CmdToolsUpdateCredsUnableUpdateCreds    = "Unable to update Zarf credentials"
 
+	CmdToolsRotateShort = "Rotates the generated credentials of deployed Zarf services before they expire"
+	CmdToolsRotateLong  = "Regenerates the internal registry and git server passwords and the Zarf agent TLS certificate when they expire within the '--before' window, " +
+		"then updates all Zarf managed pull secrets, the git server users and the Zarf Helm releases. Pass a service key to only rotate a single service. " +
+		"Use '--schedule' to run the rotation on a schedule with a CronJob in the cluster instead."
+	CmdToolsRotateExample = `
+# Rotate every credential that expires within the next 30 days:
+$ zarf tools rotate
+
+# Rotate the registry passwords now, regardless of their expiration:
+$ zarf tools rotate registry --force
+
+# Check for expiring credentials every Sunday at 03:00 from inside the cluster:
+$ zarf tools rotate --schedule "0 3 * * 0" --confirm
+
+# Remove the scheduled rotation:
+$ zarf tools rotate --unschedule
+`
+	CmdToolsRotateFlagBefore     = "Rotate credentials that expire within this duration"
+	CmdToolsRotateFlagLifetime   = "How long the newly generated registry and git server passwords stay valid, the Zarf agent TLS certificate is always issued for 375 days"
+	CmdToolsRotateFlagForce      = "Rotate the credentials regardless of their expiration"
+	CmdToolsRotateFlagSchedule   = "Cron schedule of a CronJob that rotates the credentials from inside the cluster instead of rotating them now"
+	CmdToolsRotateFlagUnschedule = "Remove the CronJob that rotates the credentials on a schedule"
+	CmdToolsRotateErrInvalidKey  = "invalid service key specified, valid keys are: %s, %s, and %s"
+	CmdToolsRotateNothingDue     = "No credentials expire before %s, nothing to rotate."
+	CmdToolsRotateScheduled      = "Scheduled credential rotation %q in the %s namespace"
+	CmdToolsRotateUnscheduled    = "Removed the scheduled credential rotation"
+
+	// zarf tools state
+	CmdToolsStateShort = "Manages the Zarf state stored in the cluster"
+	CmdToolsStateLong  = "Shows, backs up, restores and edits the Zarf state and the records of the packages deployed to the cluster."
+
+	CmdToolsStateShowShort      = "Prints the Zarf state of the cluster"
+	CmdToolsStateShowFlagOutput = "Output format (yaml|json)"
+	CmdToolsStateShowFlagRedact = "Sanitize the credentials and TLS material in the output"
+
+	CmdToolsStateExportShort = "Backs up the Zarf state and the deployed package records to a file"
+	CmdToolsStateExportLong  = "Writes the Zarf state, the records of all deployed packages and their revision history to FILE, or to stdout when no file is given. " +
+		"Files ending in .json are written as JSON, all others as YAML. The backup contains every Zarf credential in plaintext unless '--redact' is set, " +
+		"and redacted backups, which also sanitize the deploy time variables of the history, cannot be imported."
+	CmdToolsStateExportExample = `
+# Back up the Zarf state of the cluster:
+$ zarf tools state export zarf-state-backup.yaml
+
+# Share the state without its credentials:
+$ zarf tools state export --redact
+`
+	CmdToolsStateExportFlagRedact = "Sanitize the credentials and TLS material in the backup"
+	CmdToolsStateExportSuccess    = "Exported the Zarf state and %d deployed package records to %s"
+
+	CmdToolsStateImportShort = "Restores the Zarf state and the deployed package records from a backup"
+	CmdToolsStateImportLong  = "Validates the backup against the Zarf state schema and restores it. On a cluster without Zarf state the Zarf namespace, " +
+		"the state, the deployed package records and their history are created as is so a later 'zarf init' reuses the restored credentials. " +
+		"On an initialized cluster the credentials from the backup are applied to the registry and git server, the Zarf pull secrets and the Zarf agent, like 'zarf tools update-creds'."
+	CmdToolsStateImportExample = `
+# Restore a backup on a rebuilt cluster:
+$ zarf tools state import zarf-state-backup.yaml --confirm
+`
+	CmdToolsStateImportConfirmFlag = "Confirm restoring the backup over the current Zarf state without prompting"
+	CmdToolsStateImportSuccess     = "Imported the Zarf state and %d deployed package records"
+
+	CmdToolsStateSetShort = "Sets values in the Zarf state"
+	CmdToolsStateSetLong  = "Sets values at their JSON paths in the Zarf state, e.g. 'registryInfo.address'. Values replacing strings are used as is, " +
+		"other values are parsed as YAML, and the result is validated against the Zarf state schema before it is saved. " +
+		"Only the state is changed, use 'zarf tools update-creds' to change credentials that are also configured in the registry and git server."
+	CmdToolsStateSetExample = `
+# Point Zarf at a different storage class:
+$ zarf tools state set storageClass=local-path
+
+# Change the node port of the internal registry:
+$ zarf tools state set registryInfo.nodePort=31888 registryInfo.address=127.0.0.1:31888
+`
+	CmdToolsStateSetConfirmFlag = "Confirm the changes to the Zarf state without prompting"
+	CmdToolsStateSetErrInvalid  = "invalid value %q, values must be set as PATH=VALUE"
+	CmdToolsStateSetConfirm     = "Save the changes to the Zarf state?"
+	CmdToolsStateSetSuccess     = "Updated the Zarf state"
+
+	CmdToolsStateMigrateEncryptionShort = "Encrypts, re-keys or decrypts the sensitive fields of the Zarf state"
+	CmdToolsStateMigrateEncryptionLong  = "Loads the Zarf state with the current key from ZARF_STATE_ENCRYPTION_KEY (or 'state_encryption_key' in the Zarf config) and saves it again " +
+		"encrypted with '--new-key', or in plaintext with '--decrypt'. The new key must be an AWS KMS (awskms://) or HashiCorp Vault transit (hashivault://) key URI. " +
+		"Its URI is stored in the zarf-state-encryption secret so the Zarf agent can read the state, and the agent is restarted to pick it up. " +
+		"Age identities are refused because the agent could only read the state with a copy of the identity stored in the cluster."
+	CmdToolsStateMigrateEncryptionExample = `
+# Encrypt a plaintext state with an AWS KMS key:
+$ zarf tools state migrate-encryption --new-key awskms:///alias/zarf
+
+# Encrypt a plaintext state with a HashiCorp Vault transit key:
+$ VAULT_ADDR=https://vault.example.com VAULT_TOKEN=... zarf tools state migrate-encryption --new-key hashivault://zarf
+
+# Re-key an encrypted state:
+$ ZARF_STATE_ENCRYPTION_KEY=awskms:///alias/zarf zarf tools state migrate-encryption --new-key awskms:///alias/zarf-new
+
+# Store the state in plaintext again:
+$ ZARF_STATE_ENCRYPTION_KEY=awskms:///alias/zarf zarf tools state migrate-encryption --decrypt
+`
+	CmdToolsStateMigrateEncryptionFlagNewKey  = "Key to encrypt the Zarf state with"
+	CmdToolsStateMigrateEncryptionFlagDecrypt = "Store the Zarf state in plaintext"
+	CmdToolsStateMigrateEncryptionErrNoTarget = "either --new-key or --decrypt must be provided"
+	CmdToolsStateMigrateEncryptionEncrypted   = "Encrypted the Zarf state"
+	CmdToolsStateMigrateEncryptionDecrypted   = "Stored the Zarf state in plaintext"
+
 	// zarf version
 	CmdVersionShort = "Shows the version of the running Zarf binary"
 	CmdVersionLong  = "Displays the version of the Zarf release that the current binary was built from."
@@ -601,10 +783,15 @@ const (
 	AgentInfoPort                  = "Server running in port: %s"
 	AgentWarnNotOCIType            = "Skipping HelmRepo mutation because the type is not OCI: %s"
 	AgentWarnSemVerRef             = "Detected a semver OCI ref (%s) - continuing but will be unable to guarantee against collisions if multiple OCI artifacts with the same name are brought in from different registries"
+	AgentWarnImageLookup           = "Unable to check if the image %s exists in the Zarf registry: %s"
+	AgentErrImageSignature         = "image signature verification failed: %s"
+	AgentErrImagesNotInRegistry    = "the following images were not found in the Zarf registry, ensure the package providing them has been deployed: %s"
 	AgentErrBadRequest             = "could not read request body: %s"
 	AgentErrBindHandler            = "Unable to bind the webhook handler"
 	AgentErrCouldNotDeserializeReq = "could not deserialize request: %s"
 	AgentErrParsePod               = "failed to parse pod: %w"
+	AgentErrParseWorkload          = "failed to parse workload: %w"
+	AgentErrCRDFieldPath           = "failed to resolve the field path %s: %w"
 	AgentErrHostnameMatch          = "failed to complete hostname matching: %w"
 	AgentErrInvalidMethod          = "invalid method only POST requests are allowed"
 	AgentErrInvalidOp              = "invalid operation: %s"
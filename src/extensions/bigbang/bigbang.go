@@ -41,6 +41,34 @@ var tenMins = metav1.Duration{
 	Duration: 10 * time.Minute,
 }
 
+// Extension is the Big Bang component extension.
+type Extension struct{}
+
+// Name returns the name of the Big Bang extension.
+func (Extension) Name() string {
+	return bb
+}
+
+// Enabled returns true if the component configures Big Bang.
+func (Extension) Enabled(c v1alpha1.ZarfComponent) bool {
+	return c.Extensions.BigBang != nil
+}
+
+// Run mutates a component that should deploy Big Bang.
+func (Extension) Run(ctx context.Context, YOLO bool, tmpPaths *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error) {
+	return Run(ctx, YOLO, tmpPaths, c)
+}
+
+// Skeletonize mutates a component so that the Big Bang files can be contained inside a skeleton package.
+func (Extension) Skeletonize(tmpPaths *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error) {
+	return Skeletonize(tmpPaths, c)
+}
+
+// Compose merges the Big Bang overrides of an imported component.
+func (Extension) Compose(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponent, relativeTo string) {
+	Compose(c, override, relativeTo)
+}
+
 // Run mutates a component that should deploy Big Bang to a set of manifests
 // that contain the flux deployment of Big Bang
 func Run(ctx context.Context, YOLO bool, tmpPaths *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error) {
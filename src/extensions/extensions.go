@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package extensions contains the registry of component extensions that run when packages are created, published and composed.
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/extensions/bigbang"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+)
+
+// Extension is a component extension that mutates the components configuring it.
+type Extension interface {
+	// Name returns the name of the extension.
+	Name() string
+	// Enabled returns true if the component configures the extension.
+	Enabled(c v1alpha1.ZarfComponent) bool
+	// Run mutates the component during package create.
+	Run(ctx context.Context, YOLO bool, tmpPaths *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error)
+	// Skeletonize mutates the component so that its local files are contained inside a skeleton package during publish.
+	Skeletonize(tmpPaths *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error)
+	// Compose merges the extension configuration of an imported component override into the component, fixing its
+	// local paths to be relative to the provided path.
+	Compose(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponent, relativeTo string)
+}
+
+// Registry is an ordered set of extensions.
+type Registry struct {
+	mu         sync.RWMutex
+	extensions []Extension
+}
+
+// NewRegistry creates a registry holding the given extensions.
+func NewRegistry(extensions ...Extension) (*Registry, error) {
+	r := &Registry{}
+	for _, ext := range extensions {
+		if err := r.Register(ext); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds an extension to the registry, extensions run in the order they are registered.
+func (r *Registry) Register(ext Extension) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.extensions {
+		if existing.Name() == ext.Name() {
+			return fmt.Errorf("extension %s is already registered", ext.Name())
+		}
+	}
+	r.extensions = append(r.extensions, ext)
+	return nil
+}
+
+// Extensions returns the registered extensions.
+func (r *Registry) Extensions() []Extension {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Extension{}, r.extensions...)
+}
+
+// Run runs every extension enabled on the component.
+func (r *Registry) Run(ctx context.Context, YOLO bool, tmpPaths *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error) {
+	for _, ext := range r.Extensions() {
+		if !ext.Enabled(c) {
+			continue
+		}
+		var err error
+		if c, err = ext.Run(ctx, YOLO, tmpPaths, c); err != nil {
+			return c, fmt.Errorf("unable to process %s extension: %w", ext.Name(), err)
+		}
+	}
+	return c, nil
+}
+
+// Skeletonize skeletonizes every extension enabled on the component.
+func (r *Registry) Skeletonize(tmpPaths *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error) {
+	for _, ext := range r.Extensions() {
+		if !ext.Enabled(c) {
+			continue
+		}
+		var err error
+		if c, err = ext.Skeletonize(tmpPaths, c); err != nil {
+			return c, fmt.Errorf("unable to process %s extension: %w", ext.Name(), err)
+		}
+	}
+	return c, nil
+}
+
+// Compose composes the override into the component for every registered extension.
+func (r *Registry) Compose(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponent, relativeTo string) {
+	for _, ext := range r.Extensions() {
+		ext.Compose(c, override, relativeTo)
+	}
+}
+
+var defaultRegistry = &Registry{
+	extensions: []Extension{
+		bigbang.Extension{},
+	},
+}
+
+// Register adds an in-tree extension to the default registry.
+func Register(ext Extension) error {
+	return defaultRegistry.Register(ext)
+}
+
+// Registered returns the extensions in the default registry.
+func Registered() []Extension {
+	return defaultRegistry.Extensions()
+}
+
+// Run runs every extension in the default registry that is enabled on the component.
+func Run(ctx context.Context, YOLO bool, tmpPaths *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error) {
+	return defaultRegistry.Run(ctx, YOLO, tmpPaths, c)
+}
+
+// Skeletonize skeletonizes every extension in the default registry that is enabled on the component.
+func Skeletonize(tmpPaths *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error) {
+	return defaultRegistry.Skeletonize(tmpPaths, c)
+}
+
+// Compose composes the override into the component for every extension in the default registry.
+func Compose(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponent, relativeTo string) {
+	defaultRegistry.Compose(c, override, relativeTo)
+}
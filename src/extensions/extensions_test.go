@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package extensions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+)
+
+type testExtension struct {
+	name string
+	err  error
+}
+
+func (e testExtension) Name() string {
+	return e.name
+}
+
+func (e testExtension) Enabled(c v1alpha1.ZarfComponent) bool {
+	return c.Only.Flavor == e.name
+}
+
+func (e testExtension) Run(_ context.Context, _ bool, _ *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error) {
+	c.Images = append(c.Images, e.name)
+	return c, e.err
+}
+
+func (e testExtension) Skeletonize(_ *layout.ComponentPaths, c v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error) {
+	c.Description = e.name
+	return c, e.err
+}
+
+func (e testExtension) Compose(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponent, relativeTo string) {
+	c.Repos = append(c.Repos, e.name+":"+override.Name+":"+relativeTo)
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRegistry(testExtension{name: "istio"}, testExtension{name: "istio"})
+	require.EqualError(t, err, "extension istio is already registered")
+
+	r, err := NewRegistry(testExtension{name: "istio"}, testExtension{name: "olm", err: errors.New("bad catalog")})
+	require.NoError(t, err)
+	require.Len(t, r.Extensions(), 2)
+
+	c, err := r.Run(context.Background(), false, nil, v1alpha1.ZarfComponent{Only: v1alpha1.ZarfComponentOnlyTarget{Flavor: "istio"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"istio"}, c.Images)
+
+	c, err = r.Skeletonize(nil, v1alpha1.ZarfComponent{})
+	require.NoError(t, err)
+	require.Empty(t, c.Description)
+
+	_, err = r.Run(context.Background(), false, nil, v1alpha1.ZarfComponent{Only: v1alpha1.ZarfComponentOnlyTarget{Flavor: "olm"}})
+	require.EqualError(t, err, "unable to process olm extension: bad catalog")
+
+	c = v1alpha1.ZarfComponent{}
+	r.Compose(&c, v1alpha1.ZarfComponent{Name: "base"}, "imports")
+	require.Equal(t, []string{"istio:base:imports", "olm:base:imports"}, c.Repos)
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	t.Parallel()
+
+	names := []string{}
+	for _, ext := range Registered() {
+		names = append(names, ext.Name())
+	}
+	require.Equal(t, []string{"bigbang"}, names)
+}
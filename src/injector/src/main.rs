@@ -11,13 +11,14 @@ use std::path::PathBuf;
 
 use axum::{
     body::Body,
-    extract::Path,
+    extract::{DefaultBodyLimit, Path},
     http::StatusCode,
     response::{IntoResponse, Response},
-    routing::get,
+    routing::{get, put},
     Router,
 };
 use flate2::read::GzDecoder;
+use futures_util::StreamExt;
 use glob::glob;
 use hex::ToHex;
 use regex_lite::Regex;
@@ -26,6 +27,8 @@ use sha2::{Digest, Sha256};
 use tar::Archive;
 use tokio_util::io::ReaderStream;
 const OCI_MIME_TYPE: &str = "application/vnd.oci.image.manifest.v1+json";
+// The uploaded payload is written to the seed emptyDir as the working directory is not writable
+const PAYLOAD_UPLOAD_PATH: &str = "/zarf-seed/payload.tar.gz";
 
 // Reads the binary contents of a file
 fn get_file(path: &PathBuf) -> io::Result<Vec<u8>> {
@@ -75,23 +78,98 @@ fn unpack(sha_sum: &String) {
     // get a buffer of the final merged file contents
     let contents = collect_binary_data(&file_partials).unwrap();
 
+    extract_payload(&contents, sha_sum).unwrap();
+}
+
+/// Returns true if zarf-payload-* configmaps are mounted in the CWD
+fn has_payload_partials() -> bool {
+    glob("zarf-payload-*")
+        .expect("Failed to read glob pattern")
+        .next()
+        .is_some()
+}
+
+/// Checks the SHA256 hash of the payload tarball, then extracts it to /zarf-seed
+fn extract_payload(contents: &[u8], sha_sum: &str) -> Result<(), String> {
     // create a Sha256 object
     let mut hasher = Sha256::new();
 
     // write input message
-    hasher.update(&contents);
+    hasher.update(contents);
+
+    check_payload_sha(hasher, sha_sum)?;
+    extract_tarball(contents)
+}
 
+/// Compares the SHA256 hash of the payload tarball against the expected hash
+fn check_payload_sha(hasher: Sha256, sha_sum: &str) -> Result<(), String> {
     // read hash digest and consume hasher
     let result = hasher.finalize();
     let result_string = result.encode_hex::<String>();
-    assert_eq!(*sha_sum, result_string);
+    if result_string != sha_sum {
+        return Err(format!(
+            "payload checksum {} does not match {}",
+            result_string, sha_sum
+        ));
+    }
+    Ok(())
+}
 
-    // write the merged file to disk and extract it
-    let tar = GzDecoder::new(&contents[..]);
+/// Extracts the gzipped payload tarball to /zarf-seed
+fn extract_tarball<R: Read>(reader: R) -> Result<(), String> {
+    let tar = GzDecoder::new(reader);
     let mut archive = Archive::new(tar);
     archive
         .unpack("/zarf-seed")
-        .expect("Unable to unarchive the resulting tarball");
+        .map_err(|err| format!("Unable to unarchive the resulting tarball: {}", err))
+}
+
+/// Streams an uploaded payload tarball to disk while hashing it, then checks and extracts it to /zarf-seed
+///
+/// The upload is never held in memory so that seed images larger than the pod memory limit can be injected
+async fn receive_payload(body: Body, sha_sum: &str) -> Result<(), String> {
+    let upload_path = PathBuf::from(PAYLOAD_UPLOAD_PATH);
+    let mut file = File::create(&upload_path)
+        .map_err(|err| format!("Unable to create the payload file: {}", err))?;
+    let mut hasher = Sha256::new();
+    let mut stream = body.into_data_stream();
+    while let Some(chunk) = stream.next().await {
+        let chunk = chunk.map_err(|err| format!("Unable to read the payload upload: {}", err))?;
+        hasher.update(&chunk);
+        file.write_all(&chunk)
+            .map_err(|err| format!("Unable to write the payload file: {}", err))?;
+    }
+    drop(file);
+
+    let result = check_payload_sha(hasher, sha_sum).and_then(|_| {
+        let file = File::open(&upload_path)
+            .map_err(|err| format!("Unable to open the payload file: {}", err))?;
+        extract_tarball(file)
+    });
+    // the seed directory is served as an OCI layout so the upload is not left behind in it
+    fs::remove_file(&upload_path)
+        .map_err(|err| format!("Unable to remove the payload file: {}", err))?;
+    result
+}
+
+/// Accepts the payload tarball over HTTP when no payload configmaps are mounted
+///
+/// Zarf uploads the tarball through a port-forward with a PUT request to /zarf-payload
+fn start_payload_upload(sha_sum: String) -> Router {
+    Router::new()
+        .route(
+            "/zarf-payload",
+            put(move |body: Body| {
+                let sha_sum = sha_sum.clone();
+                async move {
+                    match receive_payload(body, &sha_sum).await {
+                        Ok(()) => StatusCode::CREATED.into_response(),
+                        Err(err) => (StatusCode::BAD_REQUEST, err).into_response(),
+                    }
+                }
+            }),
+        )
+        .layer(DefaultBodyLimit::disable())
 }
 
 /// Starts a static docker compliant registry server that only serves the single image from the CWD
@@ -258,13 +336,19 @@ async fn handle_get_digest(tag: String) -> Response {
 async fn main() {
     let args: Vec<String> = env::args().collect();
 
-    println!("unpacking: {}", args[1]);
     let payload_sha = &args[1];
 
-    unpack(payload_sha);
+    let mut router = start_seed_registry();
+    if has_payload_partials() {
+        println!("unpacking: {}", payload_sha);
+        unpack(payload_sha);
+    } else {
+        println!("waiting for the payload upload: {}", payload_sha);
+        router = router.merge(start_payload_upload(payload_sha.clone()));
+    }
 
     let listener = tokio::net::TcpListener::bind("0.0.0.0:5000").await.unwrap();
     println!("listening on {}", listener.local_addr().unwrap());
-    axum::serve(listener, start_seed_registry()).await.unwrap();
+    axum::serve(listener, router).await.unwrap();
     println!("Usage: {} <sha256sum>", args[1]);
 }
@@ -74,7 +74,14 @@ func mutateApplication(ctx context.Context, r *v1.AdmissionRequest, cluster *clu
 
 	patches := []operations.PatchOperation{}
 
-	if app.Spec.Source != nil {
+	if isNamespaceExcluded(state.AgentExclusions, r.Namespace) {
+		return &operations.Result{
+			Allowed:  true,
+			PatchOps: patches,
+		}, nil
+	}
+
+	if app.Spec.Source != nil && !isRepoURLExcluded(state.AgentExclusions, app.Spec.Source.RepoURL) {
 		patchedURL, err := getPatchedRepoURL(app.Spec.Source.RepoURL, state.GitServer, r)
 		if err != nil {
 			return nil, err
@@ -84,6 +91,9 @@ func mutateApplication(ctx context.Context, r *v1.AdmissionRequest, cluster *clu
 
 	if len(app.Spec.Sources) > 0 {
 		for idx, source := range app.Spec.Sources {
+			if isRepoURLExcluded(state.AgentExclusions, source.RepoURL) {
+				continue
+			}
 			patchedURL, err := getPatchedRepoURL(source.RepoURL, state.GitServer, r)
 			if err != nil {
 				return nil, err
@@ -69,6 +69,14 @@ func mutateRepositorySecret(ctx context.Context, r *v1.AdmissionRequest, cluster
 		return nil, fmt.Errorf("url field not found in argocd repository secret data")
 	}
 
+	if isNamespaceExcluded(state.AgentExclusions, r.Namespace) || isRepoURLExcluded(state.AgentExclusions, string(url)) {
+		message.Debugf("Skipping the excluded repository url (%s)", url)
+		return &operations.Result{
+			Allowed:  true,
+			PatchOps: []operations.PatchOperation{},
+		}, nil
+	}
+
 	var repoCreds RepoCreds
 	repoCreds.URL = string(url)
 
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks contains the mutation hooks for the Zarf agent.
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	v1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// NewCRDMutationHook creates a new instance of the custom resource mutation hook, which rewrites the fields listed
+// for the resource kind in the agent CRD mutations of the ZarfState.
+func NewCRDMutationHook(ctx context.Context, cluster *cluster.Cluster) operations.Hook {
+	return operations.Hook{
+		Create: func(r *v1.AdmissionRequest) (*operations.Result, error) {
+			return mutateCRD(ctx, r, cluster)
+		},
+		Update: func(r *v1.AdmissionRequest) (*operations.Result, error) {
+			return mutateCRD(ctx, r, cluster)
+		},
+	}
+}
+
+// findCRDMutation returns the mutation configured for the kind of the admitted resource.
+func findCRDMutation(mutations []types.AgentCRDMutation, gvk metav1.GroupVersionKind) (types.AgentCRDMutation, bool) {
+	for _, mutation := range mutations {
+		if mutation.Group == gvk.Group && mutation.Version == gvk.Version && mutation.Kind == gvk.Kind {
+			return mutation, true
+		}
+	}
+	return types.AgentCRDMutation{}, false
+}
+
+func mutateCRD(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Cluster) (*operations.Result, error) {
+	state, err := cluster.LoadZarfState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The webhook rules are only updated when the agent is redeployed so they may lag behind the ZarfState
+	mutation, ok := findCRDMutation(state.AgentCRDMutations, r.Kind)
+	if !ok || isNamespaceExcluded(state.AgentExclusions, r.Namespace) {
+		return &operations.Result{
+			Allowed:  true,
+			PatchOps: []operations.PatchOperation{},
+		}, nil
+	}
+
+	var obj any
+	if err := json.Unmarshal(r.Object.Raw, &obj); err != nil {
+		return nil, fmt.Errorf(lang.ErrUnmarshal, err)
+	}
+	var meta metav1.PartialObjectMetadata
+	if err := json.Unmarshal(r.Object.Raw, &meta); err != nil {
+		return nil, fmt.Errorf(lang.ErrUnmarshal, err)
+	}
+
+	var patches []operations.PatchOperation
+
+	for _, fieldPath := range mutation.ImagePaths {
+		fields, err := findStringFields(obj, fieldPath)
+		if err != nil {
+			return nil, fmt.Errorf(lang.AgentErrCRDFieldPath, fieldPath, err)
+		}
+		for _, field := range fields {
+			if isImageExcluded(state.AgentExclusions, field.value) {
+				continue
+			}
+			replacement, err := transform.ImageTransformHost(state.RegistryInfo.Address, field.value)
+			if err != nil {
+				return nil, err
+			}
+			if replacement == field.value {
+				continue
+			}
+			message.Debugf("original %s image (%s) got mutated to (%s)", r.Kind.Kind, field.value, replacement)
+			patches = append(patches, operations.ReplacePatchOperation(field.pointer, replacement))
+		}
+	}
+
+	for _, fieldPath := range mutation.GitURLPaths {
+		fields, err := findStringFields(obj, fieldPath)
+		if err != nil {
+			return nil, fmt.Errorf(lang.AgentErrCRDFieldPath, fieldPath, err)
+		}
+		for _, field := range fields {
+			if isRepoURLExcluded(state.AgentExclusions, field.value) {
+				continue
+			}
+			// Do not mutate a URL that already points at the Zarf git server
+			isPatched, err := helpers.DoHostnamesMatch(state.GitServer.Address, field.value)
+			if err != nil {
+				return nil, fmt.Errorf(lang.AgentErrHostnameMatch, err)
+			}
+			if isPatched {
+				continue
+			}
+			transformedURL, err := transform.GitURL(state.GitServer.Address, field.value, state.GitServer.PushUsername)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", AgentErrTransformGitURL, err)
+			}
+			message.Debugf("original %s git URL (%s) got mutated to (%s)", r.Kind.Kind, field.value, transformedURL.String())
+			patches = append(patches, operations.ReplacePatchOperation(field.pointer, transformedURL.String()))
+		}
+	}
+
+	patches = append(patches, getLabelPatch(meta.Labels))
+
+	return &operations.Result{
+		Allowed:  true,
+		PatchOps: patches,
+	}, nil
+}
+
+// stringField is a string value found in an object along with its JSON pointer.
+type stringField struct {
+	pointer string
+	value   string
+}
+
+// findStringFields returns the string values at the field path in the object. Missing fields are skipped.
+func findStringFields(obj any, fieldPath string) ([]stringField, error) {
+	segments, err := types.ParseFieldPath(fieldPath)
+	if err != nil {
+		return nil, err
+	}
+	var fields []stringField
+	collectStringFields(obj, "", segments, &fields)
+	return fields, nil
+}
+
+func collectStringFields(obj any, pointer string, segments []types.FieldPathSegment, fields *[]stringField) {
+	if len(segments) == 0 {
+		if value, ok := obj.(string); ok {
+			*fields = append(*fields, stringField{pointer: pointer, value: value})
+		}
+		return
+	}
+
+	segment := segments[0]
+	if segment.Index == "" {
+		m, ok := obj.(map[string]any)
+		if !ok {
+			return
+		}
+		if child, ok := m[segment.Key]; ok {
+			escapedKey := strings.ReplaceAll(strings.ReplaceAll(segment.Key, "~", "~0"), "/", "~1")
+			collectStringFields(child, pointer+"/"+escapedKey, segments[1:], fields)
+		}
+		return
+	}
+
+	list, ok := obj.([]any)
+	if !ok {
+		return
+	}
+	for idx, child := range list {
+		if segment.Index != "*" && segment.Index != strconv.Itoa(idx) {
+			continue
+		}
+		collectStringFields(child, fmt.Sprintf("%s/%d", pointer, idx), segments[1:], fields)
+	}
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func createCRDAdmissionRequest(t *testing.T, op v1.Operation, gvk metav1.GroupVersionKind, obj map[string]any) *v1.AdmissionRequest {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	require.NoError(t, err)
+	return &v1.AdmissionRequest{
+		Operation: op,
+		Kind:      gvk,
+		Object: runtime.RawExtension{
+			Raw: raw,
+		},
+	}
+}
+
+func TestFindStringFields(t *testing.T) {
+	t.Parallel()
+
+	var obj any
+	err := json.Unmarshal([]byte(`{
+		"metadata": {"annotations": {"zarf/image": "nginx"}},
+		"spec": {
+			"steps": [{"image": "alpine"}, {"name": "no-image"}, {"image": "busybox"}],
+			"matrix": [["a", "b"], ["c"]],
+			"source": {"url": "https://github.com/stefanprodan/podinfo.git"}
+		}
+	}`), &obj)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		fieldPath   string
+		expected    []stringField
+		errContains string
+	}{
+		{
+			name:      "list wildcard",
+			fieldPath: ".spec.steps[*].image",
+			expected: []stringField{
+				{pointer: "/spec/steps/0/image", value: "alpine"},
+				{pointer: "/spec/steps/2/image", value: "busybox"},
+			},
+		},
+		{
+			name:      "list index with braces",
+			fieldPath: "{.spec.steps[2].image}",
+			expected:  []stringField{{pointer: "/spec/steps/2/image", value: "busybox"}},
+		},
+		{
+			name:      "nested lists",
+			fieldPath: "$.spec.matrix[*][0]",
+			expected: []stringField{
+				{pointer: "/spec/matrix/0/0", value: "a"},
+				{pointer: "/spec/matrix/1/0", value: "c"},
+			},
+		},
+		{
+			name:      "key with a slash",
+			fieldPath: ".metadata.annotations.zarf/image",
+			expected:  []stringField{{pointer: "/metadata/annotations/zarf~1image", value: "nginx"}},
+		},
+		{
+			name:      "map field",
+			fieldPath: ".spec.source.url",
+			expected:  []stringField{{pointer: "/spec/source/url", value: "https://github.com/stefanprodan/podinfo.git"}},
+		},
+		{
+			name:      "missing field",
+			fieldPath: ".spec.missing[*].image",
+			expected:  nil,
+		},
+		{
+			name:        "relative path",
+			fieldPath:   "spec.source.url",
+			errContains: "field paths must start with '.'",
+		},
+		{
+			name:        "bad selector",
+			fieldPath:   ".spec.steps[first].image",
+			errContains: "invalid list selector [first]",
+		},
+		{
+			name:        "unterminated selector",
+			fieldPath:   ".spec.steps[0.image",
+			errContains: "unterminated list selector",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fields, err := findStringFields(obj, tt.fieldPath)
+			if tt.errContains != "" {
+				require.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, fields)
+		})
+	}
+}
+
+func TestCRDMutationWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	taskGVK := metav1.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: "Task"}
+	state := &types.ZarfState{
+		RegistryInfo: types.RegistryInfo{Address: "127.0.0.1:31999"},
+		GitServer: types.GitServerInfo{
+			Address:      "https://git-server.com",
+			PushUsername: "a-push-user",
+		},
+		AgentCRDMutations: []types.AgentCRDMutation{
+			{
+				Group:       taskGVK.Group,
+				Version:     taskGVK.Version,
+				Kind:        taskGVK.Kind,
+				Resource:    "tasks",
+				ImagePaths:  []string{".spec.steps[*].image"},
+				GitURLPaths: []string{".spec.params[*].default"},
+			},
+		},
+	}
+	c := createTestClientWithZarfState(ctx, t, state)
+	handler := admission.NewHandler().Serve(NewCRDMutationHook(ctx, c))
+
+	tests := []admissionTest{
+		{
+			name: "configured custom resource should be mutated",
+			admissionReq: createCRDAdmissionRequest(t, v1.Create, taskGVK, map[string]any{
+				"metadata": map[string]any{"name": "build", "labels": map[string]string{"app": "build"}},
+				"spec": map[string]any{
+					"steps": []map[string]any{
+						{"name": "clone", "image": "alpine/git:v2.45.2"},
+						{"name": "done", "image": "127.0.0.1:31999/library/busybox:latest-zarf-2140033595"},
+					},
+					"params": []map[string]any{
+						{"name": "repo", "default": "https://github.com/stefanprodan/podinfo.git"},
+						{"name": "mirrored", "default": "https://git-server.com/a-push-user/podinfo-1646971829.git"},
+					},
+				},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation(
+					"/spec/steps/0/image",
+					"127.0.0.1:31999/alpine/git:v2.45.2-zarf-2739568766",
+				),
+				operations.ReplacePatchOperation(
+					"/spec/params/0/default",
+					"https://git-server.com/a-push-user/podinfo-1646971829.git",
+				),
+				operations.ReplacePatchOperation(
+					"/metadata/labels",
+					map[string]string{"app": "build", "zarf-agent": "patched"},
+				),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "unconfigured custom resource should not be mutated",
+			admissionReq: createCRDAdmissionRequest(t, v1.Create, metav1.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: "Pipeline"}, map[string]any{
+				"spec": map[string]any{"steps": []map[string]any{{"image": "alpine"}}},
+			}),
+			patch: nil,
+			code:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+			verifyAdmission(t, rr, tt)
+		})
+	}
+}
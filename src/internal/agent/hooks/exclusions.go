@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks contains the mutation hooks for the Zarf agent.
+package hooks
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// isNamespaceExcluded returns whether the agent leaves the resources in the namespace untouched.
+func isNamespaceExcluded(rules types.AgentExclusionRules, namespace string) bool {
+	for _, pattern := range rules.Namespaces {
+		if match, err := path.Match(pattern, namespace); err == nil && match {
+			return true
+		}
+	}
+	return false
+}
+
+// isImageExcluded returns whether the agent leaves the image untouched. Image patterns are matched against both the
+// image as written and its fully qualified reference so that "nginx" and "docker.io/library/nginx:latest" match alike.
+func isImageExcluded(rules types.AgentExclusionRules, image string) bool {
+	refs := []string{image}
+	if ref, err := transform.ParseImageRef(image); err == nil {
+		refs = append(refs, ref.Reference)
+		for _, registry := range rules.Registries {
+			if hasRegistryPrefix(ref.Name, registry) {
+				return true
+			}
+		}
+	}
+	for _, pattern := range rules.Images {
+		for _, ref := range refs {
+			if globMatch(pattern, ref) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isRepoURLExcluded returns whether a git, Helm or OCI repository URL points at a registry considered internal.
+func isRepoURLExcluded(rules types.AgentExclusionRules, repoURL string) bool {
+	location := strings.TrimPrefix(repoURL, helpers.OCIURLPrefix)
+	if parsed, err := url.Parse(repoURL); err == nil && parsed.Host != "" {
+		location = parsed.Host + parsed.Path
+	}
+	for _, registry := range rules.Registries {
+		if hasRegistryPrefix(location, registry) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRegistryPrefix returns whether the location is on the registry, which may include a path prefix.
+func hasRegistryPrefix(location, registry string) bool {
+	registry = strings.TrimSuffix(registry, "/")
+	return registry != "" && (location == registry || strings.HasPrefix(location, registry+"/"))
+}
+
+// globMatch matches a glob pattern where '*' matches any sequence of characters, including '/'.
+func globMatch(pattern, s string) bool {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	match, err := regexp.MatchString("^"+expr+"$", s)
+	return err == nil && match
+}
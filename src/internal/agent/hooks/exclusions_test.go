@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	flux "github.com/fluxcd/source-controller/api/v1"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestAgentExclusionRules(t *testing.T) {
+	t.Parallel()
+
+	rules := types.AgentExclusionRules{
+		Namespaces: []string{"team-*", "legacy"},
+		Images:     []string{"docker.io/library/busybox:*", "ghcr.io/site/*"},
+		Registries: []string{"mirror.example.com", "registry.example.com/approved/"},
+	}
+
+	namespaceTests := map[string]bool{
+		"team-a":      true,
+		"legacy":      true,
+		"legacy-apps": false,
+		"default":     false,
+	}
+	for namespace, expected := range namespaceTests {
+		require.Equal(t, expected, isNamespaceExcluded(rules, namespace), namespace)
+	}
+
+	imageTests := map[string]bool{
+		"busybox":                    true,
+		"busybox:1.36":               true,
+		"nginx":                      false,
+		"ghcr.io/site/nested/app:v1": true,
+		"ghcr.io/other/app:v1":       false,
+		"mirror.example.com/library/nginx:latest":   true,
+		"mirror.example.com:5000/nginx":             false,
+		"registry.example.com/approved/nginx:1.0.0": true,
+		"registry.example.com/other/nginx:1.0.0":    false,
+	}
+	for image, expected := range imageTests {
+		require.Equal(t, expected, isImageExcluded(rules, image), image)
+	}
+
+	repoURLTests := map[string]bool{
+		"https://mirror.example.com/org/repo.git":            true,
+		"oci://registry.example.com/approved/charts/podinfo": true,
+		"oci://registry.example.com/charts/podinfo":          false,
+		"https://github.com/stefanprodan/podinfo.git":        false,
+	}
+	for repoURL, expected := range repoURLTests {
+		require.Equal(t, expected, isRepoURLExcluded(rules, repoURL), repoURL)
+	}
+}
+
+func TestPodMutationWebhookExclusions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	state := &types.ZarfState{
+		RegistryInfo: types.RegistryInfo{Address: "127.0.0.1:31999"},
+		AgentExclusions: types.AgentExclusionRules{
+			Namespaces: []string{"legacy-*"},
+			Registries: []string{"mirror.example.com"},
+		},
+	}
+	c := createTestClientWithZarfState(ctx, t, state)
+	handler := admission.NewHandler().Serve(NewPodMutationHook(ctx, c))
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "nginx", Image: "nginx"},
+				{Name: "mirrored", Image: "mirror.example.com/library/busybox:1.36"},
+			},
+		},
+	}
+	excludedNamespaceReq := createPodAdmissionRequest(t, v1.Create, pod)
+	excludedNamespaceReq.Namespace = "legacy-apps"
+	excludedImagesPod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "mirror-credentials"}},
+			Containers: []corev1.Container{
+				{Name: "mirrored", Image: "mirror.example.com/library/busybox:1.36"},
+			},
+		},
+	}
+
+	tests := []admissionTest{
+		{
+			name:         "only images outside the internal registries should be mutated",
+			admissionReq: createPodAdmissionRequest(t, v1.Create, pod),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation(
+					"/spec/imagePullSecrets",
+					[]corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}},
+				),
+				operations.ReplacePatchOperation(
+					"/spec/containers/0/image",
+					"127.0.0.1:31999/library/nginx:latest-zarf-3793515731",
+				),
+				operations.ReplacePatchOperation(
+					"/metadata/labels",
+					map[string]string{"zarf-agent": "patched"},
+				),
+				operations.ReplacePatchOperation(
+					"/metadata/annotations",
+					map[string]string{"zarf.dev/original-image-nginx": "nginx"},
+				),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:         "pods with only excluded images should keep their image pull secrets",
+			admissionReq: createPodAdmissionRequest(t, v1.Create, excludedImagesPod),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation(
+					"/metadata/labels",
+					map[string]string{"zarf-agent": "patched"},
+				),
+				operations.ReplacePatchOperation(
+					"/metadata/annotations",
+					map[string]string{},
+				),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:         "pods in an excluded namespace should not be mutated",
+			admissionReq: excludedNamespaceReq,
+			patch:        nil,
+			code:         http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+			verifyAdmission(t, rr, tt)
+		})
+	}
+}
+
+func TestFluxMutationWebhookExclusions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	state := &types.ZarfState{
+		GitServer: types.GitServerInfo{
+			Address:      "https://git-server.com",
+			PushUsername: "a-push-user",
+		},
+		AgentExclusions: types.AgentExclusionRules{
+			Registries: []string{"git.example.com"},
+		},
+	}
+	c := createTestClientWithZarfState(ctx, t, state)
+	handler := admission.NewHandler().Serve(NewGitRepositoryMutationHook(ctx, c))
+
+	req := createFluxGitRepoAdmissionRequest(t, v1.Create, &flux.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "mirrored"},
+		Spec: flux.GitRepositorySpec{
+			URL: "https://git.example.com/stefanprodan/podinfo.git",
+		},
+	})
+	rr := sendAdmissionRequest(t, req, handler)
+	verifyAdmission(t, rr, admissionTest{code: http.StatusOK})
+}
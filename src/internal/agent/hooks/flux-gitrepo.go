@@ -58,6 +58,14 @@ func mutateGitRepo(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster
 		return nil, fmt.Errorf(lang.ErrUnmarshal, err)
 	}
 
+	if isNamespaceExcluded(state.AgentExclusions, r.Namespace) || isRepoURLExcluded(state.AgentExclusions, repo.Spec.URL) {
+		message.Debugf("Skipping the excluded git URL (%s)", repo.Spec.URL)
+		return &operations.Result{
+			Allowed:  true,
+			PatchOps: []operations.PatchOperation{},
+		}, nil
+	}
+
 	// Check if this is an update operation and the hostname is different from what we have in the zarfState
 	// NOTE: We mutate on updates IF AND ONLY IF the hostname in the request is different than the hostname in the zarfState
 	// NOTE: We are checking if the hostname is different before because we do not want to potentially mutate a URL that has already been mutated.
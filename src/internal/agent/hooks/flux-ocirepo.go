@@ -62,6 +62,14 @@ func mutateOCIRepo(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster
 		return nil, err
 	}
 
+	if isNamespaceExcluded(zarfState.AgentExclusions, r.Namespace) || isRepoURLExcluded(zarfState.AgentExclusions, src.Spec.URL) {
+		message.Debugf("Skipping the excluded OCIRepo URL (%s)", src.Spec.URL)
+		return &operations.Result{
+			Allowed:  true,
+			PatchOps: []operations.PatchOperation{},
+		}, nil
+	}
+
 	// Get the registry service info if this is a NodePort service to use the internal kube-dns
 	registryAddress, err := cluster.GetServiceInfoFromRegistryAddress(ctx, zarfState.RegistryInfo.Address)
 	if err != nil {
@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks contains the mutation hooks for the Zarf agent.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	v1 "k8s.io/api/admission/v1"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/internal/packager/images"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+const (
+	// imageCacheTTL is how long an image that passed a registry check is trusted before it is checked again.
+	imageCacheTTL = 5 * time.Minute
+	// imageCacheSize is the number of images remembered by an image cache.
+	imageCacheSize = 1024
+	// imageLookupTimeout bounds the registry lookups of a single admission request, it is kept below the timeoutSeconds
+	// of the webhooks so that an unreachable registry fails open instead of timing out the request.
+	imageLookupTimeout = 5 * time.Second
+)
+
+// imageCache remembers the images that passed a check against the Zarf registry so that every admission request does
+// not need to query the registry. Failed checks are never cached so that images are admitted as soon as they are fixed.
+type imageCache struct {
+	mu     sync.Mutex
+	size   int
+	passed map[string]time.Time
+}
+
+func newImageCache() *imageCache {
+	return &imageCache{size: imageCacheSize, passed: map[string]time.Time{}}
+}
+
+func (c *imageCache) has(image string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	passedAt, ok := c.passed[image]
+	return ok && time.Since(passedAt) < imageCacheTTL
+}
+
+// add remembers an image, evicting expired images and then the oldest images when the cache is full.
+func (c *imageCache) add(image string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.passed[image] = time.Now()
+	if len(c.passed) <= c.size {
+		return
+	}
+	for cached, passedAt := range c.passed {
+		if time.Since(passedAt) >= imageCacheTTL {
+			delete(c.passed, cached)
+		}
+	}
+	for len(c.passed) > c.size {
+		oldest := ""
+		for cached, passedAt := range c.passed {
+			if oldest == "" || passedAt.Before(c.passed[oldest]) {
+				oldest = cached
+			}
+		}
+		delete(c.passed, oldest)
+	}
+}
+
+// NewPodImageValidationHook creates a new instance of the pod image validation hook.
+// When warnOnly is set pods with images missing from the registry are admitted with a warning instead of rejected.
+func NewPodImageValidationHook(ctx context.Context, cluster *cluster.Cluster, warnOnly bool) operations.Hook {
+	cache := newImageCache()
+	return operations.Hook{
+		Create: func(r *v1.AdmissionRequest) (*operations.Result, error) {
+			return validatePodImages(ctx, r, cluster, cache, warnOnly)
+		},
+		Update: func(r *v1.AdmissionRequest) (*operations.Result, error) {
+			return validatePodImages(ctx, r, cluster, cache, warnOnly)
+		},
+	}
+}
+
+func validatePodImages(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster, cache *imageCache, warnOnly bool) (*operations.Result, error) {
+	pod, err := parsePod(r.Object.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(lang.AgentErrParsePod, err)
+	}
+
+	// The registry itself runs in the Zarf namespace and cannot be required to contain its own image
+	if r.Namespace == cluster.ZarfNamespaceName {
+		return &operations.Result{Allowed: true}, nil
+	}
+
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registryURL := state.RegistryInfo.Address
+
+	if isNamespaceExcluded(state.AgentExclusions, r.Namespace) {
+		return &operations.Result{Allowed: true}, nil
+	}
+
+	podImages := []string{}
+	for _, container := range pod.Spec.InitContainers {
+		podImages = append(podImages, container.Image)
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		podImages = append(podImages, container.Image)
+	}
+	for _, container := range pod.Spec.Containers {
+		podImages = append(podImages, container.Image)
+	}
+
+	uncheckedImages := []string{}
+	for _, image := range helpers.Unique(podImages) {
+		// Excluded images were not redirected to the Zarf registry so they are not expected to be in it
+		if isImageExcluded(state.AgentExclusions, image) {
+			continue
+		}
+		// The mutating webhook has already run so this returns the image unchanged unless it was skipped
+		transformedImage, err := transform.ImageTransformHost(registryURL, image)
+		if err != nil {
+			return nil, err
+		}
+		if !cache.has(transformedImage) {
+			uncheckedImages = append(uncheckedImages, transformedImage)
+		}
+	}
+	if len(uncheckedImages) == 0 {
+		return &operations.Result{Allowed: true}, nil
+	}
+
+	// The agent cannot reach a NodePort on localhost so the registry is looked up through its service instead
+	registryAddress, inCluster, err := c.InClusterRegistryAddress(ctx, registryURL)
+	if err != nil {
+		return nil, err
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, imageLookupTimeout)
+	defer cancel()
+	opts := []crane.Option{crane.WithContext(lookupCtx), images.WithPullAuth(state.RegistryInfo)}
+	if inCluster {
+		opts = append(opts, crane.Insecure)
+	}
+
+	missingImages := []string{}
+	warnings := []string{}
+	for _, image := range uncheckedImages {
+		lookupImage := strings.Replace(image, registryURL, registryAddress, 1)
+		exists, err := registryImageExists(lookupImage, opts...)
+		if err != nil {
+			// Fail open so that an unavailable registry does not block every pod in the cluster
+			message.Warnf(lang.AgentWarnImageLookup, image, err)
+			warnings = append(warnings, fmt.Sprintf(lang.AgentWarnImageLookup, image, err))
+			continue
+		}
+		if !exists {
+			missingImages = append(missingImages, image)
+			continue
+		}
+		cache.add(image)
+	}
+
+	if len(missingImages) == 0 {
+		return &operations.Result{Allowed: true, Warnings: warnings}, nil
+	}
+	msg := fmt.Sprintf(lang.AgentErrImagesNotInRegistry, strings.Join(missingImages, ", "))
+	if warnOnly {
+		message.Warn(msg)
+		return &operations.Result{Allowed: true, Warnings: append(warnings, msg)}, nil
+	}
+	return &operations.Result{Allowed: false, Msg: msg, Warnings: warnings}, nil
+}
+
+// registryImageExists returns whether the manifest for the given image reference exists in its registry.
+func registryImageExists(image string, opts ...crane.Option) (bool, error) {
+	_, err := crane.Head(image, opts...)
+	if err == nil {
+		return true, nil
+	}
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return false, err
+}
+
+// verifyImageSignatures resolves the given images in the Zarf registry to their digests and verifies the cosign
+// signatures of those digests against the public key in the Zarf state. It returns the digest reference of each verified
+// image so that the pod runs exactly what was verified, and a description of each image that failed verification.
+func verifyImageSignatures(ctx context.Context, c *cluster.Cluster, state *types.ZarfState, podImages []string, verified *imageCache) (map[string]string, []string, error) {
+	registryURL := state.RegistryInfo.Address
+	registryAddress, inCluster, err := c.InClusterRegistryAddress(ctx, registryURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, imageLookupTimeout)
+	defer cancel()
+	auth := images.PullAuth(state.RegistryInfo)
+	opts := []crane.Option{crane.WithContext(lookupCtx), crane.WithAuth(auth)}
+	if inCluster {
+		opts = append(opts, crane.Insecure)
+	}
+
+	digestImages := map[string]string{}
+	failures := []string{}
+	for _, image := range helpers.Unique(podImages) {
+		lookupImage := strings.Replace(image, registryURL, registryAddress, 1)
+		digest, err := crane.Digest(lookupImage, opts...)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", image, err))
+			continue
+		}
+		digestImage, err := imageDigestReference(image, digest)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !verified.has(digestImage) {
+			lookupImage := strings.Replace(digestImage, registryURL, registryAddress, 1)
+			err := utils.CosignVerifyImage(lookupCtx, lookupImage, []byte(state.ImageVerification.PublicKey), inCluster, auth)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %s", image, err))
+				continue
+			}
+			verified.add(digestImage)
+		}
+		digestImages[image] = digestImage
+	}
+	return digestImages, failures, nil
+}
+
+// imageDigestReference returns the reference to the given digest in the repository of the given image.
+func imageDigestReference(image, digest string) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	return ref.Context().Digest(digest).String(), nil
+}
@@ -0,0 +1,398 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/cosign/v2/pkg/oci/mutate"
+	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	"github.com/sigstore/cosign/v2/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestPodImageValidationWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+	registryAddress := strings.TrimPrefix(srv.URL, "http://")
+
+	img, err := random.Image(256, 1)
+	require.NoError(t, err)
+	err = crane.Push(img, fmt.Sprintf("%s/library/nginx:latest-zarf-3793515731", registryAddress))
+	require.NoError(t, err)
+
+	state := &types.ZarfState{RegistryInfo: types.RegistryInfo{Address: registryAddress}}
+	c := createTestClientWithZarfState(ctx, t, state)
+
+	tests := []struct {
+		name            string
+		warnOnly        bool
+		namespace       string
+		image           string
+		expectedAllowed bool
+		expectedMsg     string
+		expectedWarning string
+	}{
+		{
+			name:            "image in registry is allowed",
+			namespace:       "default",
+			image:           "nginx",
+			expectedAllowed: true,
+		},
+		{
+			name:            "mutated image in registry is allowed",
+			namespace:       "default",
+			image:           fmt.Sprintf("%s/library/nginx:latest-zarf-3793515731", registryAddress),
+			expectedAllowed: true,
+		},
+		{
+			name:            "image missing from registry is rejected",
+			namespace:       "default",
+			image:           "busybox",
+			expectedAllowed: false,
+			expectedMsg:     "library/busybox:latest-zarf-2140033595",
+		},
+		{
+			name:            "image missing from registry is allowed with a warning",
+			warnOnly:        true,
+			namespace:       "default",
+			image:           "busybox",
+			expectedAllowed: true,
+			expectedWarning: "library/busybox:latest-zarf-2140033595",
+		},
+		{
+			name:            "pods in the zarf namespace are allowed",
+			namespace:       cluster.ZarfNamespaceName,
+			image:           "busybox",
+			expectedAllowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := admission.NewHandler().Serve(NewPodImageValidationHook(ctx, c, tt.warnOnly))
+			req := createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: tt.namespace},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: tt.image}},
+				},
+			})
+			req.Namespace = tt.namespace
+			rr := sendAdmissionRequest(t, req, handler)
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var review v1.AdmissionReview
+			err := json.NewDecoder(rr.Body).Decode(&review)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedAllowed, review.Response.Allowed)
+			require.Empty(t, review.Response.Patch)
+			if tt.expectedMsg != "" {
+				require.Contains(t, review.Response.Result.Message, tt.expectedMsg)
+			}
+			if tt.expectedWarning != "" {
+				require.Len(t, review.Response.Warnings, 1)
+				require.Contains(t, review.Response.Warnings[0], tt.expectedWarning)
+			} else {
+				require.Empty(t, review.Response.Warnings)
+			}
+		})
+	}
+}
+
+func TestPodImageValidationWebhookLookupTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// A registry that never answers must not hold the admission request past the webhook timeout
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+	registryAddress := strings.TrimPrefix(srv.URL, "http://")
+
+	state := &types.ZarfState{RegistryInfo: types.RegistryInfo{Address: registryAddress}}
+	c := createTestClientWithZarfState(ctx, t, state)
+
+	handler := admission.NewHandler().Serve(NewPodImageValidationHook(ctx, c, false))
+	req := createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "nginx"}},
+		},
+	})
+	req.Namespace = "default"
+	start := time.Now()
+	rr := sendAdmissionRequest(t, req, handler)
+	require.Less(t, time.Since(start), imageLookupTimeout+5*time.Second)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var review v1.AdmissionReview
+	err := json.NewDecoder(rr.Body).Decode(&review)
+	require.NoError(t, err)
+	require.True(t, review.Response.Allowed)
+	require.Len(t, review.Response.Warnings, 1)
+	require.Contains(t, review.Response.Warnings[0], "library/nginx:latest-zarf-3793515731")
+}
+
+func TestImageCache(t *testing.T) {
+	t.Parallel()
+
+	cache := newImageCache()
+	cache.size = 2
+	cache.add("a")
+	cache.add("b")
+	require.True(t, cache.has("a"))
+	require.True(t, cache.has("b"))
+
+	// The oldest image is evicted when the cache is full
+	cache.passed["a"] = time.Now().Add(-time.Minute)
+	cache.add("c")
+	require.Len(t, cache.passed, 2)
+	require.False(t, cache.has("a"))
+	require.True(t, cache.has("b"))
+	require.True(t, cache.has("c"))
+
+	// Expired images are evicted before any image that is still valid
+	cache.passed["b"] = time.Now().Add(-imageCacheTTL)
+	cache.passed["c"] = time.Now().Add(-2 * imageCacheTTL)
+	cache.add("d")
+	require.Len(t, cache.passed, 1)
+	require.True(t, cache.has("d"))
+	require.False(t, cache.has("b"))
+}
+
+// pushSignedImage pushes a random image to the given reference along with a cosign signature from the signer and
+// returns the digest of the image.
+func pushSignedImage(t *testing.T, ref string, signer signature.Signer) string {
+	t.Helper()
+
+	img, err := random.Image(256, 1)
+	require.NoError(t, err)
+	err = crane.Push(img, ref)
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	if signer == nil {
+		return digest.String()
+	}
+
+	digestRef, err := name.NewDigest(fmt.Sprintf("%s@%s", strings.Split(ref, ":latest")[0], digest))
+	require.NoError(t, err)
+	sigPayload, err := (&payload.Cosign{Image: digestRef}).MarshalJSON()
+	require.NoError(t, err)
+	sig, err := signer.SignMessage(bytes.NewReader(sigPayload))
+	require.NoError(t, err)
+	ociSig, err := static.NewSignature(sigPayload, base64.StdEncoding.EncodeToString(sig))
+	require.NoError(t, err)
+	se, err := ociremote.SignedEntity(digestRef)
+	require.NoError(t, err)
+	se, err = mutate.AttachSignatureToEntity(se, ociSig)
+	require.NoError(t, err)
+	err = ociremote.WriteSignatures(digestRef.Repository, se)
+	require.NoError(t, err)
+	return digest.String()
+}
+
+func TestPodMutationWebhookImageVerification(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+	registryAddress := strings.TrimPrefix(srv.URL, "http://")
+
+	passFunc := func(bool) ([]byte, error) { return []byte("password"), nil }
+	keys, err := cosign.GenerateKeyPair(passFunc)
+	require.NoError(t, err)
+	signer, err := cosign.LoadPrivateKey(keys.PrivateBytes, []byte("password"))
+	require.NoError(t, err)
+	otherKeys, err := cosign.GenerateKeyPair(passFunc)
+	require.NoError(t, err)
+	otherSigner, err := cosign.LoadPrivateKey(otherKeys.PrivateBytes, []byte("password"))
+	require.NoError(t, err)
+
+	nginxDigest := pushSignedImage(t, fmt.Sprintf("%s/library/nginx:latest-zarf-3793515731", registryAddress), signer)
+	pushSignedImage(t, fmt.Sprintf("%s/library/busybox:latest-zarf-2140033595", registryAddress), nil)
+	pushSignedImage(t, fmt.Sprintf("%s/library/alpine:latest-zarf-1117969859", registryAddress), otherSigner)
+
+	tests := []struct {
+		name            string
+		mode            string
+		namespace       string
+		image           string
+		expectedAllowed bool
+		expectedImage   string
+		expectedMsg     string
+		expectedWarning string
+	}{
+		{
+			name:            "signed image is allowed and pinned to its digest",
+			mode:            types.ImageVerificationEnforce,
+			image:           "nginx",
+			expectedAllowed: true,
+			expectedImage:   fmt.Sprintf("%s/library/nginx@%s", registryAddress, nginxDigest),
+		},
+		{
+			name:            "unsigned image is rejected",
+			mode:            types.ImageVerificationEnforce,
+			image:           "busybox",
+			expectedAllowed: false,
+			expectedMsg:     "image signature verification failed: " + registryAddress + "/library/busybox:latest-zarf-2140033595",
+		},
+		{
+			name:            "image signed with another key is rejected",
+			mode:            types.ImageVerificationEnforce,
+			image:           "alpine",
+			expectedAllowed: false,
+			expectedMsg:     "image signature verification failed: " + registryAddress + "/library/alpine:latest-zarf-1117969859",
+		},
+		{
+			name:            "unsigned image is allowed with a warning",
+			mode:            types.ImageVerificationWarn,
+			image:           "busybox",
+			expectedAllowed: true,
+			expectedImage:   registryAddress + "/library/busybox:latest-zarf-2140033595",
+			expectedWarning: "image signature verification failed: " + registryAddress + "/library/busybox:latest-zarf-2140033595",
+		},
+		{
+			name:            "image missing from registry is rejected",
+			mode:            types.ImageVerificationEnforce,
+			image:           "redis",
+			expectedAllowed: false,
+			expectedMsg:     "image signature verification failed: " + registryAddress + "/library/redis:latest-zarf-",
+		},
+		{
+			name:            "pods in the zarf namespace are not verified",
+			mode:            types.ImageVerificationEnforce,
+			namespace:       cluster.ZarfNamespaceName,
+			image:           "busybox",
+			expectedAllowed: true,
+			expectedImage:   registryAddress + "/library/busybox:latest-zarf-2140033595",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := &types.ZarfState{
+				RegistryInfo: types.RegistryInfo{Address: registryAddress},
+				ImageVerification: types.ImageVerificationPolicy{
+					PublicKey: string(keys.PublicBytes),
+					Mode:      tt.mode,
+				},
+			}
+			c := createTestClientWithZarfState(ctx, t, state)
+			handler := admission.NewHandler().Serve(NewPodMutationHook(ctx, c))
+			req := createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: tt.image}},
+				},
+			})
+			req.Namespace = tt.namespace
+			rr := sendAdmissionRequest(t, req, handler)
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var review v1.AdmissionReview
+			err := json.NewDecoder(rr.Body).Decode(&review)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedAllowed, review.Response.Allowed)
+			if tt.expectedMsg != "" {
+				require.Contains(t, review.Response.Result.Message, tt.expectedMsg)
+				require.Empty(t, review.Response.Patch)
+			}
+			if tt.expectedAllowed {
+				var patches []operations.PatchOperation
+				err := json.Unmarshal(review.Response.Patch, &patches)
+				require.NoError(t, err)
+				require.Contains(t, patches, operations.ReplacePatchOperation("/spec/containers/0/image", tt.expectedImage))
+			}
+			if tt.expectedWarning != "" {
+				require.Len(t, review.Response.Warnings, 1)
+				require.Contains(t, review.Response.Warnings[0], tt.expectedWarning)
+			} else {
+				require.Empty(t, review.Response.Warnings)
+			}
+		})
+	}
+}
+
+func TestPodMutationWebhookImageVerificationMovedTag(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+	registryAddress := strings.TrimPrefix(srv.URL, "http://")
+
+	passFunc := func(bool) ([]byte, error) { return []byte("password"), nil }
+	keys, err := cosign.GenerateKeyPair(passFunc)
+	require.NoError(t, err)
+	signer, err := cosign.LoadPrivateKey(keys.PrivateBytes, []byte("password"))
+	require.NoError(t, err)
+
+	state := &types.ZarfState{
+		RegistryInfo: types.RegistryInfo{Address: registryAddress},
+		ImageVerification: types.ImageVerificationPolicy{
+			PublicKey: string(keys.PublicBytes),
+			Mode:      types.ImageVerificationEnforce,
+		},
+	}
+	c := createTestClientWithZarfState(ctx, t, state)
+	handler := admission.NewHandler().Serve(NewPodMutationHook(ctx, c))
+	admit := func() v1.AdmissionReview {
+		req := createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Image: "nginx"}},
+			},
+		})
+		rr := sendAdmissionRequest(t, req, handler)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var review v1.AdmissionReview
+		err := json.NewDecoder(rr.Body).Decode(&review)
+		require.NoError(t, err)
+		return review
+	}
+
+	ref := fmt.Sprintf("%s/library/nginx:latest-zarf-3793515731", registryAddress)
+	pushSignedImage(t, ref, signer)
+	require.True(t, admit().Response.Allowed)
+
+	// Verification is cached by digest so an unsigned image pushed to the verified tag is still rejected
+	pushSignedImage(t, ref, nil)
+	review := admit()
+	require.False(t, review.Response.Allowed)
+	require.Contains(t, review.Response.Result.Message, "image signature verification failed: "+ref)
+}
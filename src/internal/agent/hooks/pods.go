@@ -8,12 +8,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/agent/operations"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
 	v1 "k8s.io/api/admission/v1"
 
 	corev1 "k8s.io/api/core/v1"
@@ -23,12 +26,13 @@ const annotationPrefix = "zarf.dev"
 
 // NewPodMutationHook creates a new instance of pods mutation hook.
 func NewPodMutationHook(ctx context.Context, cluster *cluster.Cluster) operations.Hook {
+	verified := newImageCache()
 	return operations.Hook{
 		Create: func(r *v1.AdmissionRequest) (*operations.Result, error) {
-			return mutatePod(ctx, r, cluster)
+			return mutatePod(ctx, r, cluster, verified)
 		},
 		Update: func(r *v1.AdmissionRequest) (*operations.Result, error) {
-			return mutatePod(ctx, r, cluster)
+			return mutatePod(ctx, r, cluster, verified)
 		},
 	}
 }
@@ -45,7 +49,7 @@ func getImageAnnotationKey(containerName string) string {
 	return fmt.Sprintf("%s/original-image-%s", annotationPrefix, containerName)
 }
 
-func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Cluster) (*operations.Result, error) {
+func mutatePod(ctx context.Context, r *v1.AdmissionRequest, c *cluster.Cluster, verified *imageCache) (*operations.Result, error) {
 	pod, err := parsePod(r.Object.Raw)
 	if err != nil {
 		return nil, fmt.Errorf(lang.AgentErrParsePod, err)
@@ -59,54 +63,60 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 		}, nil
 	}
 
-	state, err := cluster.LoadZarfState(ctx)
+	state, err := c.LoadZarfState(ctx)
 	if err != nil {
 		return nil, err
 	}
 	registryURL := state.RegistryInfo.Address
 
-	var patches []operations.PatchOperation
+	if isNamespaceExcluded(state.AgentExclusions, r.Namespace) {
+		message.Debugf("Skipping the pod %s in the excluded namespace %s", pod.Name, r.Namespace)
+		return &operations.Result{
+			Allowed:  true,
+			PatchOps: []operations.PatchOperation{},
+		}, nil
+	}
 
-	// Add the zarf secret to the podspec
-	zarfSecret := []corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}}
-	patches = append(patches, operations.ReplacePatchOperation("/spec/imagePullSecrets", zarfSecret))
+	var patches []operations.PatchOperation
 
 	updatedAnnotations := pod.Annotations
 	if updatedAnnotations == nil {
 		updatedAnnotations = make(map[string]string)
 	}
 
-	// update the image host for each init container
-	for idx, container := range pod.Spec.InitContainers {
-		path := fmt.Sprintf("/spec/initContainers/%d/image", idx)
-		replacement, err := transform.ImageTransformHost(registryURL, container.Image)
-		if err != nil {
-			return nil, err
-		}
-		updatedAnnotations[getImageAnnotationKey(container.Name)] = container.Image
-		patches = append(patches, operations.ReplacePatchOperation(path, replacement))
+	imagePatches, transformedImages, err := podSpecImagePatches(registryURL, state.AgentExclusions, "/spec", pod.Spec, updatedAnnotations)
+	if err != nil {
+		return nil, err
 	}
 
-	// update the image host for each ephemeral container
-	for idx, container := range pod.Spec.EphemeralContainers {
-		path := fmt.Sprintf("/spec/ephemeralContainers/%d/image", idx)
-		replacement, err := transform.ImageTransformHost(registryURL, container.Image)
-		if err != nil {
-			return nil, err
-		}
-		updatedAnnotations[getImageAnnotationKey(container.Name)] = container.Image
-		patches = append(patches, operations.ReplacePatchOperation(path, replacement))
+	// Add the zarf secret to the podspec when an image is pulled from the Zarf registry, keeping the secrets the pod
+	// already uses for the images that were excluded
+	if len(transformedImages) > 0 {
+		patches = append(patches, operations.ReplacePatchOperation("/spec/imagePullSecrets", podImagePullSecrets(pod.Spec.ImagePullSecrets)))
 	}
+	patches = append(patches, imagePatches...)
 
-	// update the image host for each normal container
-	for idx, container := range pod.Spec.Containers {
-		path := fmt.Sprintf("/spec/containers/%d/image", idx)
-		replacement, err := transform.ImageTransformHost(registryURL, container.Image)
+	var warnings []string
+	// Zarf's own components are pushed without signatures so they are not verified
+	if state.ImageVerification.Enabled() && r.Namespace != cluster.ZarfNamespaceName {
+		digestImages, failures, err := verifyImageSignatures(ctx, c, state, transformedImages, verified)
 		if err != nil {
 			return nil, err
 		}
-		updatedAnnotations[getImageAnnotationKey(container.Name)] = container.Image
-		patches = append(patches, operations.ReplacePatchOperation(path, replacement))
+		if len(failures) > 0 {
+			msg := fmt.Sprintf(lang.AgentErrImageSignature, strings.Join(failures, "; "))
+			if !state.ImageVerification.WarnOnly() {
+				return &operations.Result{Allowed: false, Msg: msg}, nil
+			}
+			message.Warn(msg)
+			warnings = append(warnings, msg)
+		}
+		// Pin verified images to their digest so that a tag moved after verification is not pulled
+		for i, patch := range patches {
+			if image, ok := patch.Value.(string); ok && digestImages[image] != "" {
+				patches[i].Value = digestImages[image]
+			}
+		}
 	}
 
 	patches = append(patches, getLabelPatch(pod.Labels))
@@ -116,5 +126,67 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 	return &operations.Result{
 		Allowed:  true,
 		PatchOps: patches,
+		Warnings: warnings,
 	}, nil
 }
+
+// podImagePullSecrets returns the given image pull secrets with the Zarf image pull secret appended.
+func podImagePullSecrets(secrets []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	pullSecrets := []corev1.LocalObjectReference{}
+	for _, secret := range secrets {
+		if secret.Name != config.ZarfImagePullSecretName {
+			pullSecrets = append(pullSecrets, secret)
+		}
+	}
+	return append(pullSecrets, corev1.LocalObjectReference{Name: config.ZarfImagePullSecretName})
+}
+
+// podSpecImagePatches returns the patches that point the images of a pod spec at the Zarf registry along with the
+// transformed images, and records the original images in the given annotations. Images matching the exclusion rules are
+// left untouched. specPath is the JSON pointer to the pod spec within the admitted object.
+func podSpecImagePatches(registryURL string, rules types.AgentExclusionRules, specPath string, spec corev1.PodSpec, annotations map[string]string) ([]operations.PatchOperation, []string, error) {
+	var patches []operations.PatchOperation
+	var transformedImages []string
+
+	patchImage := func(path, containerName, image string) error {
+		if isImageExcluded(rules, image) {
+			message.Debugf("Skipping the excluded image %s", image)
+			return nil
+		}
+		replacement, err := transform.ImageTransformHost(registryURL, image)
+		if err != nil {
+			return err
+		}
+		// Keep the original image recorded on a pod template when the image was already transformed there
+		annotationKey := getImageAnnotationKey(containerName)
+		if replacement != image || annotations[annotationKey] == "" {
+			annotations[annotationKey] = image
+		}
+		transformedImages = append(transformedImages, replacement)
+		patches = append(patches, operations.ReplacePatchOperation(path, replacement))
+		return nil
+	}
+
+	// update the image host for each init container
+	for idx, container := range spec.InitContainers {
+		if err := patchImage(fmt.Sprintf("%s/initContainers/%d/image", specPath, idx), container.Name, container.Image); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// update the image host for each ephemeral container
+	for idx, container := range spec.EphemeralContainers {
+		if err := patchImage(fmt.Sprintf("%s/ephemeralContainers/%d/image", specPath, idx), container.Name, container.Image); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// update the image host for each normal container
+	for idx, container := range spec.Containers {
+		if err := patchImage(fmt.Sprintf("%s/containers/%d/image", specPath, idx), container.Name, container.Image); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return patches, transformedImages, nil
+}
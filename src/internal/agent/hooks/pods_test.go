@@ -98,6 +98,42 @@ func TestPodMutationWebhook(t *testing.T) {
 			},
 			code: http.StatusOK,
 		},
+		{
+			name: "pod with image pull secrets should keep them",
+			admissionReq: createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+				Spec: corev1.PodSpec{
+					ImagePullSecrets: []corev1.LocalObjectReference{
+						{Name: "other-registry"},
+						{Name: config.ZarfImagePullSecretName},
+					},
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+				},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation(
+					"/spec/imagePullSecrets",
+					[]corev1.LocalObjectReference{
+						{Name: "other-registry"},
+						{Name: config.ZarfImagePullSecretName},
+					},
+				),
+				operations.ReplacePatchOperation(
+					"/spec/containers/0/image",
+					"127.0.0.1:31999/library/nginx:latest-zarf-3793515731",
+				),
+				operations.ReplacePatchOperation(
+					"/metadata/labels",
+					map[string]string{"zarf-agent": "patched"},
+				),
+				operations.ReplacePatchOperation(
+					"/metadata/annotations",
+					map[string]string{
+						"zarf.dev/original-image-nginx": "nginx",
+					},
+				),
+			},
+			code: http.StatusOK,
+		},
 		{
 			name: "pod with zarf-agent patched label should not be mutated",
 			admissionReq: createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
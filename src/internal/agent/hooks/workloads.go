@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hooks contains the mutation hooks for the Zarf agent.
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+)
+
+// NewWorkloadMutationHook creates a new instance of the workload mutation hook for the pod templates of
+// Deployments, StatefulSets, DaemonSets, Jobs and CronJobs.
+func NewWorkloadMutationHook(ctx context.Context, cluster *cluster.Cluster) operations.Hook {
+	return operations.Hook{
+		Create: func(r *v1.AdmissionRequest) (*operations.Result, error) {
+			return mutateWorkload(ctx, r, cluster)
+		},
+		Update: func(r *v1.AdmissionRequest) (*operations.Result, error) {
+			return mutateWorkload(ctx, r, cluster)
+		},
+	}
+}
+
+// parsePodTemplate returns the JSON pointer to the pod template of a workload and the pod template itself.
+func parsePodTemplate(r *v1.AdmissionRequest) (string, corev1.PodTemplateSpec, error) {
+	switch r.Kind.Kind {
+	case "Deployment":
+		var deployment appsv1.Deployment
+		if err := json.Unmarshal(r.Object.Raw, &deployment); err != nil {
+			return "", corev1.PodTemplateSpec{}, err
+		}
+		return "/spec/template", deployment.Spec.Template, nil
+	case "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		if err := json.Unmarshal(r.Object.Raw, &statefulSet); err != nil {
+			return "", corev1.PodTemplateSpec{}, err
+		}
+		return "/spec/template", statefulSet.Spec.Template, nil
+	case "DaemonSet":
+		var daemonSet appsv1.DaemonSet
+		if err := json.Unmarshal(r.Object.Raw, &daemonSet); err != nil {
+			return "", corev1.PodTemplateSpec{}, err
+		}
+		return "/spec/template", daemonSet.Spec.Template, nil
+	case "Job":
+		var job batchv1.Job
+		if err := json.Unmarshal(r.Object.Raw, &job); err != nil {
+			return "", corev1.PodTemplateSpec{}, err
+		}
+		return "/spec/template", job.Spec.Template, nil
+	case "CronJob":
+		var cronJob batchv1.CronJob
+		if err := json.Unmarshal(r.Object.Raw, &cronJob); err != nil {
+			return "", corev1.PodTemplateSpec{}, err
+		}
+		return "/spec/jobTemplate/spec/template", cronJob.Spec.JobTemplate.Spec.Template, nil
+	default:
+		return "", corev1.PodTemplateSpec{}, fmt.Errorf("unsupported workload kind %s", r.Kind.Kind)
+	}
+}
+
+// mutateWorkload points the images of a workload pod template at the Zarf registry and records the original images
+// in the template annotations. Pods created from the template are still mutated by the pod hook, which adds the
+// image pull secret and leaves the already transformed images and their annotations unchanged.
+func mutateWorkload(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Cluster) (*operations.Result, error) {
+	templatePath, template, err := parsePodTemplate(r)
+	if err != nil {
+		return nil, fmt.Errorf(lang.AgentErrParseWorkload, err)
+	}
+
+	state, err := cluster.LoadZarfState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if isNamespaceExcluded(state.AgentExclusions, r.Namespace) {
+		return &operations.Result{
+			Allowed:  true,
+			PatchOps: []operations.PatchOperation{},
+		}, nil
+	}
+
+	updatedAnnotations := template.Annotations
+	if updatedAnnotations == nil {
+		updatedAnnotations = make(map[string]string)
+	}
+
+	patches, _, err := podSpecImagePatches(state.RegistryInfo.Address, state.AgentExclusions, templatePath+"/spec", template.Spec, updatedAnnotations)
+	if err != nil {
+		return nil, err
+	}
+	patches = append(patches, operations.ReplacePatchOperation(templatePath+"/metadata/annotations", updatedAnnotations))
+
+	return &operations.Result{
+		Allowed:  true,
+		PatchOps: patches,
+	}, nil
+}
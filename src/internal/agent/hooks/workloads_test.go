@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
+	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func createWorkloadAdmissionRequest(t *testing.T, op v1.Operation, kind string, workload any) *v1.AdmissionRequest {
+	t.Helper()
+	raw, err := json.Marshal(workload)
+	require.NoError(t, err)
+	return &v1.AdmissionRequest{
+		Operation: op,
+		Kind:      metav1.GroupVersionKind{Kind: kind},
+		Object: runtime.RawExtension{
+			Raw: raw,
+		},
+	}
+}
+
+func TestWorkloadMutationWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	state := &types.ZarfState{RegistryInfo: types.RegistryInfo{Address: "127.0.0.1:31999"}}
+	c := createTestClientWithZarfState(ctx, t, state)
+	handler := admission.NewHandler().Serve(NewWorkloadMutationHook(ctx, c))
+
+	tests := []admissionTest{
+		{
+			name: "deployment pod template should be mutated",
+			admissionReq: createWorkloadAdmissionRequest(t, v1.Create, "Deployment", &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{"should-be": "kept"},
+						},
+						Spec: corev1.PodSpec{
+							Containers:     []corev1.Container{{Name: "nginx", Image: "nginx"}},
+							InitContainers: []corev1.Container{{Name: "different", Image: "busybox"}},
+						},
+					},
+				},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation(
+					"/spec/template/spec/initContainers/0/image",
+					"127.0.0.1:31999/library/busybox:latest-zarf-2140033595",
+				),
+				operations.ReplacePatchOperation(
+					"/spec/template/spec/containers/0/image",
+					"127.0.0.1:31999/library/nginx:latest-zarf-3793515731",
+				),
+				operations.ReplacePatchOperation(
+					"/spec/template/metadata/annotations",
+					map[string]string{
+						"zarf.dev/original-image-nginx":     "nginx",
+						"zarf.dev/original-image-different": "busybox",
+						"should-be":                         "kept",
+					},
+				),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "cronjob job template should be mutated",
+			admissionReq: createWorkloadAdmissionRequest(t, v1.Create, "CronJob", &batchv1.CronJob{
+				Spec: batchv1.CronJobSpec{
+					JobTemplate: batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{{Name: "alpine", Image: "alpine"}},
+								},
+							},
+						},
+					},
+				},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation(
+					"/spec/jobTemplate/spec/template/spec/containers/0/image",
+					"127.0.0.1:31999/library/alpine:latest-zarf-1117969859",
+				),
+				operations.ReplacePatchOperation(
+					"/spec/jobTemplate/spec/template/metadata/annotations",
+					map[string]string{"zarf.dev/original-image-alpine": "alpine"},
+				),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "already mutated statefulset keeps the original image annotation",
+			admissionReq: createWorkloadAdmissionRequest(t, v1.Update, "StatefulSet", &appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{"zarf.dev/original-image-nginx": "nginx"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "nginx", Image: "127.0.0.1:31999/library/nginx:latest-zarf-3793515731"}},
+						},
+					},
+				},
+			}),
+			patch: []operations.PatchOperation{
+				operations.ReplacePatchOperation(
+					"/spec/template/spec/containers/0/image",
+					"127.0.0.1:31999/library/nginx:latest-zarf-3793515731",
+				),
+				operations.ReplacePatchOperation(
+					"/spec/template/metadata/annotations",
+					map[string]string{"zarf.dev/original-image-nginx": "nginx"},
+				),
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:         "unsupported kind should error",
+			admissionReq: createWorkloadAdmissionRequest(t, v1.Create, "ReplicaSet", &appsv1.ReplicaSet{}),
+			code:         http.StatusInternalServerError,
+			errContains:  "unsupported workload kind ReplicaSet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := sendAdmissionRequest(t, tt.admissionReq, handler)
+			verifyAdmission(t, rr, tt)
+		})
+	}
+}
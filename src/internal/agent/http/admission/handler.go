@@ -92,9 +92,10 @@ func (h *Handler) Serve(hook operations.Hook) http.HandlerFunc {
 		admissionResponse := corev1.AdmissionReview{
 			TypeMeta: admissionMeta,
 			Response: &corev1.AdmissionResponse{
-				UID:     review.Request.UID,
-				Allowed: result.Allowed,
-				Result:  &metav1.Status{Message: result.Msg},
+				UID:      review.Request.UID,
+				Allowed:  result.Allowed,
+				Result:   &metav1.Status{Message: result.Msg},
+				Warnings: result.Warnings,
 			},
 		}
 
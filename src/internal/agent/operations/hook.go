@@ -16,6 +16,7 @@ type Result struct {
 	Allowed  bool
 	Msg      string
 	PatchOps []PatchOperation
+	Warnings []string
 }
 
 // AdmitFunc defines how to process an admission request.
@@ -1,7 +1,7 @@
 // SPDX-License-Identifier: Apache-2.0
 // SPDX-FileCopyrightText: 2021-Present The Zarf Authors
 
-// Package agent holds the mutating webhook server.
+// Package agent holds the mutating and validating webhook server.
 package agent
 
 import (
@@ -32,7 +32,7 @@ const (
 	tlsKey   = "/etc/certs/tls.key"
 )
 
-// StartWebhook launches the Zarf agent mutating webhook in the cluster.
+// StartWebhook launches the Zarf agent mutating and validating webhooks in the cluster.
 func StartWebhook(ctx context.Context, cluster *cluster.Cluster) error {
 	// Routers
 	admissionHandler := admission.NewHandler()
@@ -42,6 +42,10 @@ func StartWebhook(ctx context.Context, cluster *cluster.Cluster) error {
 	argocdRepositoryMutation := hooks.NewRepositorySecretMutationHook(ctx, cluster)
 	fluxHelmRepositoryMutation := hooks.NewHelmRepositoryMutationHook(ctx, cluster)
 	fluxOCIRepositoryMutation := hooks.NewOCIRepositoryMutationHook(ctx, cluster)
+	workloadMutation := hooks.NewWorkloadMutationHook(ctx, cluster)
+	crdMutation := hooks.NewCRDMutationHook(ctx, cluster)
+	podImagesValidation := hooks.NewPodImageValidationHook(ctx, cluster, false)
+	podImagesWarnValidation := hooks.NewPodImageValidationHook(ctx, cluster, true)
 
 	// Routers
 	mux := http.NewServeMux()
@@ -51,6 +55,10 @@ func StartWebhook(ctx context.Context, cluster *cluster.Cluster) error {
 	mux.Handle("/mutate/flux-ocirepository", admissionHandler.Serve(fluxOCIRepositoryMutation))
 	mux.Handle("/mutate/argocd-application", admissionHandler.Serve(argocdApplicationMutation))
 	mux.Handle("/mutate/argocd-repository", admissionHandler.Serve(argocdRepositoryMutation))
+	mux.Handle("/mutate/workload", admissionHandler.Serve(workloadMutation))
+	mux.Handle("/mutate/crd", admissionHandler.Serve(crdMutation))
+	mux.Handle("/validate/pod-images", admissionHandler.Serve(podImagesValidation))
+	mux.Handle("/validate/pod-images-warn", admissionHandler.Serve(podImagesWarnValidation))
 
 	return startServer(ctx, httpPort, mux)
 }
@@ -30,8 +30,9 @@ import (
 	"github.com/zarf-dev/zarf/src/types"
 )
 
-// InstallOrUpgradeChart performs a helm install of the given chart.
-func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings, string, error) {
+// InstallOrUpgradeChart performs a helm install of the given chart and returns its connect strings, release name and
+// the revision of the release that was deployed.
+func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings, string, int, error) {
 	fromMessage := h.chart.URL
 	if fromMessage == "" {
 		fromMessage = "Zarf-generated helm chart"
@@ -50,15 +51,16 @@ func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings,
 	// Setup K8s connection.
 	err := h.createActionConfig(h.chart.Namespace, spinner)
 	if err != nil {
-		return nil, "", fmt.Errorf("unable to initialize the K8s client: %w", err)
+		return nil, "", 0, fmt.Errorf("unable to initialize the K8s client: %w", err)
 	}
 
 	postRender, err := h.newRenderer(ctx)
 	if err != nil {
-		return nil, "", fmt.Errorf("unable to create helm renderer: %w", err)
+		return nil, "", 0, fmt.Errorf("unable to create helm renderer: %w", err)
 	}
 
 	histClient := action.NewHistory(h.actionConfig)
+	var rel *release.Release
 	tryHelm := func() error {
 		var err error
 
@@ -70,14 +72,14 @@ func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings,
 			// No prior release, try to install it.
 			spinner.Updatef("Attempting chart installation")
 
-			_, err = h.installChart(postRender)
+			rel, err = h.installChart(postRender)
 		} else if histErr == nil && len(releases) > 0 {
 			// Otherwise, there is a prior release so upgrade it.
 			spinner.Updatef("Attempting chart upgrade")
 
 			lastRelease := releases[len(releases)-1]
 
-			_, err = h.upgradeChart(lastRelease, postRender)
+			rel, err = h.upgradeChart(lastRelease, postRender)
 		} else {
 			// 😭 things aren't working
 			return fmt.Errorf("unable to verify the chart installation status: %w", histErr)
@@ -107,21 +109,21 @@ func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings,
 
 		// No prior releases means this was an initial install.
 		if previouslyDeployedVersion == 0 {
-			return nil, "", fmt.Errorf("unable to install chart after %d attempts: %s", h.retries, removeMsg)
+			return nil, "", 0, fmt.Errorf("unable to install chart after %d attempts: %s", h.retries, removeMsg)
 		}
 
 		// Attempt to rollback on a failed upgrade.
 		spinner.Updatef("Performing chart rollback")
 		err = h.rollbackChart(h.chart.ReleaseName, previouslyDeployedVersion)
 		if err != nil {
-			return nil, "", fmt.Errorf("unable to upgrade chart after %d attempts and unable to rollback: %s", h.retries, removeMsg)
+			return nil, "", 0, fmt.Errorf("unable to upgrade chart after %d attempts and unable to rollback: %s", h.retries, removeMsg)
 		}
 
-		return nil, "", fmt.Errorf("unable to upgrade chart after %d attempts: %s", h.retries, removeMsg)
+		return nil, "", 0, fmt.Errorf("unable to upgrade chart after %d attempts: %s", h.retries, removeMsg)
 	}
 
 	// return any collected connect strings for zarf connect.
-	return postRender.connectStrings, h.chart.ReleaseName, nil
+	return postRender.connectStrings, h.chart.ReleaseName, rel.Version, nil
 }
 
 // TemplateChart generates a helm template from a given chart.
@@ -129,11 +131,72 @@ func (h *Helm) TemplateChart(ctx context.Context) (manifest string, chartValues
 	spinner := message.NewProgressSpinner("Templating helm chart %s", h.chart.Name)
 	defer spinner.Stop()
 
+	manifest, chartValues, _, err = h.templateChart(ctx, spinner)
+	if err != nil {
+		return "", nil, err
+	}
+
+	spinner.Success()
+
+	return manifest, chartValues, nil
+}
+
+// ChartPlan describes what installing a chart would change in the cluster.
+type ChartPlan struct {
+	ReleaseName       string
+	Namespace         string
+	Manifest          string
+	CreatedNamespaces []string
+	AdoptedNamespaces []string
+	ConnectStrings    types.ConnectStrings
+}
+
+// PlanChart generates a helm template from a given chart using the connected cluster and reports the namespaces
+// that would be created or adopted by an install without making any changes to the cluster.
+func (h *Helm) PlanChart(ctx context.Context) (plan ChartPlan, err error) {
+	spinner := message.NewProgressSpinner("Planning helm chart %s", h.chart.Name)
+	defer spinner.Stop()
+
+	if h.cluster == nil {
+		return plan, errors.New("unable to plan a helm chart without a cluster connection")
+	}
+	if !h.cfg.DeployOpts.DryRun {
+		return plan, errors.New("unable to plan a helm chart outside of a dry run")
+	}
+
+	manifest, _, postRender, err := h.templateChart(ctx, spinner)
+	if err != nil {
+		return plan, err
+	}
+
+	created, adopted, err := postRender.planNamespaces(ctx)
+	if err != nil {
+		return plan, fmt.Errorf("unable to check namespaces for chart %s: %w", h.chart.Name, err)
+	}
+
+	releaseName := h.chart.ReleaseName
+	if releaseName == "" {
+		releaseName = h.chart.Name
+	}
+
+	spinner.Success()
+
+	return ChartPlan{
+		ReleaseName:       releaseName,
+		Namespace:         h.chart.Namespace,
+		Manifest:          manifest,
+		CreatedNamespaces: created,
+		AdoptedNamespaces: adopted,
+		ConnectStrings:    postRender.connectStrings,
+	}, nil
+}
+
+func (h *Helm) templateChart(ctx context.Context, spinner *message.Spinner) (manifest string, chartValues chartutil.Values, postRender *renderer, err error) {
 	err = h.createActionConfig(h.chart.Namespace, spinner)
 
 	// Setup K8s connection.
 	if err != nil {
-		return "", nil, fmt.Errorf("unable to initialize the K8s client: %w", err)
+		return "", nil, nil, fmt.Errorf("unable to initialize the K8s client: %w", err)
 	}
 
 	// Bind the helm action.
@@ -149,7 +212,7 @@ func (h *Helm) TemplateChart(ctx context.Context) (manifest string, chartValues
 	if h.kubeVersion != "" {
 		parsedKubeVersion, err := chartutil.ParseKubeVersion(h.kubeVersion)
 		if err != nil {
-			return "", nil, fmt.Errorf("invalid kube version %s: %w", h.kubeVersion, err)
+			return "", nil, nil, fmt.Errorf("invalid kube version %s: %w", h.kubeVersion, err)
 		}
 		client.KubeVersion = parsedKubeVersion
 	}
@@ -165,18 +228,19 @@ func (h *Helm) TemplateChart(ctx context.Context) (manifest string, chartValues
 
 	loadedChart, chartValues, err := h.loadChartData()
 	if err != nil {
-		return "", nil, fmt.Errorf("unable to load chart data: %w", err)
+		return "", nil, nil, fmt.Errorf("unable to load chart data: %w", err)
 	}
 
-	client.PostRenderer, err = h.newRenderer(ctx)
+	postRender, err = h.newRenderer(ctx)
 	if err != nil {
-		return "", nil, fmt.Errorf("unable to create helm renderer: %w", err)
+		return "", nil, nil, fmt.Errorf("unable to create helm renderer: %w", err)
 	}
+	client.PostRenderer = postRender
 
 	// Perform the loadedChart installation.
 	templatedChart, err := client.Run(loadedChart, chartValues)
 	if err != nil {
-		return "", nil, fmt.Errorf("error generating helm chart template: %w", err)
+		return "", nil, nil, fmt.Errorf("error generating helm chart template: %w", err)
 	}
 
 	manifest = templatedChart.Manifest
@@ -185,9 +249,7 @@ func (h *Helm) TemplateChart(ctx context.Context) (manifest string, chartValues
 		manifest += fmt.Sprintf("\n---\n%s", hook.Manifest)
 	}
 
-	spinner.Success()
-
-	return manifest, chartValues, nil
+	return manifest, chartValues, postRender, nil
 }
 
 // RemoveChart removes a chart from the cluster.
@@ -200,6 +262,109 @@ func (h *Helm) RemoveChart(namespace string, name string, spinner *message.Spinn
 	return err
 }
 
+// RollbackChart returns a release to the revision recorded for the package generation that is restored. A revision of
+// 0 means the release was not part of that generation so it is uninstalled instead.
+func (h *Helm) RollbackChart(namespace string, name string, revision int, spinner *message.Spinner) error {
+	// Establish a new actionConfig for the namespace.
+	if err := h.createActionConfig(namespace, spinner); err != nil {
+		return fmt.Errorf("unable to initialize the K8s client: %w", err)
+	}
+
+	histClient := action.NewHistory(h.actionConfig)
+	releases, err := histClient.Run(name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if revision == 0 {
+		spinner.Updatef("Uninstalling chart %s from the %s namespace", name, namespace)
+		response, err := h.uninstallChart(name)
+		message.Debug(response)
+		return err
+	}
+
+	rollback, err := needsRollback(releases, revision)
+	if err != nil {
+		return err
+	}
+	if !rollback {
+		return nil
+	}
+
+	spinner.Updatef("Rolling back chart %s in the %s namespace to revision %d", name, namespace, revision)
+	return h.rollbackChart(name, revision)
+}
+
+// needsRollback returns whether a release has to be rolled back to the revision, which is not the case when the
+// revision is still the deployed one.
+func needsRollback(releases []*release.Release, revision int) (bool, error) {
+	for _, rel := range releases {
+		if rel.Version == revision {
+			return rel.Info.Status != release.StatusDeployed, nil
+		}
+	}
+	return false, fmt.Errorf("revision %d is no longer in the history of the release", revision)
+}
+
+// RollbackFailedChart returns a release whose latest revision did not finish deploying to the revision before it. It is
+// used when the revision to restore was not recorded, releases whose latest revision is deployed are left as they are.
+func (h *Helm) RollbackFailedChart(namespace string, name string, spinner *message.Spinner) error {
+	// Establish a new actionConfig for the namespace.
+	if err := h.createActionConfig(namespace, spinner); err != nil {
+		return fmt.Errorf("unable to initialize the K8s client: %w", err)
+	}
+
+	histClient := action.NewHistory(h.actionConfig)
+	releases, err := histClient.Run(name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	revision := previousRevision(releases)
+	if revision == 0 {
+		return nil
+	}
+
+	spinner.Updatef("Rolling back chart %s in the %s namespace to revision %d", name, namespace, revision)
+	return h.rollbackChart(name, revision)
+}
+
+// previousRevision returns the revision before the latest revision of a release when the latest revision is not
+// deployed, or 0 when there is nothing to roll back.
+func previousRevision(releases []*release.Release) int {
+	var latest *release.Release
+	for _, rel := range releases {
+		if latest == nil || rel.Version > latest.Version {
+			latest = rel
+		}
+	}
+	if latest == nil || latest.Info.Status == release.StatusDeployed {
+		return 0
+	}
+	previous := 0
+	for _, rel := range releases {
+		if rel.Version < latest.Version && rel.Version > previous {
+			previous = rel.Version
+		}
+	}
+	return previous
+}
+
+// GetRelease returns the latest release of a chart in the namespace.
+func (h *Helm) GetRelease(namespace string, name string, spinner *message.Spinner) (*release.Release, error) {
+	// Establish a new actionConfig for the namespace.
+	if err := h.createActionConfig(namespace, spinner); err != nil {
+		return nil, fmt.Errorf("unable to initialize the K8s client: %w", err)
+	}
+	return action.NewGet(h.actionConfig).Run(name)
+}
+
 // UpdateReleaseValues updates values for a given chart release
 // (note: this only works on single-deep charts, charts with dependencies (like loki-stack) will not work)
 func (h *Helm) UpdateReleaseValues(ctx context.Context, updatedValues map[string]interface{}) error {
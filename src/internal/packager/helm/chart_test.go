@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func TestNeedsRollback(t *testing.T) {
+	t.Parallel()
+
+	// Revisions 2 and 3 were deployed within the same second, only their revision numbers tell them apart
+	releases := []*release.Release{
+		{Version: 1, Info: &release.Info{Status: release.StatusSuperseded}},
+		{Version: 2, Info: &release.Info{Status: release.StatusSuperseded}},
+		{Version: 3, Info: &release.Info{Status: release.StatusDeployed}},
+		{Version: 4, Info: &release.Info{Status: release.StatusFailed}},
+	}
+
+	tests := []struct {
+		name        string
+		revision    int
+		expected    bool
+		expectedErr string
+	}{
+		{
+			name:     "superseded revision",
+			revision: 2,
+			expected: true,
+		},
+		{
+			name:     "deployed revision",
+			revision: 3,
+			expected: false,
+		},
+		{
+			name:        "revision pruned from the history",
+			revision:    7,
+			expectedErr: "revision 7 is no longer in the history of the release",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rollback, err := needsRollback(releases, tt.revision)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, rollback)
+		})
+	}
+}
+
+func TestPreviousRevision(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		releases []*release.Release
+		expected int
+	}{
+		{
+			name: "failed upgrade",
+			releases: []*release.Release{
+				{Version: 1, Info: &release.Info{Status: release.StatusSuperseded}},
+				{Version: 3, Info: &release.Info{Status: release.StatusFailed}},
+				{Version: 2, Info: &release.Info{Status: release.StatusDeployed}},
+			},
+			expected: 2,
+		},
+		{
+			name: "deployed release",
+			releases: []*release.Release{
+				{Version: 1, Info: &release.Info{Status: release.StatusSuperseded}},
+				{Version: 2, Info: &release.Info{Status: release.StatusDeployed}},
+			},
+			expected: 0,
+		},
+		{
+			name: "failed installation",
+			releases: []*release.Release{
+				{Version: 1, Info: &release.Info{Status: release.StatusFailed}},
+			},
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, previousRevision(tt.releases))
+		})
+	}
+}
@@ -103,8 +103,11 @@ func (r *renderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
 			return nil, err
 		}
 
-		if err := r.adoptAndUpdateNamespaces(ctx); err != nil {
-			return nil, err
+		// During a dry run namespaces are only reported, see planNamespaces
+		if !r.cfg.DeployOpts.DryRun {
+			if err := r.adoptAndUpdateNamespaces(ctx); err != nil {
+				return nil, err
+			}
 		}
 	} else {
 		for _, resource := range resources {
@@ -207,6 +210,27 @@ func (r *renderer) adoptAndUpdateNamespaces(ctx context.Context) error {
 	return nil
 }
 
+// planNamespaces reports the namespaces that adoptAndUpdateNamespaces would create or adopt without modifying the cluster.
+func (r *renderer) planNamespaces(ctx context.Context) (created []string, adopted []string, err error) {
+	namespaceList, err := r.cluster.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	for name := range r.namespaces {
+		existingNamespace := slices.ContainsFunc(namespaceList.Items, func(ns corev1.Namespace) bool {
+			return ns.Name == name
+		})
+		if !existingNamespace {
+			created = append(created, name)
+		} else if r.cfg.DeployOpts.AdoptExistingResources && !slices.Contains([]string{"default", "kube-node-lease", "kube-public", "kube-system"}, name) {
+			adopted = append(adopted, name)
+		}
+	}
+	slices.Sort(created)
+	slices.Sort(adopted)
+	return created, adopted, nil
+}
+
 func (r *renderer) editHelmResources(ctx context.Context, resources []releaseutil.Manifest, finalManifestsOutput *bytes.Buffer) error {
 	dc, err := dynamic.NewForConfig(r.cluster.RestConfig)
 	if err != nil {
@@ -269,7 +293,7 @@ func (r *renderer) editHelmResources(ctx context.Context, resources []releaseuti
 		}
 
 		// If we have been asked to adopt existing resources, process those now as well
-		if r.cfg.DeployOpts.AdoptExistingResources {
+		if r.cfg.DeployOpts.AdoptExistingResources && !r.cfg.DeployOpts.DryRun {
 			deployedNamespace := namespace
 			if deployedNamespace == "" {
 				deployedNamespace = r.chart.Namespace
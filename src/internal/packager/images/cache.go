@@ -0,0 +1,370 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// ImageCache is a content-addressed store of image manifests, configs and layers keyed by their digest that is shared
+// by every package create. Blobs are stored once under blobs/<alg>/<hex> like in an OCI image layout, the cache has no
+// index as images are looked up by the digest of their manifest.
+type ImageCache struct {
+	path clayout.Path
+}
+
+// NewImageCache returns the image cache in dir, which is created when the first image is written to it.
+func NewImageCache(dir string) *ImageCache {
+	return &ImageCache{path: clayout.Path(dir)}
+}
+
+// Image returns the image with the manifest digest from the cache if its manifest, config and layers are all cached.
+func (c *ImageCache) Image(digest v1.Hash) (v1.Image, error) {
+	rawManifest, err := os.ReadFile(c.blobPath(digest))
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(rawManifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the cached manifest %s: %w", digest, err)
+	}
+	img := &blobImage{rawManifest: rawManifest, manifest: manifest, blob: func(h v1.Hash) (io.ReadCloser, error) {
+		return os.Open(c.blobPath(h))
+	}}
+	blobs, err := imageBlobs(img)
+	if err != nil {
+		return nil, err
+	}
+	for _, blob := range blobs {
+		if _, err := os.Stat(c.blobPath(blob)); err != nil {
+			return nil, err
+		}
+	}
+	return partial.CompressedToImage(img)
+}
+
+// WriteTo adds the blobs of an image that are not cached yet to the cache and then adds every blob of the image to
+// the layout, hard-linking it from the cache where possible and copying it otherwise.
+func (c *ImageCache) WriteTo(dst clayout.Path, img v1.Image) error {
+	// Images loaded from the docker daemon can name their config with a digest that does not match its contents,
+	// caching those would store the config under the wrong key
+	configName, err := img.ConfigName()
+	if err != nil {
+		return err
+	}
+	rawConfig, err := img.RawConfigFile()
+	if err != nil {
+		return err
+	}
+	configDigest, _, err := v1.SHA256(bytes.NewReader(rawConfig))
+	if err != nil {
+		return err
+	}
+	if configDigest != configName {
+		return dst.WriteImage(img)
+	}
+
+	if err := c.path.WriteImage(img); err != nil {
+		return fmt.Errorf("failed to write the image to the image cache: %w", err)
+	}
+	blobs, err := imageBlobs(img)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, blob := range blobs {
+		src := c.blobPath(blob)
+		// Mark the blob as used so pruning the cache keeps it
+		if err := os.Chtimes(src, now, now); err != nil {
+			return err
+		}
+		if err := linkOrCopy(src, filepath.Join(string(dst), "blobs", blob.Algorithm, blob.Hex)); err != nil {
+			return fmt.Errorf("failed to add blob %s from the image cache: %w", blob, err)
+		}
+	}
+	return nil
+}
+
+// Index returns the image index with the manifest digest from the cache if its manifest and the manifests, configs and
+// layers of every image in it are cached.
+func (c *ImageCache) Index(digest v1.Hash) (v1.ImageIndex, error) {
+	rawManifest, err := os.ReadFile(c.blobPath(digest))
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := v1.ParseIndexManifest(bytes.NewReader(rawManifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the cached index manifest %s: %w", digest, err)
+	}
+	if !manifest.MediaType.IsIndex() {
+		return nil, fmt.Errorf("the cached manifest %s is not an image index", digest)
+	}
+	idx := &blobIndex{
+		rawManifest: rawManifest,
+		manifest:    manifest,
+		images:      map[v1.Hash]v1.Image{},
+		indexes:     map[v1.Hash]v1.ImageIndex{},
+	}
+	for _, desc := range manifest.Manifests {
+		if desc.MediaType.IsIndex() {
+			child, err := c.Index(desc.Digest)
+			if err != nil {
+				return nil, err
+			}
+			idx.indexes[desc.Digest] = child
+			continue
+		}
+		img, err := c.Image(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		idx.images[desc.Digest] = img
+	}
+	return idx, nil
+}
+
+// WriteIndex adds the manifests of an image index and of its nested indexes to the cache, the images in them are added
+// with WriteTo.
+func (c *ImageCache) WriteIndex(idx v1.ImageIndex) error {
+	digest, err := idx.Digest()
+	if err != nil {
+		return err
+	}
+	rawManifest, err := idx.RawManifest()
+	if err != nil {
+		return err
+	}
+	if err := c.path.WriteBlob(digest, io.NopCloser(bytes.NewReader(rawManifest))); err != nil {
+		return fmt.Errorf("failed to write the image index to the image cache: %w", err)
+	}
+	// Mark the manifest as used so pruning the cache keeps it
+	now := time.Now()
+	if err := os.Chtimes(c.blobPath(digest), now, now); err != nil {
+		return err
+	}
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return err
+	}
+	for _, desc := range manifest.Manifests {
+		if !desc.MediaType.IsIndex() {
+			continue
+		}
+		child, err := idx.ImageIndex(desc.Digest)
+		if err != nil {
+			return err
+		}
+		if err := c.WriteIndex(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ImageCache) blobPath(blob v1.Hash) string {
+	return filepath.Join(string(c.path), "blobs", blob.Algorithm, blob.Hex)
+}
+
+// blobImage is an image read from a store of blobs keyed by their digest.
+type blobImage struct {
+	rawManifest []byte
+	manifest    *v1.Manifest
+	blob        func(v1.Hash) (io.ReadCloser, error)
+}
+
+// RawManifest returns the manifest of the image.
+func (i *blobImage) RawManifest() ([]byte, error) {
+	return i.rawManifest, nil
+}
+
+// MediaType returns the media type of the manifest of the image.
+func (i *blobImage) MediaType() (types.MediaType, error) {
+	if i.manifest.MediaType == "" {
+		return types.OCIManifestSchema1, nil
+	}
+	return i.manifest.MediaType, nil
+}
+
+// RawConfigFile returns the config of the image.
+func (i *blobImage) RawConfigFile() ([]byte, error) {
+	rc, err := i.blob(i.manifest.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// LayerByDigest returns the layer or config of the image with the digest.
+func (i *blobImage) LayerByDigest(h v1.Hash) (partial.CompressedLayer, error) {
+	if h == i.manifest.Config.Digest {
+		return &blobLayer{image: i, desc: i.manifest.Config}, nil
+	}
+	for _, desc := range i.manifest.Layers {
+		if h == desc.Digest {
+			return &blobLayer{image: i, desc: desc}, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find layer %s in the image", h)
+}
+
+// blobIndex is an image index read from a store of blobs keyed by their digest.
+type blobIndex struct {
+	rawManifest []byte
+	manifest    *v1.IndexManifest
+	images      map[v1.Hash]v1.Image
+	indexes     map[v1.Hash]v1.ImageIndex
+}
+
+// MediaType returns the media type of the manifest of the index.
+func (i *blobIndex) MediaType() (types.MediaType, error) {
+	return i.manifest.MediaType, nil
+}
+
+// Digest returns the digest of the manifest of the index.
+func (i *blobIndex) Digest() (v1.Hash, error) {
+	return partial.Digest(i)
+}
+
+// Size returns the size of the manifest of the index.
+func (i *blobIndex) Size() (int64, error) {
+	return int64(len(i.rawManifest)), nil
+}
+
+// IndexManifest returns the manifest of the index.
+func (i *blobIndex) IndexManifest() (*v1.IndexManifest, error) {
+	return i.manifest.DeepCopy(), nil
+}
+
+// RawManifest returns the serialized manifest of the index.
+func (i *blobIndex) RawManifest() ([]byte, error) {
+	return i.rawManifest, nil
+}
+
+// Image returns the image in the index with the digest.
+func (i *blobIndex) Image(h v1.Hash) (v1.Image, error) {
+	img, ok := i.images[h]
+	if !ok {
+		return nil, fmt.Errorf("could not find image %s in the index", h)
+	}
+	return img, nil
+}
+
+// ImageIndex returns the nested index in the index with the digest.
+func (i *blobIndex) ImageIndex(h v1.Hash) (v1.ImageIndex, error) {
+	idx, ok := i.indexes[h]
+	if !ok {
+		return nil, fmt.Errorf("could not find image index %s in the index", h)
+	}
+	return idx, nil
+}
+
+// blobLayer is a layer of a blobImage.
+type blobLayer struct {
+	image *blobImage
+	desc  v1.Descriptor
+}
+
+// Digest returns the digest of the compressed layer.
+func (l *blobLayer) Digest() (v1.Hash, error) {
+	return l.desc.Digest, nil
+}
+
+// Compressed returns the compressed contents of the layer.
+func (l *blobLayer) Compressed() (io.ReadCloser, error) {
+	return l.image.blob(l.desc.Digest)
+}
+
+// Size returns the size of the compressed layer.
+func (l *blobLayer) Size() (int64, error) {
+	return l.desc.Size, nil
+}
+
+// MediaType returns the media type of the layer.
+func (l *blobLayer) MediaType() (types.MediaType, error) {
+	return l.desc.MediaType, nil
+}
+
+// imageBlobs returns the digests of the manifest, config and layers of an image.
+func imageBlobs(img partial.WithRawManifest) ([]v1.Hash, error) {
+	digest, err := partial.Digest(img)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := partial.Manifest(img)
+	if err != nil {
+		return nil, err
+	}
+	blobs := []v1.Hash{digest, manifest.Config.Digest}
+	for _, layer := range manifest.Layers {
+		blobs = append(blobs, layer.Digest)
+	}
+	return blobs, nil
+}
+
+// linkOrCopy hard-links src to dst, falling back to a copy when src and dst are on different filesystems.
+func linkOrCopy(src, dst string) error {
+	if _, err := os.Stat(dst); err == nil {
+		return nil
+	}
+	if err := helpers.CreateParentDirectory(dst); err != nil {
+		return err
+	}
+	err := os.Link(src, dst)
+	// Another image that shares the blob may have linked it first
+	if err == nil || errors.Is(err, fs.ErrExist) {
+		return nil
+	}
+	return helpers.CreatePathAndCopy(src, dst)
+}
+
+// PruneImageCache removes the blobs in the image cache in dir that were not used by a package create within
+// olderThan, returning the number of blobs removed and the bytes freed.
+func PruneImageCache(dir string, olderThan time.Duration) (int, int64, error) {
+	cutoff := time.Now().Add(-olderThan)
+	removed := 0
+	var freed int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(cutoff) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		removed++
+		freed += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prune the image cache %s: %w", dir, err)
+	}
+	return removed, freed, nil
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package images
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageCache(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := filepath.Join(t.TempDir(), "images")
+	imgCache := NewImageCache(cacheDir)
+
+	img, err := random.Image(1024, 2)
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+
+	// Nothing is cached yet
+	_, err = imgCache.Image(digest)
+	require.Error(t, err)
+
+	dst, err := clayout.Write(t.TempDir(), empty.Index)
+	require.NoError(t, err)
+	err = imgCache.WriteTo(dst, img)
+	require.NoError(t, err)
+
+	// Every blob of the image is linked from the cache into the layout
+	blobs, err := imageBlobs(img)
+	require.NoError(t, err)
+	require.Len(t, blobs, 4)
+	for _, blob := range blobs {
+		cached, err := os.Stat(filepath.Join(cacheDir, "blobs", blob.Algorithm, blob.Hex))
+		require.NoError(t, err)
+		linked, err := os.Stat(filepath.Join(string(dst), "blobs", blob.Algorithm, blob.Hex))
+		require.NoError(t, err)
+		require.True(t, os.SameFile(cached, linked))
+	}
+
+	// The image is read back from the cache with the same digest and layers
+	cachedImg, err := imgCache.Image(digest)
+	require.NoError(t, err)
+	cachedDigest, err := cachedImg.Digest()
+	require.NoError(t, err)
+	require.Equal(t, digest, cachedDigest)
+	layers, err := cachedImg.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 2)
+	_, err = cachedImg.ConfigFile()
+	require.NoError(t, err)
+
+	// Reopening the cache keeps its contents
+	imgCache = NewImageCache(cacheDir)
+	_, err = imgCache.Image(digest)
+	require.NoError(t, err)
+
+	// A missing layer is a cache miss
+	err = os.Remove(filepath.Join(cacheDir, "blobs", blobs[2].Algorithm, blobs[2].Hex))
+	require.NoError(t, err)
+	_, err = imgCache.Image(digest)
+	require.Error(t, err)
+}
+
+func TestPruneImageCache(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := filepath.Join(t.TempDir(), "images")
+	imgCache := NewImageCache(cacheDir)
+
+	stale, err := random.Image(512, 1)
+	require.NoError(t, err)
+	fresh, err := random.Image(512, 1)
+	require.NoError(t, err)
+	dst, err := clayout.Write(t.TempDir(), empty.Index)
+	require.NoError(t, err)
+	for _, img := range []v1.Image{stale, fresh} {
+		err = imgCache.WriteTo(dst, img)
+		require.NoError(t, err)
+	}
+
+	staleBlobs, err := imageBlobs(stale)
+	require.NoError(t, err)
+	lastUsed := time.Now().Add(-48 * time.Hour)
+	for _, blob := range staleBlobs {
+		err = os.Chtimes(filepath.Join(cacheDir, "blobs", blob.Algorithm, blob.Hex), lastUsed, lastUsed)
+		require.NoError(t, err)
+	}
+
+	removed, freed, err := PruneImageCache(cacheDir, 24*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, len(staleBlobs), removed)
+	require.Positive(t, freed)
+
+	staleDigest, err := stale.Digest()
+	require.NoError(t, err)
+	_, err = imgCache.Image(staleDigest)
+	require.Error(t, err)
+	freshDigest, err := fresh.Digest()
+	require.NoError(t, err)
+	_, err = imgCache.Image(freshDigest)
+	require.NoError(t, err)
+
+	// Pruning a cache that does not exist is a no-op
+	removed, _, err = PruneImageCache(filepath.Join(t.TempDir(), "missing"), time.Hour)
+	require.NoError(t, err)
+	require.Zero(t, removed)
+}
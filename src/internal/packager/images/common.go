@@ -5,15 +5,17 @@
 package images
 
 import (
+	"io"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
-	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -26,9 +28,15 @@ type PullConfig struct {
 
 	Arch string
 
+	// Architectures are the platforms kept from the image index of each image in a multi-architecture package, images
+	// that need more than one architecture are pulled as an image index and the others are pulled for Arch.
+	Architectures map[transform.Image][]string
+
 	RegistryOverrides map[string]string
 
 	CacheDirectory string
+
+	LocalSources []string
 }
 
 // PushConfig is the configuration for pushing images.
@@ -44,6 +52,8 @@ type PushConfig struct {
 	Arch string
 
 	Retries int
+
+	Concurrency int
 }
 
 // NoopOpt is a no-op option for crane.
@@ -68,9 +78,13 @@ func WithArchitecture(arch string) crane.Option {
 }
 
 // CommonOpts returns a set of common options for crane under Zarf.
+//
+// The platform is not set for multi-architecture packages so references resolve to their image index.
 func CommonOpts(arch string) []crane.Option {
 	opts := WithGlobalInsecureFlag()
-	opts = append(opts, WithArchitecture(arch))
+	if arch != v1alpha1.ZarfPackageArchMulti {
+		opts = append(opts, WithArchitecture(arch))
+	}
 
 	opts = append(opts,
 		crane.WithUserAgent("zarf"),
@@ -88,9 +102,17 @@ func WithBasicAuth(username, password string) crane.Option {
 	}))
 }
 
+// PullAuth returns the authenticator for the pull user of a given registry info.
+func PullAuth(ri types.RegistryInfo) authn.Authenticator {
+	return authn.FromConfig(authn.AuthConfig{
+		Username: ri.PullUsername,
+		Password: ri.PullPassword,
+	})
+}
+
 // WithPullAuth returns an option for crane that sets pull auth from a given registry info.
 func WithPullAuth(ri types.RegistryInfo) crane.Option {
-	return WithBasicAuth(ri.PullUsername, ri.PullPassword)
+	return crane.WithAuth(PullAuth(ri))
 }
 
 // WithPushAuth returns an option for crane that sets push auth from a given registry info.
@@ -98,7 +120,7 @@ func WithPushAuth(ri types.RegistryInfo) crane.Option {
 	return WithBasicAuth(ri.PushUsername, ri.PushPassword)
 }
 
-func createPushOpts(cfg PushConfig, pb *message.ProgressBar) []crane.Option {
+func createPushOpts(cfg PushConfig, pb helpers.ProgressWriter, counter *byteCounter) []crane.Option {
 	opts := CommonOpts(cfg.Arch)
 	opts = append(opts, WithPushAuth(cfg.RegInfo))
 
@@ -107,9 +129,42 @@ func createPushOpts(cfg PushConfig, pb *message.ProgressBar) []crane.Option {
 	// TODO (@WSTARR) This is set to match the TLSHandshakeTimeout to potentially mitigate effects of https://github.com/zarf-dev/zarf/issues/1444
 	transport.ResponseHeaderTimeout = 10 * time.Second
 
-	transportWithProgressBar := helpers.NewTransport(transport, pb)
+	var roundTripper http.RoundTripper = transport
+	if counter != nil {
+		roundTripper = &countingTransport{base: roundTripper, counter: counter}
+	}
+	if pb != nil {
+		roundTripper = helpers.NewTransport(roundTripper, pb)
+	}
 
-	opts = append(opts, crane.WithTransport(transportWithProgressBar))
+	opts = append(opts, crane.WithTransport(roundTripper))
 
 	return opts
 }
+
+// byteCounter counts the bytes written to it.
+type byteCounter struct {
+	atomic.Int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.Add(int64(len(p)))
+	return len(p), nil
+}
+
+// countingTransport is an http.RoundTripper that counts the bytes of the request bodies sent to the registry.
+type countingTransport struct {
+	base    http.RoundTripper
+	counter *byteCounter
+}
+
+// RoundTrip counts the request body as it is sent.
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.TeeReader(req.Body, t.counter), req.Body}
+	}
+	return t.base.RoundTrip(req)
+}
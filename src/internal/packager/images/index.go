@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+// pullIndex pulls the image index of a reference with the images for the architectures. Indexes pinned to a digest
+// are read from the image cache when they are cached, and indexes that cannot be found on a remote are built from the
+// images of the local sources for each architecture.
+func pullIndex(ctx context.Context, refInfo transform.Image, ref string, archs []string, imgCache *ImageCache, localSources []LocalSource) (v1.ImageIndex, error) {
+	var idx v1.ImageIndex
+	if refInfo.LayoutPath != "" {
+		layoutIdx, err := LayoutIndex(refInfo)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load %s: %w", refInfo.Reference, err)
+		}
+		idx = layoutIdx
+	} else if strings.HasSuffix(ref, ".tar") || strings.HasSuffix(ref, ".tar.gz") || strings.HasSuffix(ref, ".tgz") {
+		return nil, fmt.Errorf("unable to load %s: image tarballs only contain a single platform, a multi-architecture package needs it for %s",
+			refInfo.Reference, strings.Join(archs, ", "))
+	} else if cached := cachedIndexForRef(imgCache, refInfo); cached != nil {
+		message.Debugf("Using %s from the image cache", refInfo.Reference)
+		idx = cached
+	} else {
+		desc, err := crane.Get(ref, CommonOpts(v1alpha1.ZarfPackageArchMulti)...)
+		switch {
+		case err != nil && strings.Contains(err.Error(), "unexpected status code 429 Too Many Requests"):
+			return nil, fmt.Errorf("rate limited by registry: %w", err)
+		case err != nil && (len(localSources) == 0 || refInfo.Digest != ""):
+			// An index built from local images would not match the digest of the reference
+			return nil, fmt.Errorf("unable to find %s on a remote: %w", refInfo.Reference, err)
+		case err != nil:
+			message.Warnf("Falling back to local image sources, failed to find the manifest on a remote: %s", err.Error())
+			idx, err = localIndex(ctx, localSources, ref, archs)
+			if err != nil {
+				return nil, err
+			}
+		case !desc.MediaType.IsIndex():
+			return nil, fmt.Errorf("%s is not a multi-platform image, a multi-architecture package needs it for %s",
+				refInfo.Reference, strings.Join(archs, ", "))
+		default:
+			idx, err = desc.ImageIndex()
+			if err != nil {
+				return nil, fmt.Errorf("unable to pull image index %s: %w", refInfo.Reference, err)
+			}
+		}
+	}
+
+	filtered, err := indexForArchitectures(refInfo, idx, archs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", refInfo.Reference, err)
+	}
+	return filtered, nil
+}
+
+// cachedIndexForRef returns the image index of a reference pinned to a digest from the image cache, or nil when it is
+// not cached.
+func cachedIndexForRef(imgCache *ImageCache, refInfo transform.Image) v1.ImageIndex {
+	if imgCache == nil || refInfo.Digest == "" {
+		return nil
+	}
+	digest, err := v1.NewHash(refInfo.Digest)
+	if err != nil {
+		return nil
+	}
+	idx, err := imgCache.Index(digest)
+	if err != nil {
+		return nil
+	}
+	return idx
+}
+
+// localIndex builds an image index from the image that the local sources hold for each architecture.
+func localIndex(ctx context.Context, sources []LocalSource, ref string, archs []string) (v1.ImageIndex, error) {
+	platformSources := []LocalSource{}
+	for _, source := range sources {
+		platformSources = append(platformSources, platformSource{source})
+	}
+	addenda := []mutate.IndexAddendum{}
+	for _, arch := range archs {
+		img, err := loadFromLocalSources(ctx, platformSources, ref, arch)
+		if err != nil {
+			return nil, err
+		}
+		addenda = append(addenda, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: arch}},
+		})
+	}
+	return mutate.AppendManifests(empty.Index, addenda...), nil
+}
+
+// platformSource only returns the images of a local source that are for the requested architecture, as daemons return
+// the image they hold for a reference whatever its architecture.
+type platformSource struct {
+	LocalSource
+}
+
+// Image loads the image with the reference from the source if it is for the architecture.
+func (s platformSource) Image(ctx context.Context, ref string, arch string) (v1.Image, error) {
+	img, err := s.LocalSource.Image(ctx, ref, arch)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.OS != "linux" || cfg.Architecture != arch {
+		return nil, fmt.Errorf("the image is for %s/%s and not linux/%s", cfg.OS, cfg.Architecture, arch)
+	}
+	return img, nil
+}
+
+// indexForArchitectures returns an image index with only the images for the architectures. Indexes referenced by
+// digest are kept whole as removing images from them would change their digest.
+func indexForArchitectures(refInfo transform.Image, idx v1.ImageIndex, archs []string) (v1.ImageIndex, error) {
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	for _, arch := range archs {
+		if _, err := manifestForArch(manifest, arch); err != nil {
+			return nil, err
+		}
+	}
+	if refInfo.Digest != "" {
+		return idx, nil
+	}
+	return mutate.RemoveManifests(idx, func(desc v1.Descriptor) bool {
+		return desc.Platform == nil || desc.Platform.OS != "linux" || !slices.Contains(archs, desc.Platform.Architecture)
+	}), nil
+}
+
+// indexImages returns the images of an image index, including the images of nested indexes.
+func indexImages(idx v1.ImageIndex) ([]v1.Image, error) {
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	images := []v1.Image{}
+	for _, desc := range manifest.Manifests {
+		if desc.MediaType.IsIndex() {
+			child, err := idx.ImageIndex(desc.Digest)
+			if err != nil {
+				return nil, err
+			}
+			childImages, err := indexImages(child)
+			if err != nil {
+				return nil, err
+			}
+			images = append(images, childImages...)
+			continue
+		}
+		img, err := idx.Image(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+// manifestImages returns the images of an image or an image index.
+func manifestImages(m partial.Describable) ([]v1.Image, error) {
+	switch m := m.(type) {
+	case v1.Image:
+		return []v1.Image{m}, nil
+	case v1.ImageIndex:
+		return indexImages(m)
+	default:
+		return nil, fmt.Errorf("unexpected manifest type %T", m)
+	}
+}
+
+// writeManifest writes an image or an image index to the layout, through the image cache if one is given.
+func writeManifest(cl clayout.Path, imgCache *ImageCache, m partial.Describable) error {
+	idx, ok := m.(v1.ImageIndex)
+	if !ok {
+		return writeImage(cl, imgCache, m.(v1.Image))
+	}
+	images, err := indexImages(idx)
+	if err != nil {
+		return err
+	}
+	for _, img := range images {
+		if imgCache == nil {
+			err = cl.WriteImage(img)
+		} else {
+			// Every image of an index is cached, including attestations, so that the index can be read from the cache
+			err = imgCache.WriteTo(cl, img)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if imgCache != nil {
+		if err := imgCache.WriteIndex(idx); err != nil {
+			return err
+		}
+	}
+	return writeIndexManifests(cl, idx)
+}
+
+// writeIndexManifests writes the manifest of an image index and of its nested indexes to the layout.
+func writeIndexManifests(cl clayout.Path, idx v1.ImageIndex) error {
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return err
+	}
+	for _, desc := range manifest.Manifests {
+		if !desc.MediaType.IsIndex() {
+			continue
+		}
+		child, err := idx.ImageIndex(desc.Digest)
+		if err != nil {
+			return err
+		}
+		if err := writeIndexManifests(cl, child); err != nil {
+			return err
+		}
+	}
+	rawManifest, err := idx.RawManifest()
+	if err != nil {
+		return err
+	}
+	digest, err := idx.Digest()
+	if err != nil {
+		return err
+	}
+	return cl.WriteBlob(digest, io.NopCloser(bytes.NewReader(rawManifest)))
+}
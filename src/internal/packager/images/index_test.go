@@ -0,0 +1,291 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package images
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// multiPlatformIndex returns an image index with a random image for each architecture.
+func multiPlatformIndex(t *testing.T, archs ...string) v1.ImageIndex {
+	t.Helper()
+
+	addenda := []mutate.IndexAddendum{}
+	for _, arch := range archs {
+		img, err := random.Image(256, 1)
+		require.NoError(t, err)
+		addenda = append(addenda, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: arch}},
+		})
+	}
+	return mutate.AppendManifests(empty.Index, addenda...)
+}
+
+func TestPullIndex(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	registryAddress := strings.TrimPrefix(srv.URL, "http://")
+
+	idx := multiPlatformIndex(t, "amd64", "arm64", "s390x")
+	ref, err := name.ParseReference(registryAddress + "/podinfo:6.4.0")
+	require.NoError(t, err)
+	err = remote.WriteIndex(ref, idx)
+	require.NoError(t, err)
+	idxDigest, err := idx.Digest()
+	require.NoError(t, err)
+	single, err := random.Image(256, 1)
+	require.NoError(t, err)
+	err = crane.Push(single, registryAddress+"/single:1.0.0")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		ref               string
+		archs             []string
+		expectedPlatforms int
+		expectedErr       string
+	}{
+		{
+			name:              "tag keeps the platforms of the package",
+			ref:               registryAddress + "/podinfo:6.4.0",
+			archs:             []string{"amd64", "arm64"},
+			expectedPlatforms: 2,
+		},
+		{
+			name:              "digest keeps the whole index",
+			ref:               registryAddress + "/podinfo@" + idxDigest.String(),
+			archs:             []string{"amd64", "arm64"},
+			expectedPlatforms: 3,
+		},
+		{
+			name:        "missing platform",
+			ref:         registryAddress + "/podinfo:6.4.0",
+			archs:       []string{"amd64", "ppc64le"},
+			expectedErr: "no image for linux/ppc64le in the index, available platforms are linux/amd64, linux/arm64, linux/s390x",
+		},
+		{
+			name:        "single platform image",
+			ref:         registryAddress + "/single:1.0.0",
+			archs:       []string{"amd64", "arm64"},
+			expectedErr: "is not a multi-platform image, a multi-architecture package needs it for amd64, arm64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			refInfo, err := transform.ParseImageRef(tt.ref)
+			require.NoError(t, err)
+			dst := t.TempDir()
+			pulled, err := Pull(context.Background(), PullConfig{
+				DestinationDirectory: dst,
+				ImageList:            []transform.Image{refInfo},
+				Arch:                 v1alpha1.ZarfPackageArchMulti,
+				Architectures:        map[transform.Image][]string{refInfo: tt.archs},
+			})
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			pulledIdx, ok := pulled[refInfo].(v1.ImageIndex)
+			require.True(t, ok)
+
+			// The index and all of its images are read back from the package layout
+			loaded, err := utils.LoadOCIManifest(dst, refInfo)
+			require.NoError(t, err)
+			loadedIdx, ok := loaded.(v1.ImageIndex)
+			require.True(t, ok)
+			pulledDigest, err := pulledIdx.Digest()
+			require.NoError(t, err)
+			loadedDigest, err := loadedIdx.Digest()
+			require.NoError(t, err)
+			require.Equal(t, pulledDigest, loadedDigest)
+			images, err := indexImages(loadedIdx)
+			require.NoError(t, err)
+			require.Len(t, images, tt.expectedPlatforms)
+			for _, img := range images {
+				_, err := img.ConfigFile()
+				require.NoError(t, err)
+				layers, err := img.Layers()
+				require.NoError(t, err)
+				for _, layer := range layers {
+					rc, err := layer.Compressed()
+					require.NoError(t, err)
+					require.NoError(t, rc.Close())
+				}
+			}
+
+			// The image of the first platform is used to generate the SBOM
+			img, err := utils.LoadOCIImage(dst, refInfo)
+			require.NoError(t, err)
+			firstDigest, err := images[0].Digest()
+			require.NoError(t, err)
+			imgDigest, err := img.Digest()
+			require.NoError(t, err)
+			require.Equal(t, firstDigest, imgDigest)
+		})
+	}
+}
+
+func TestPushImageIndex(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	registryAddress := strings.TrimPrefix(srv.URL, "http://")
+
+	idx := multiPlatformIndex(t, "amd64", "arm64")
+	idxDigest, err := idx.Digest()
+	require.NoError(t, err)
+	refInfo, err := transform.ParseImageRef("ghcr.io/stefanprodan/podinfo:6.4.0")
+	require.NoError(t, err)
+	push, err := newImagePush(refInfo, idx, registryAddress, false)
+	require.NoError(t, err)
+	opts := createPushOpts(PushConfig{}, nil, &byteCounter{})
+
+	err = inventoryRegistry([]*imagePush{push}, 1, opts)
+	require.NoError(t, err)
+	require.Equal(t, push.names, push.missing)
+	err = pushImage(push, opts)
+	require.NoError(t, err)
+
+	// Both references resolve to the index and to the image of each platform
+	for _, n := range push.names {
+		desc, err := crane.Head(n, opts...)
+		require.NoError(t, err)
+		require.Equal(t, idxDigest, desc.Digest)
+		require.True(t, desc.MediaType.IsIndex())
+		for _, arch := range []string{"amd64", "arm64"} {
+			_, err := crane.Digest(n, append(opts, WithArchitecture(arch))...)
+			require.NoError(t, err)
+		}
+	}
+	err = inventoryRegistry([]*imagePush{push}, 1, opts)
+	require.NoError(t, err)
+	require.Empty(t, push.missing)
+
+	size, err := calcImgSize(idx)
+	require.NoError(t, err)
+	images, err := indexImages(idx)
+	require.NoError(t, err)
+	var expectedSize int64
+	for _, img := range images {
+		imgSize, err := calcImgSize(img)
+		require.NoError(t, err)
+		expectedSize += imgSize
+	}
+	idxSize, err := idx.Size()
+	require.NoError(t, err)
+	require.Equal(t, expectedSize+idxSize, size)
+}
+
+func TestPullIndexFromCache(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	registryAddress := strings.TrimPrefix(srv.URL, "http://")
+
+	// Attestations have an unknown platform and are cached with the images of the index
+	attestation, err := random.Image(256, 1)
+	require.NoError(t, err)
+	idx := mutate.AppendManifests(multiPlatformIndex(t, "amd64", "arm64"), mutate.IndexAddendum{
+		Add:        attestation,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "unknown", Architecture: "unknown"}},
+	})
+	idxDigest, err := idx.Digest()
+	require.NoError(t, err)
+	ref, err := name.ParseReference(registryAddress + "/podinfo:6.4.0")
+	require.NoError(t, err)
+	err = remote.WriteIndex(ref, idx)
+	require.NoError(t, err)
+
+	refInfo, err := transform.ParseImageRef(registryAddress + "/podinfo@" + idxDigest.String())
+	require.NoError(t, err)
+	cacheDir := t.TempDir()
+	pull := func() v1.ImageIndex {
+		t.Helper()
+
+		pulled, err := Pull(context.Background(), PullConfig{
+			DestinationDirectory: t.TempDir(),
+			ImageList:            []transform.Image{refInfo},
+			Arch:                 v1alpha1.ZarfPackageArchMulti,
+			Architectures:        map[transform.Image][]string{refInfo: {"amd64", "arm64"}},
+			CacheDirectory:       cacheDir,
+		})
+		require.NoError(t, err)
+		pulledIdx, ok := pulled[refInfo].(v1.ImageIndex)
+		require.True(t, ok)
+		return pulledIdx
+	}
+	pull()
+
+	// The index is read from the cache once the registry is gone
+	srv.Close()
+	cached := pull()
+	cachedDigest, err := cached.Digest()
+	require.NoError(t, err)
+	require.Equal(t, idxDigest, cachedDigest)
+	images, err := indexImages(cached)
+	require.NoError(t, err)
+	require.Len(t, images, 3)
+}
+
+func TestLocalIndex(t *testing.T) {
+	t.Parallel()
+
+	platformImage := func(arch string) v1.Image {
+		t.Helper()
+
+		img, err := random.Image(256, 1)
+		require.NoError(t, err)
+		img, err = mutate.ConfigFile(img, &v1.ConfigFile{OS: "linux", Architecture: arch})
+		require.NoError(t, err)
+		return img
+	}
+	sources := []LocalSource{
+		&fakeLocalSource{name: config.DockerImageSource, img: platformImage("amd64")},
+		&fakeLocalSource{name: config.ContainerdImageSource, img: platformImage("arm64")},
+	}
+
+	idx, err := localIndex(context.Background(), sources, "podinfo:6.4.0", []string{"amd64", "arm64"})
+	require.NoError(t, err)
+	manifest, err := idx.IndexManifest()
+	require.NoError(t, err)
+	require.Len(t, manifest.Manifests, 2)
+	for i, arch := range []string{"amd64", "arm64"} {
+		require.Equal(t, arch, manifest.Manifests[i].Platform.Architecture)
+		img, err := idx.Image(manifest.Manifests[i].Digest)
+		require.NoError(t, err)
+		cfg, err := img.ConfigFile()
+		require.NoError(t, err)
+		require.Equal(t, arch, cfg.Architecture)
+	}
+
+	_, err = localIndex(context.Background(), sources, "podinfo:6.4.0", []string{"s390x"})
+	require.EqualError(t, err, "unable to load podinfo:6.4.0 from a local image source: docker: the image is for linux/amd64 and not linux/s390x\ncontainerd: the image is for linux/arm64 and not linux/s390x")
+}
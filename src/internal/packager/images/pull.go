@@ -21,16 +21,12 @@ import (
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/google/go-containerregistry/pkg/logs"
-	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
-	"github.com/google/go-containerregistry/pkg/v1/cache"
-	"github.com/google/go-containerregistry/pkg/v1/daemon"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/partial"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/types"
-	"github.com/moby/moby/client"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
@@ -61,7 +57,10 @@ func checkForIndex(refInfo transform.Image, desc *remote.Descriptor) error {
 }
 
 // Pull pulls all of the images from the given config.
-func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, error) {
+//
+// Images that a multi-architecture package needs for more than one architecture are returned as a v1.ImageIndex,
+// every other image is returned as a v1.Image.
+func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]partial.Describable, error) {
 	var longer string
 	imageCount := len(cfg.ImageList)
 	// Give some additional user feedback on larger image sets
@@ -80,28 +79,37 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 		return nil, err
 	}
 
+	localSources, err := NewLocalSources(cfg.LocalSources)
+	if err != nil {
+		return nil, err
+	}
+	defer closeLocalSources(localSources)
+
+	var imgCache *ImageCache
+	if cfg.CacheDirectory != "" {
+		imgCache = NewImageCache(cfg.CacheDirectory)
+	}
+
 	spinner := message.NewProgressSpinner("Fetching info for %d images. %s", imageCount, longer)
 	defer spinner.Stop()
 
 	logs.Warn.SetOutput(&message.DebugWriter{})
 	logs.Progress.SetOutput(&message.DebugWriter{})
 
-	eg, ectx := errgroup.WithContext(ctx)
+	eg, _ := errgroup.WithContext(ctx)
 	eg.SetLimit(10)
 
 	var shaLock sync.Mutex
 	shas := map[string]bool{}
-	opts := CommonOpts(cfg.Arch)
 
-	fetched := map[transform.Image]v1.Image{}
+	fetched := map[transform.Image]partial.Describable{}
 
 	var counter, totalBytes atomic.Int64
 
 	for _, refInfo := range cfg.ImageList {
 		refInfo := refInfo
 		eg.Go(func() error {
-			idx := counter.Add(1)
-			spinner.Updatef("Fetching image info (%d of %d)", idx, imageCount)
+			spinner.Updatef("Fetching image info (%d of %d)", counter.Add(1), imageCount)
 
 			ref := refInfo.Reference
 			for k, v := range cfg.RegistryOverrides {
@@ -110,53 +118,57 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 				}
 			}
 
-			var img v1.Image
-			var desc *remote.Descriptor
+			// Images of a multi-architecture package that are only needed for one architecture are pulled for it
+			arch := cfg.Arch
+			archs := cfg.Architectures[refInfo]
+			if len(archs) == 1 {
+				arch = archs[0]
+			}
+			opts := CommonOpts(arch)
+
+			var (
+				img  v1.Image
+				idx  v1.ImageIndex
+				desc *remote.Descriptor
+				err  error
+			)
 
-			// load from local fs if it's a tarball
-			if strings.HasSuffix(ref, ".tar") || strings.HasSuffix(ref, ".tar.gz") || strings.HasSuffix(ref, ".tgz") {
-				img, err = crane.Load(ref, opts...)
+			if len(archs) > 1 {
+				idx, err = pullIndex(ctx, refInfo, ref, archs, imgCache, localSources)
+				if err != nil {
+					return err
+				}
+			} else if refInfo.LayoutPath != "" {
+				// load from an OCI image layout directory
+				img, err = LayoutImage(refInfo, arch)
 				if err != nil {
 					return fmt.Errorf("unable to load %s: %w", refInfo.Reference, err)
 				}
-			} else {
-				reference, err := name.ParseReference(ref)
+			} else if strings.HasSuffix(ref, ".tar") || strings.HasSuffix(ref, ".tar.gz") || strings.HasSuffix(ref, ".tgz") {
+				img, err = crane.Load(ref, opts...)
 				if err != nil {
-					return fmt.Errorf("failed to parse reference: %w", err)
+					return fmt.Errorf("unable to load %s: %w", refInfo.Reference, err)
 				}
+			} else if cached := cachedImageForRef(imgCache, refInfo); cached != nil {
+				// Images pinned to a digest that are already cached do not need the network
+				message.Debugf("Using %s from the image cache", refInfo.Reference)
+				img = cached
+			} else {
 				desc, err = crane.Get(ref, opts...)
 				if err != nil {
 					if strings.Contains(err.Error(), "unexpected status code 429 Too Many Requests") {
 						return fmt.Errorf("rate limited by registry: %w", err)
 					}
-
-					message.Warnf("Falling back to local 'docker', failed to find the manifest on a remote: %s", err.Error())
-
-					// Attempt to connect to the local docker daemon.
-					cli, err := client.NewClientWithOpts(client.FromEnv)
-					if err != nil {
-						return fmt.Errorf("docker not available: %w", err)
+					if len(localSources) == 0 {
+						return fmt.Errorf("unable to find %s on a remote: %w", refInfo.Reference, err)
 					}
-					cli.NegotiateAPIVersion(ectx)
 
-					// Inspect the image to get the size.
-					rawImg, _, err := cli.ImageInspectWithRaw(ectx, ref)
-					if err != nil {
-						return err
-					}
-
-					// Warn the user if the image is large.
-					if rawImg.Size > 750*1000*1000 {
-						message.Warnf("%s is %s and may take a very long time to load via docker. "+
-							"See https://docs.zarf.dev/faq for suggestions on how to improve large local image loading operations.",
-							ref, utils.ByteFormat(float64(rawImg.Size), 2))
-					}
+					message.Warnf("Falling back to local image sources, failed to find the manifest on a remote: %s", err.Error())
 
-					// Use unbuffered opener to avoid OOM Kill issues https://github.com/zarf-dev/zarf/issues/1214.
-					// This will also take forever to load large images.
-					img, err = daemon.Image(reference, daemon.WithUnbufferedOpener())
+					// The layers are read when the image is saved, after the context of the errgroup is done
+					img, err = loadFromLocalSources(ctx, localSources, ref, arch)
 					if err != nil {
-						return fmt.Errorf("failed to load from docker daemon: %w", err)
+						return err
 					}
 				} else {
 					img, err = crane.Pull(ref, opts...)
@@ -170,48 +182,49 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 				return err
 			}
 
-			cacheImg, err := utils.OnlyHasImageLayers(img)
-			if err != nil {
-				return err
-			}
-			if cacheImg {
-				img = cache.Image(img, cache.NewFilesystemCache(cfg.CacheDirectory))
-			}
-
-			manifest, err := img.Manifest()
-			if err != nil {
-				return fmt.Errorf("unable to get manifest for %s: %w", refInfo.Reference, err)
+			var pulled partial.Describable = img
+			if idx != nil {
+				pulled = idx
+			} else if img == nil {
+				return fmt.Errorf("failed to fetch image %s", refInfo.Reference)
 			}
-			totalBytes.Add(manifest.Config.Size)
-
-			layers, err := img.Layers()
+			images, err := manifestImages(pulled)
 			if err != nil {
-				return fmt.Errorf("unable to get layers for %s: %w", refInfo.Reference, err)
+				return fmt.Errorf("unable to get the images of %s: %w", refInfo.Reference, err)
 			}
 
 			shaLock.Lock()
 			defer shaLock.Unlock()
-			for _, layer := range layers {
-				digest, err := layer.Digest()
+			for _, img := range images {
+				manifest, err := img.Manifest()
 				if err != nil {
-					return fmt.Errorf("unable to get digest for image layer: %w", err)
+					return fmt.Errorf("unable to get manifest for %s: %w", refInfo.Reference, err)
 				}
+				totalBytes.Add(manifest.Config.Size)
 
-				if _, ok := shas[digest.Hex]; !ok {
-					shas[digest.Hex] = true
-					size, err := layer.Size()
+				layers, err := img.Layers()
+				if err != nil {
+					return fmt.Errorf("unable to get layers for %s: %w", refInfo.Reference, err)
+				}
+
+				for _, layer := range layers {
+					digest, err := layer.Digest()
 					if err != nil {
-						return fmt.Errorf("unable to get size for image layer: %w", err)
+						return fmt.Errorf("unable to get digest for image layer: %w", err)
 					}
-					totalBytes.Add(size)
-				}
-			}
 
-			if img == nil {
-				return fmt.Errorf("failed to fetch image %s", refInfo.Reference)
+					if _, ok := shas[digest.Hex]; !ok {
+						shas[digest.Hex] = true
+						size, err := layer.Size()
+						if err != nil {
+							return fmt.Errorf("unable to get size for image layer: %w", err)
+						}
+						totalBytes.Add(size)
+					}
+				}
 			}
 
-			fetched[refInfo] = img
+			fetched[refInfo] = pulled
 
 			return nil
 		})
@@ -230,7 +243,7 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 	toPull := maps.Clone(fetched)
 
 	sc := func() error {
-		saved, err := SaveConcurrent(ctx, cranePath, toPull)
+		saved, err := SaveConcurrent(ctx, cranePath, imgCache, toPull)
 		for k := range saved {
 			delete(toPull, k)
 		}
@@ -238,7 +251,7 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 	}
 
 	ss := func() error {
-		saved, err := SaveSequential(ctx, cranePath, toPull)
+		saved, err := SaveSequential(ctx, cranePath, imgCache, toPull)
 		for k := range saved {
 			delete(toPull, k)
 		}
@@ -285,6 +298,37 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 	return fetched, nil
 }
 
+// cachedImageForRef returns the image a digest reference points to from the image cache, or nil if it is not cached.
+func cachedImageForRef(imgCache *ImageCache, refInfo transform.Image) v1.Image {
+	if imgCache == nil || refInfo.Digest == "" {
+		return nil
+	}
+	digest, err := v1.NewHash(refInfo.Digest)
+	if err != nil {
+		return nil
+	}
+	img, err := imgCache.Image(digest)
+	if err != nil {
+		return nil
+	}
+	return img
+}
+
+// writeImage writes an image to the layout, through the image cache if one is given and the image only has image layers.
+func writeImage(cl clayout.Path, imgCache *ImageCache, img v1.Image) error {
+	if imgCache == nil {
+		return cl.WriteImage(img)
+	}
+	cacheImg, err := utils.OnlyHasImageLayers(img)
+	if err != nil {
+		return err
+	}
+	if !cacheImg {
+		return cl.WriteImage(img)
+	}
+	return imgCache.WriteTo(cl, img)
+}
+
 // CleanupInProgressLayers removes incomplete layers from the cache.
 func CleanupInProgressLayers(ctx context.Context, img v1.Image) error {
 	layers, err := img.Layers()
@@ -304,7 +348,7 @@ func CleanupInProgressLayers(ctx context.Context, img v1.Image) error {
 				return err
 			}
 			cacheDir := filepath.Join(config.GetAbsCachePath(), layout.ImagesDir)
-			location := filepath.Join(cacheDir, digest.String())
+			location := filepath.Join(cacheDir, "blobs", digest.Algorithm, digest.Hex)
 			info, err := os.Stat(location)
 			if errors.Is(err, fs.ErrNotExist) {
 				return nil
@@ -323,17 +367,37 @@ func CleanupInProgressLayers(ctx context.Context, img v1.Image) error {
 	return eg.Wait()
 }
 
-// SaveSequential saves images sequentially.
-func SaveSequential(ctx context.Context, cl clayout.Path, m map[transform.Image]v1.Image) (map[transform.Image]v1.Image, error) {
-	saved := map[transform.Image]v1.Image{}
+// cleanupInProgressManifest removes the incomplete layers of an image or the images of an image index from the cache.
+func cleanupInProgressManifest(ctx context.Context, m partial.Describable) {
+	images, err := manifestImages(m)
+	if err == nil {
+		for _, img := range images {
+			if err = CleanupInProgressLayers(ctx, img); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		message.WarnErr(err, "failed to clean up in-progress layers, please run `zarf tools clear-cache`")
+	}
+}
+
+// SaveSequential saves images and image indexes sequentially.
+func SaveSequential(ctx context.Context, cl clayout.Path, imgCache *ImageCache, m map[transform.Image]partial.Describable) (map[transform.Image]partial.Describable, error) {
+	saved := map[transform.Image]partial.Describable{}
 	for info, img := range m {
-		annotations := map[string]string{
+		desc, err := partial.Descriptor(img)
+		if err != nil {
+			return saved, err
+		}
+		if err := writeManifest(cl, imgCache, img); err != nil {
+			cleanupInProgressManifest(ctx, img)
+			return saved, err
+		}
+		desc.Annotations = map[string]string{
 			ocispec.AnnotationBaseImageName: info.Reference,
 		}
-		if err := cl.AppendImage(img, clayout.WithAnnotations(annotations)); err != nil {
-			if err := CleanupInProgressLayers(ctx, img); err != nil {
-				message.WarnErr(err, "failed to clean up in-progress layers, please run `zarf tools clear-cache`")
-			}
+		if err := cl.AppendDescriptor(*desc); err != nil {
 			return saved, err
 		}
 		saved[info] = img
@@ -341,9 +405,9 @@ func SaveSequential(ctx context.Context, cl clayout.Path, m map[transform.Image]
 	return saved, nil
 }
 
-// SaveConcurrent saves images in a concurrent, bounded manner.
-func SaveConcurrent(ctx context.Context, cl clayout.Path, m map[transform.Image]v1.Image) (map[transform.Image]v1.Image, error) {
-	saved := map[transform.Image]v1.Image{}
+// SaveConcurrent saves images and image indexes in a concurrent, bounded manner.
+func SaveConcurrent(ctx context.Context, cl clayout.Path, imgCache *ImageCache, m map[transform.Image]partial.Describable) (map[transform.Image]partial.Describable, error) {
+	saved := map[transform.Image]partial.Describable{}
 
 	var mu sync.Mutex
 
@@ -362,10 +426,8 @@ func SaveConcurrent(ctx context.Context, cl clayout.Path, m map[transform.Image]
 					return err
 				}
 
-				if err := cl.WriteImage(img); err != nil {
-					if err := CleanupInProgressLayers(ectx, img); err != nil {
-						message.WarnErr(err, "failed to clean up in-progress layers, please run `zarf tools clear-cache`")
-					}
+				if err := writeManifest(cl, imgCache, img); err != nil {
+					cleanupInProgressManifest(ectx, img)
 					return err
 				}
 
@@ -6,54 +6,66 @@ package images
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/google/go-containerregistry/pkg/logs"
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"golang.org/x/sync/errgroup"
 )
 
+// imagePush is an image or image index and the references it is pushed to in the registry.
+type imagePush struct {
+	refInfo transform.Image
+	img     partial.Describable
+	digest  v1.Hash
+	// names are the references the image is pushed to, the first one is pushed with the image layers.
+	names []string
+	// missing are the names that do not point to the image in the registry yet.
+	missing []string
+}
+
 // Push pushes images to a registry.
+//
+// Images are pushed concurrently through a single tunnel to the registry. Images that fail to push are retried
+// while the images that were pushed are not pushed again.
 func Push(ctx context.Context, cfg PushConfig) error {
 	logs.Warn.SetOutput(&message.DebugWriter{})
 	logs.Progress.SetOutput(&message.DebugWriter{})
 
-	toPush := map[transform.Image]v1.Image{}
-	var totalSize int64
+	toPush := map[transform.Image]partial.Describable{}
 	// Build an image list from the references
 	for _, refInfo := range cfg.ImageList {
-		img, err := utils.LoadOCIImage(cfg.SourceDirectory, refInfo)
+		img, err := utils.LoadOCIManifest(cfg.SourceDirectory, refInfo)
 		if err != nil {
 			return err
 		}
 		toPush[refInfo] = img
-		imgSize, err := calcImgSize(img)
-		if err != nil {
-			return err
-		}
-		totalSize += imgSize
-	}
-
-	// If this is not a no checksum image push we will be pushing two images (the second will go faster as it checks the same layers)
-	if !cfg.NoChecksum {
-		totalSize = totalSize * 2
 	}
 
 	var (
 		err         error
 		tunnel      *cluster.Tunnel
 		registryURL = cfg.RegInfo.Address
+		progress    *syncProgressBar
+		transferred = &byteCounter{}
+		skipped     atomic.Int64
 	)
 
-	progress := message.NewProgressBar(totalSize, fmt.Sprintf("Pushing %d images", len(toPush)))
-	defer progress.Close()
-
 	if err := helpers.Retry(func() error {
 		c, _ := cluster.NewCluster()
 		if c != nil {
@@ -66,89 +78,251 @@ func Push(ctx context.Context, cfg PushConfig) error {
 			}
 		}
 
-		progress = message.NewProgressBar(totalSize, fmt.Sprintf("Pushing %d images", len(toPush)))
-		pushOptions := createPushOpts(cfg, progress)
-
-		pushImage := func(img v1.Image, name string) error {
+		wrap := func(fn func() error) error {
 			if tunnel != nil {
-				return tunnel.Wrap(func() error { return crane.Push(img, name, pushOptions...) })
+				return tunnel.Wrap(fn)
 			}
-
-			return crane.Push(img, name, pushOptions...)
+			return fn()
 		}
 
-		pushed := []transform.Image{}
-		defer func() {
-			for _, refInfo := range pushed {
-				delete(toPush, refInfo)
-			}
-		}()
+		pushes := []*imagePush{}
 		for refInfo, img := range toPush {
-			refTruncated := helpers.Truncate(refInfo.Reference, 55, true)
-			progress.Updatef(fmt.Sprintf("Pushing %s", refTruncated))
-
-			size, err := calcImgSize(img)
+			push, err := newImagePush(refInfo, img, registryURL, cfg.NoChecksum)
 			if err != nil {
 				return err
 			}
+			pushes = append(pushes, push)
+		}
+		message.Debugf("Checking %d images in the registry", len(pushes))
+		inventoryOptions := createPushOpts(cfg, nil, nil)
+		if err := wrap(func() error { return inventoryRegistry(pushes, cfg.Concurrency, inventoryOptions) }); err != nil {
+			return err
+		}
 
-			// If this is not a no checksum image push it for use with the Zarf agent
-			if !cfg.NoChecksum {
-				offlineNameCRC, err := transform.ImageTransformHost(registryURL, refInfo.Reference)
+		// The progress bar is kept across retries so it resumes from the images that were already pushed
+		if progress == nil {
+			var totalSize int64
+			for _, push := range pushes {
+				if len(push.missing) != len(push.names) {
+					continue
+				}
+				size, err := calcImgSize(push.img)
 				if err != nil {
 					return err
 				}
+				totalSize += size
+			}
+			progress = &syncProgressBar{ProgressBar: message.NewProgressBar(totalSize, fmt.Sprintf("Pushing %d images", len(toPush)))}
+		}
+		pushOptions := createPushOpts(cfg, progress, transferred)
 
-				if err = pushImage(img, offlineNameCRC); err != nil {
+		var mu sync.Mutex
+		return pushConcurrently(pushes, cfg.Concurrency, func(push *imagePush) error {
+			if len(push.missing) == 0 {
+				message.Debugf("skip %s, it is already present in the registry", push.refInfo.Reference)
+				skipped.Add(1)
+			} else {
+				refTruncated := helpers.Truncate(push.refInfo.Reference, 55, true)
+				progress.Updatef("Pushing %s", refTruncated)
+				if err := wrap(func() error { return pushImage(push, pushOptions) }); err != nil {
 					return err
 				}
+			}
+			mu.Lock()
+			delete(toPush, push.refInfo)
+			mu.Unlock()
+			return nil
+		})
+	}, cfg.Retries, 5*time.Second, message.Warnf); err != nil {
+		if progress != nil {
+			progress.Close()
+		}
+		return err
+	}
+
+	progress.Successf("Pushed %d images, %d were already present in the registry (%s transferred)",
+		int64(len(cfg.ImageList))-skipped.Load(), skipped.Load(), utils.ByteFormat(float64(transferred.Load()), 2))
+
+	return nil
+}
 
-				totalSize -= size
+// pushConcurrently runs the push of every image with at most concurrency pushes at a time. Every push runs even if
+// others fail and the errors of all failed pushes are returned.
+func pushConcurrently(pushes []*imagePush, concurrency int, pushFn func(*imagePush) error) error {
+	var (
+		mu   sync.Mutex
+		errs []error
+		g    errgroup.Group
+	)
+	g.SetLimit(max(concurrency, 1))
+	for _, push := range pushes {
+		g.Go(func() error {
+			if err := pushFn(push); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("unable to push %s: %w", push.refInfo.Reference, err))
+				mu.Unlock()
 			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return errors.Join(errs...)
+}
+
+// syncProgressBar serializes the updates of a progress bar that is shared by concurrent pushes.
+type syncProgressBar struct {
+	mu sync.Mutex
+	*message.ProgressBar
+}
+
+// Updatef updates the text of the progress bar.
+func (p *syncProgressBar) Updatef(format string, a ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ProgressBar.Updatef(format, a...)
+}
+
+// Write adds the number of bytes in a buffer to the progress bar.
+func (p *syncProgressBar) Write(data []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ProgressBar.Write(data)
+}
+
+// newImagePush returns the references an image or image index is pushed to in the registry.
+func newImagePush(refInfo transform.Image, img partial.Describable, registryURL string, noChecksum bool) (*imagePush, error) {
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, err
+	}
+	names, err := PushNames(registryURL, refInfo, noChecksum)
+	if err != nil {
+		return nil, err
+	}
+	return &imagePush{refInfo: refInfo, img: img, digest: digest, names: names}, nil
+}
+
+// PushNames returns the references an image is pushed to in the registry.
+func PushNames(registryURL string, refInfo transform.Image, noChecksum bool) ([]string, error) {
+	names := []string{}
 
-			// To allow for other non-zarf workloads to easily see the images upload a non-checksum version
-			// (this may result in collisions but this is acceptable for this use case)
-			offlineName, err := transform.ImageTransformHostWithoutChecksum(registryURL, refInfo.Reference)
+	// If this is not a no checksum image push it for use with the Zarf agent
+	if !noChecksum {
+		offlineNameCRC, err := transform.ImageTransformHost(registryURL, refInfo.Reference)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, offlineNameCRC)
+	}
+
+	// To allow for other non-zarf workloads to easily see the images upload a non-checksum version
+	// (this may result in collisions but this is acceptable for this use case)
+	offlineName, err := transform.ImageTransformHostWithoutChecksum(registryURL, refInfo.Reference)
+	if err != nil {
+		return nil, err
+	}
+	// Images referenced by digest are pushed to the same reference with or without a checksum
+	if len(names) == 0 || names[0] != offlineName {
+		names = append(names, offlineName)
+	}
+	return names, nil
+}
+
+// inventoryRegistry looks up the manifest of every reference in the registry and records the references that do not
+// point to the image yet. Images are looked up with at most concurrency images at a time.
+func inventoryRegistry(pushes []*imagePush, concurrency int, opts []crane.Option) error {
+	return pushConcurrently(pushes, concurrency, func(push *imagePush) error {
+		push.missing = nil
+		for _, name := range push.names {
+			desc, err := crane.Head(name, opts...)
+			var terr *transport.Error
+			if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+				push.missing = append(push.missing, name)
+				continue
+			}
 			if err != nil {
-				return err
+				return fmt.Errorf("unable to check for %s in the registry: %w", name, err)
 			}
+			if desc.Digest != push.digest {
+				push.missing = append(push.missing, name)
+			}
+		}
+		return nil
+	})
+}
 
-			message.Debugf("push %s -> %s)", refInfo.Reference, offlineName)
+// pushImage pushes the layers of an image or the images of an image index once and points the remaining references
+// at its manifest.
+func pushImage(push *imagePush, opts []crane.Option) error {
+	taggable, ok := push.img.(remote.Taggable)
+	if !ok {
+		return fmt.Errorf("unexpected manifest type %T", push.img)
+	}
 
-			if err = pushImage(img, offlineName); err != nil {
+	names := push.missing
+	o := crane.GetOptions(opts...)
+	// Only push the layers if no reference to the image exists yet, otherwise its blobs are already in the repository
+	if len(push.missing) == len(push.names) {
+		message.Debugf("push %s -> %s", push.refInfo.Reference, names[0])
+		if idx, ok := push.img.(v1.ImageIndex); ok {
+			ref, err := name.ParseReference(names[0], o.Name...)
+			if err != nil {
 				return err
 			}
-
-			pushed = append(pushed, refInfo)
-			totalSize -= size
+			if err := remote.WriteIndex(ref, idx, o.Remote...); err != nil {
+				return err
+			}
+		} else if err := crane.Push(push.img.(v1.Image), names[0], opts...); err != nil {
+			return err
+		}
+		names = names[1:]
+	}
+	for _, n := range names {
+		message.Debugf("tag %s -> %s", push.refInfo.Reference, n)
+		ref, err := name.ParseReference(n, o.Name...)
+		if err != nil {
+			return err
+		}
+		if err := remote.Put(ref, taggable, o.Remote...); err != nil {
+			return err
 		}
-		return nil
-	}, cfg.Retries, 5*time.Second, message.Warnf); err != nil {
-		return err
 	}
-
-	progress.Successf("Pushed %d images", len(cfg.ImageList))
-
 	return nil
 }
 
-func calcImgSize(img v1.Image) (int64, error) {
-	size, err := img.Size()
-	if err != nil {
-		return size, err
+// calcImgSize returns the size of the manifests, configs and layers of an image or of the images of an image index.
+func calcImgSize(img partial.Describable) (int64, error) {
+	var size int64
+	if _, ok := img.(v1.ImageIndex); ok {
+		indexSize, err := img.Size()
+		if err != nil {
+			return size, err
+		}
+		size += indexSize
 	}
 
-	layers, err := img.Layers()
+	images, err := manifestImages(img)
 	if err != nil {
 		return size, err
 	}
+	for _, img := range images {
+		imgSize, err := img.Size()
+		if err != nil {
+			return size, err
+		}
+		size += imgSize
 
-	for _, layer := range layers {
-		ls, err := layer.Size()
+		layers, err := img.Layers()
 		if err != nil {
 			return size, err
 		}
-		size += ls
+		for _, layer := range layers {
+			ls, err := layer.Size()
+			if err != nil {
+				return size, err
+			}
+			size += ls
+		}
 	}
 
 	return size, nil
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package images
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+func TestNewImagePush(t *testing.T) {
+	t.Parallel()
+
+	img, err := random.Image(256, 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		ref           string
+		noChecksum    bool
+		expectedNames []string
+	}{
+		{
+			name:          "tagged image",
+			ref:           "nginx:1.21",
+			expectedNames: []string{"127.0.0.1:31999/library/nginx:1.21-zarf-3793515731", "127.0.0.1:31999/library/nginx:1.21"},
+		},
+		{
+			name:          "tagged image without checksum",
+			ref:           "nginx:1.21",
+			noChecksum:    true,
+			expectedNames: []string{"127.0.0.1:31999/library/nginx:1.21"},
+		},
+		{
+			name:          "image referenced by digest",
+			ref:           "nginx@sha256:0b694ca1c33afae97b7471488e07968599f1d2470c629f76af67145ca64428af",
+			expectedNames: []string{"127.0.0.1:31999/library/nginx@sha256:0b694ca1c33afae97b7471488e07968599f1d2470c629f76af67145ca64428af"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			refInfo, err := transform.ParseImageRef(tt.ref)
+			require.NoError(t, err)
+			push, err := newImagePush(refInfo, img, "127.0.0.1:31999", tt.noChecksum)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedNames, push.names)
+		})
+	}
+}
+
+func TestPushImageSkipsPresentBlobs(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	requests := []string{}
+	reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			mu.Lock()
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			mu.Unlock()
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	registryAddress := strings.TrimPrefix(srv.URL, "http://")
+	writes := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		written := requests
+		requests = []string{}
+		return written
+	}
+
+	img, err := random.Image(1024, 2)
+	require.NoError(t, err)
+	refInfo, err := transform.ParseImageRef("nginx:1.21")
+	require.NoError(t, err)
+	push, err := newImagePush(refInfo, img, registryAddress, false)
+	require.NoError(t, err)
+	counter := &byteCounter{}
+	opts := createPushOpts(PushConfig{}, nil, counter)
+
+	// Nothing is in the registry so the image is pushed once and tagged a second time
+	err = inventoryRegistry([]*imagePush{push}, 1, opts)
+	require.NoError(t, err)
+	require.Equal(t, push.names, push.missing)
+	err = pushImage(push, opts)
+	require.NoError(t, err)
+	size, err := calcImgSize(img)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, counter.Load(), size)
+	// Two layers and a config blob are each uploaded in three requests, followed by the two manifests
+	require.Len(t, writes(), 11)
+
+	// Both references already point to the image
+	err = inventoryRegistry([]*imagePush{push}, 1, opts)
+	require.NoError(t, err)
+	require.Empty(t, push.missing)
+	require.Empty(t, writes())
+
+	// A colliding image overwrote the reference without a checksum so only the manifest is put back
+	other, err := random.Image(256, 1)
+	require.NoError(t, err)
+	err = crane.Push(other, push.names[1])
+	require.NoError(t, err)
+	writes()
+	err = inventoryRegistry([]*imagePush{push}, 1, opts)
+	require.NoError(t, err)
+	require.Equal(t, push.names[1:], push.missing)
+	err = pushImage(push, opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{"PUT /v2/library/nginx/manifests/1.21"}, writes())
+}
+
+func TestPushConcurrently(t *testing.T) {
+	t.Parallel()
+
+	pushes := []*imagePush{}
+	for i := range 10 {
+		refInfo, err := transform.ParseImageRef(fmt.Sprintf("nginx:1.%d", i))
+		require.NoError(t, err)
+		pushes = append(pushes, &imagePush{refInfo: refInfo})
+	}
+
+	var (
+		inFlight    atomic.Int64
+		maxInFlight atomic.Int64
+		mu          sync.Mutex
+		pushed      = []string{}
+	)
+	err := pushConcurrently(pushes, 3, func(push *imagePush) error {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if push.refInfo.Tag == "1.3" || push.refInfo.Tag == "1.7" {
+			return errors.New("connection reset")
+		}
+		mu.Lock()
+		pushed = append(pushed, push.refInfo.Tag)
+		mu.Unlock()
+		return nil
+	})
+	require.ErrorContains(t, err, "unable to push docker.io/library/nginx:1.3: connection reset")
+	require.ErrorContains(t, err, "unable to push docker.io/library/nginx:1.7: connection reset")
+	require.Len(t, pushed, 8)
+	require.LessOrEqual(t, maxInFlight.Load(), int64(3))
+}
+
+func TestInventoryRegistryConcurrently(t *testing.T) {
+	t.Parallel()
+
+	var (
+		inFlight    atomic.Int64
+		maxInFlight atomic.Int64
+	)
+	reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				observed := maxInFlight.Load()
+				if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	registryAddress := strings.TrimPrefix(srv.URL, "http://")
+
+	img, err := random.Image(256, 1)
+	require.NoError(t, err)
+	pushes := []*imagePush{}
+	for i := range 9 {
+		refInfo, err := transform.ParseImageRef(fmt.Sprintf("nginx:1.%d", i))
+		require.NoError(t, err)
+		push, err := newImagePush(refInfo, img, registryAddress, false)
+		require.NoError(t, err)
+		pushes = append(pushes, push)
+	}
+	err = crane.Push(img, pushes[0].names[0])
+	require.NoError(t, err)
+
+	err = inventoryRegistry(pushes, 3, createPushOpts(PushConfig{}, nil, nil))
+	require.NoError(t, err)
+	require.Equal(t, pushes[0].names[1:], pushes[0].missing)
+	for _, push := range pushes[1:] {
+		require.Equal(t, push.names, push.missing)
+	}
+	require.Greater(t, maxInFlight.Load(), int64(1))
+	require.LessOrEqual(t, maxInFlight.Load(), int64(3))
+}
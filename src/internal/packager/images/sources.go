@@ -0,0 +1,386 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/distribution/reference"
+	"github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/daemon"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// LocalSource loads images that cannot be pulled from a registry from the local machine. Sources that hold a connection
+// to read the layers of the images they loaded can implement io.Closer to be closed once the images are saved.
+type LocalSource interface {
+	// Name returns the name of the source.
+	Name() string
+	// Image loads the image with the reference for the architecture from the source.
+	Image(ctx context.Context, ref string, arch string) (v1.Image, error)
+}
+
+// NewLocalSources returns the local image sources with the names, in order.
+func NewLocalSources(names []string) ([]LocalSource, error) {
+	sources := []LocalSource{}
+	for _, sourceName := range names {
+		switch sourceName {
+		case config.DockerImageSource:
+			sources = append(sources, &daemonSource{name: config.DockerImageSource})
+		case config.PodmanImageSource:
+			sources = append(sources, &daemonSource{name: config.PodmanImageSource, host: podmanHost()})
+		case config.ContainerdImageSource:
+			sources = append(sources, newContainerdSource())
+		default:
+			return nil, fmt.Errorf("unknown local image source %q, must be one of %s", sourceName, strings.Join(config.ZarfDefaultImageSources, ", "))
+		}
+	}
+	return sources, nil
+}
+
+// loadFromLocalSources loads an image from the first local source that has it.
+func loadFromLocalSources(ctx context.Context, sources []LocalSource, ref string, arch string) (v1.Image, error) {
+	errs := []error{}
+	for _, source := range sources {
+		img, err := source.Image(ctx, ref, arch)
+		if err == nil {
+			message.Debugf("Loaded %s from the local %s image source", ref, source.Name())
+			return img, nil
+		}
+		message.Debugf("Unable to load %s from the local %s image source: %s", ref, source.Name(), err.Error())
+		errs = append(errs, fmt.Errorf("%s: %w", source.Name(), err))
+	}
+	return nil, fmt.Errorf("unable to load %s from a local image source: %w", ref, errors.Join(errs...))
+}
+
+// closeLocalSources closes the local sources that hold a connection once the images loaded from them are saved.
+func closeLocalSources(sources []LocalSource) {
+	for _, source := range sources {
+		closer, ok := source.(io.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			message.Debugf("Unable to close the local %s image source: %s", source.Name(), err.Error())
+		}
+	}
+}
+
+// daemonSource loads images from a daemon that serves the docker engine API.
+type daemonSource struct {
+	name string
+	// host is the address of the daemon, the docker environment variables are used when empty.
+	host string
+
+	mu  sync.Mutex
+	cli *client.Client
+}
+
+// Name returns the name of the source.
+func (s *daemonSource) Name() string {
+	return s.name
+}
+
+// Image loads the image with the reference from the daemon.
+func (s *daemonSource) Image(ctx context.Context, ref string, _ string) (v1.Image, error) {
+	reference, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reference: %w", err)
+	}
+
+	cli, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s not available: %w", s.name, err)
+	}
+
+	// Inspect the image to get the size.
+	rawImg, _, err := cli.ImageInspectWithRaw(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// Warn the user if the image is large.
+	if rawImg.Size > 750*1000*1000 {
+		message.Warnf("%s is %s and may take a very long time to load via %s. "+
+			"See https://docs.zarf.dev/faq for suggestions on how to improve large local image loading operations.",
+			ref, utils.ByteFormat(float64(rawImg.Size), 2), s.name)
+	}
+
+	// Use unbuffered opener to avoid OOM Kill issues https://github.com/zarf-dev/zarf/issues/1214.
+	// This will also take forever to load large images.
+	img, err := daemon.Image(reference, daemon.WithClient(cli), daemon.WithUnbufferedOpener())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load from %s: %w", s.name, err)
+	}
+	return img, nil
+}
+
+// client returns the client of the daemon, it stays open as the layers of the images are read after they are loaded.
+func (s *daemonSource) client(ctx context.Context) (*client.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cli != nil {
+		return s.cli, nil
+	}
+	opts := []client.Opt{client.FromEnv}
+	if s.host != "" {
+		opts = append(opts, client.WithHost(s.host))
+	}
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, err
+	}
+	cli.NegotiateAPIVersion(ctx)
+	s.cli = cli
+	return s.cli, nil
+}
+
+// Close closes the client of the daemon.
+func (s *daemonSource) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cli == nil {
+		return nil
+	}
+	err := s.cli.Close()
+	s.cli = nil
+	return err
+}
+
+// podmanHost returns the address of the podman API socket.
+func podmanHost() string {
+	if host := os.Getenv("CONTAINER_HOST"); host != "" {
+		return host
+	}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" && os.Getuid() != 0 {
+		return "unix://" + filepath.Join(runtimeDir, "podman", "podman.sock")
+	}
+	return "unix:///run/podman/podman.sock"
+}
+
+// containerdSource loads images from the content store of containerd.
+type containerdSource struct {
+	address   string
+	namespace string
+
+	mu        sync.Mutex
+	cli       *containerd.Client
+	clientErr error
+}
+
+func newContainerdSource() *containerdSource {
+	s := &containerdSource{
+		address:   "/run/containerd/containerd.sock",
+		namespace: "default",
+	}
+	if address := os.Getenv("CONTAINERD_ADDRESS"); address != "" {
+		s.address = address
+	}
+	if namespace := os.Getenv("CONTAINERD_NAMESPACE"); namespace != "" {
+		s.namespace = namespace
+	}
+	return s
+}
+
+// client returns the client of containerd, it stays open as the layers of the images are read after they are loaded.
+// A failure to connect is kept so that containerd is not dialed again for every image.
+func (s *containerdSource) client() (*containerd.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cli == nil && s.clientErr == nil {
+		s.cli, s.clientErr = containerd.New(s.address, containerd.WithDefaultNamespace(s.namespace))
+	}
+	return s.cli, s.clientErr
+}
+
+// Close closes the client of containerd.
+func (s *containerdSource) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cli == nil {
+		return nil
+	}
+	err := s.cli.Close()
+	s.cli = nil
+	return err
+}
+
+// Name returns the name of the source.
+func (s *containerdSource) Name() string {
+	return config.ContainerdImageSource
+}
+
+// Image loads the image with the reference for the architecture from the content store.
+func (s *containerdSource) Image(ctx context.Context, ref string, arch string) (v1.Image, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reference: %w", err)
+	}
+	cli, err := s.client()
+	if err != nil {
+		return nil, fmt.Errorf("containerd not available: %w", err)
+	}
+	ctx = namespaces.WithNamespace(ctx, s.namespace)
+	record, err := cli.ImageService().Get(ctx, reference.TagNameOnly(named).String())
+	if err != nil {
+		return nil, err
+	}
+
+	store := cli.ContentStore()
+	blob := func(h v1.Hash) (io.ReadCloser, error) {
+		ra, err := store.ReaderAt(ctx, ocispec.Descriptor{Digest: digest.Digest(h.String())})
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{content.NewReader(ra), ra}, nil
+	}
+	readBlob := func(h v1.Hash) ([]byte, error) {
+		rc, err := blob(h)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+
+	targetDigest, err := v1.NewHash(record.Target.Digest.String())
+	if err != nil {
+		return nil, err
+	}
+	desc := v1.Descriptor{MediaType: types.MediaType(record.Target.MediaType), Digest: targetDigest}
+	if desc.MediaType.IsIndex() {
+		rawIndex, err := readBlob(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		index, err := v1.ParseIndexManifest(bytes.NewReader(rawIndex))
+		if err != nil {
+			return nil, err
+		}
+		desc, err = manifestForArch(index, arch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rawManifest, err := readBlob(desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(rawManifest))
+	if err != nil {
+		return nil, err
+	}
+	return partial.CompressedToImage(&blobImage{rawManifest: rawManifest, manifest: manifest, blob: blob})
+}
+
+// LayoutImage loads the image of an oci-layout:// reference from its OCI image layout directory.
+func LayoutImage(refInfo transform.Image, arch string) (v1.Image, error) {
+	index, desc, err := layoutDescriptor(refInfo)
+	if err != nil {
+		return nil, err
+	}
+	if desc.MediaType.IsIndex() {
+		child, err := index.ImageIndex(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		childManifest, err := child.IndexManifest()
+		if err != nil {
+			return nil, err
+		}
+		platformDesc, err := manifestForArch(childManifest, arch)
+		if err != nil {
+			return nil, fmt.Errorf("%s%s: %w", refInfo.Name, refInfo.TagOrDigest, err)
+		}
+		return child.Image(platformDesc.Digest)
+	}
+	return index.Image(desc.Digest)
+}
+
+// LayoutIndex loads the image index of an oci-layout:// reference from its OCI image layout directory.
+func LayoutIndex(refInfo transform.Image) (v1.ImageIndex, error) {
+	index, desc, err := layoutDescriptor(refInfo)
+	if err != nil {
+		return nil, err
+	}
+	if !desc.MediaType.IsIndex() {
+		return nil, fmt.Errorf("%s%s is not a multi-platform image in the image layout %s", refInfo.Name, refInfo.TagOrDigest, refInfo.LayoutPath)
+	}
+	return index.ImageIndex(desc.Digest)
+}
+
+// layoutDescriptor returns the index of the OCI image layout of an oci-layout:// reference and the descriptor in it
+// that the reference points to.
+func layoutDescriptor(refInfo transform.Image) (v1.ImageIndex, v1.Descriptor, error) {
+	path, err := clayout.FromPath(refInfo.LayoutPath)
+	if err != nil {
+		return nil, v1.Descriptor{}, fmt.Errorf("unable to open the image layout %s: %w", refInfo.LayoutPath, err)
+	}
+	index, err := path.ImageIndex()
+	if err != nil {
+		return nil, v1.Descriptor{}, err
+	}
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, v1.Descriptor{}, err
+	}
+	for _, manifest := range indexManifest.Manifests {
+		if layoutDescriptorMatches(manifest, refInfo) {
+			return index, manifest, nil
+		}
+	}
+	return nil, v1.Descriptor{}, fmt.Errorf("unable to find %s%s in the image layout %s", refInfo.Name, refInfo.TagOrDigest, refInfo.LayoutPath)
+}
+
+// layoutDescriptorMatches returns whether a descriptor in the index of an image layout is the image of the reference.
+// Tags are matched against the org.opencontainers.image.ref.name annotation, which holds either the tag or the full
+// reference depending on the tool that wrote the layout.
+func layoutDescriptorMatches(desc v1.Descriptor, refInfo transform.Image) bool {
+	if refInfo.Digest != "" {
+		return desc.Digest.String() == refInfo.Digest
+	}
+	refName := desc.Annotations[ocispec.AnnotationRefName]
+	return refName == refInfo.Tag || strings.HasSuffix(refName, ":"+refInfo.Tag)
+}
+
+// manifestForArch returns the descriptor of the image for the architecture from an image index.
+func manifestForArch(index *v1.IndexManifest, arch string) (v1.Descriptor, error) {
+	available := []string{}
+	for _, desc := range index.Manifests {
+		if desc.Platform == nil {
+			continue
+		}
+		if desc.Platform.OS == "linux" && desc.Platform.Architecture == arch {
+			return desc, nil
+		}
+		available = append(available, desc.Platform.String())
+	}
+	return v1.Descriptor{}, fmt.Errorf("no image for linux/%s in the index, available platforms are %s", arch, strings.Join(available, ", "))
+}
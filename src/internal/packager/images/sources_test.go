@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package images
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+type fakeLocalSource struct {
+	name string
+	img  v1.Image
+}
+
+func (s *fakeLocalSource) Name() string {
+	return s.name
+}
+
+func (s *fakeLocalSource) Image(_ context.Context, _ string, _ string) (v1.Image, error) {
+	if s.img == nil {
+		return nil, errors.New("image not found")
+	}
+	return s.img, nil
+}
+
+func TestNewLocalSources(t *testing.T) {
+	t.Parallel()
+
+	sources, err := NewLocalSources([]string{config.ContainerdImageSource, config.DockerImageSource})
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	require.Equal(t, config.ContainerdImageSource, sources[0].Name())
+	require.Equal(t, config.DockerImageSource, sources[1].Name())
+
+	_, err = NewLocalSources([]string{"buildah"})
+	require.EqualError(t, err, `unknown local image source "buildah", must be one of docker, podman, containerd`)
+}
+
+func TestLoadFromLocalSources(t *testing.T) {
+	t.Parallel()
+
+	img, err := random.Image(256, 1)
+	require.NoError(t, err)
+
+	sources := []LocalSource{
+		&fakeLocalSource{name: config.DockerImageSource},
+		&fakeLocalSource{name: config.PodmanImageSource, img: img},
+		&fakeLocalSource{name: config.ContainerdImageSource},
+	}
+	loaded, err := loadFromLocalSources(context.Background(), sources, "nginx:1.21", "amd64")
+	require.NoError(t, err)
+	require.Equal(t, img, loaded)
+
+	_, err = loadFromLocalSources(context.Background(), sources[2:], "nginx:1.21", "amd64")
+	require.EqualError(t, err, "unable to load nginx:1.21 from a local image source: containerd: image not found")
+}
+
+func TestLayoutImage(t *testing.T) {
+	t.Parallel()
+
+	amd64, err := random.Image(256, 1)
+	require.NoError(t, err)
+	arm64, err := random.Image(256, 1)
+	require.NoError(t, err)
+	single, err := random.Image(256, 1)
+	require.NoError(t, err)
+	idx := mutate.AppendManifests(empty.Index,
+		mutate.IndexAddendum{Add: amd64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+		mutate.IndexAddendum{Add: arm64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+	)
+
+	layoutPath := filepath.Join(t.TempDir(), "podinfo")
+	path, err := clayout.Write(layoutPath, empty.Index)
+	require.NoError(t, err)
+	err = path.AppendImage(single, clayout.WithAnnotations(map[string]string{ocispec.AnnotationRefName: "6.4.0"}))
+	require.NoError(t, err)
+	err = path.AppendIndex(idx, clayout.WithAnnotations(map[string]string{ocispec.AnnotationRefName: "ghcr.io/stefanprodan/podinfo:6.4.1"}))
+	require.NoError(t, err)
+	singleDigest, err := single.Digest()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		ref         string
+		arch        string
+		expected    v1.Image
+		expectedErr string
+	}{
+		{
+			name:     "tag annotation",
+			ref:      "oci-layout://" + layoutPath + ":6.4.0",
+			expected: single,
+		},
+		{
+			name:     "digest",
+			ref:      "oci-layout://" + layoutPath + "@" + singleDigest.String(),
+			expected: single,
+		},
+		{
+			name:     "index for the architecture",
+			ref:      "oci-layout://" + layoutPath + ":6.4.1",
+			arch:     "arm64",
+			expected: arm64,
+		},
+		{
+			name:        "index without the architecture",
+			ref:         "oci-layout://" + layoutPath + ":6.4.1",
+			arch:        "s390x",
+			expectedErr: "no image for linux/s390x in the index, available platforms are linux/amd64, linux/arm64",
+		},
+		{
+			name:        "missing tag",
+			ref:         "oci-layout://" + layoutPath + ":6.5.0",
+			expectedErr: "unable to find docker.io/library/podinfo:6.5.0 in the image layout",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			refInfo, err := transform.ParseImageRef(tt.ref)
+			require.NoError(t, err)
+			img, err := LayoutImage(refInfo, tt.arch)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			expectedDigest, err := tt.expected.Digest()
+			require.NoError(t, err)
+			digest, err := img.Digest()
+			require.NoError(t, err)
+			require.Equal(t, expectedDigest, digest)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/anchore/stereoscope/pkg/file"
 	"github.com/anchore/stereoscope/pkg/image"
@@ -62,8 +63,15 @@ func Catalog(componentSBOMs map[string]*layout.ComponentSBOM, imageList []transf
 	// Ensure the sbom directory exists
 	_ = helpers.CreateDirectory(builder.outputDir, helpers.ReadWriteExecuteUser)
 
+	// Load the images, every platform of an image index gets its own SBOM
+	sbomImages, err := loadSBOMImages(paths.Images.Base, imageList)
+	if err != nil {
+		builder.spinner.Errorf(err, "Unable to load the image to generate an SBOM")
+		return err
+	}
+
 	// Generate a list of images and files for the sbom viewer
-	json, err := builder.generateJSONList(componentSBOMs, imageList)
+	json, err := builder.generateJSONList(componentSBOMs, sbomImages)
 	if err != nil {
 		builder.spinner.Errorf(err, "Unable to generate the SBOM image list")
 		return err
@@ -71,29 +79,19 @@ func Catalog(componentSBOMs map[string]*layout.ComponentSBOM, imageList []transf
 	builder.jsonList = json
 
 	// Generate SBOM for each image
-	currImage := 1
-	for _, refInfo := range imageList {
-		builder.spinner.Updatef("Creating image SBOMs (%d of %d): %s", currImage, imageCount, refInfo.Reference)
+	for idx, sbomImg := range sbomImages {
+		builder.spinner.Updatef("Creating image SBOMs (%d of %d): %s", idx+1, len(sbomImages), sbomImg.identifier)
 
-		// Get the image that we are creating an SBOM for
-		img, err := utils.LoadOCIImage(paths.Images.Base, refInfo)
+		jsonData, err := builder.createImageSBOM(sbomImg.img, sbomImg.reference, sbomImg.identifier)
 		if err != nil {
-			builder.spinner.Errorf(err, "Unable to load the image to generate an SBOM")
+			builder.spinner.Errorf(err, "Unable to create SBOM for image %s", sbomImg.identifier)
 			return err
 		}
 
-		jsonData, err := builder.createImageSBOM(img, refInfo.Reference)
-		if err != nil {
-			builder.spinner.Errorf(err, "Unable to create SBOM for image %s", refInfo.Reference)
+		if err = builder.createSBOMViewerAsset(sbomImg.identifier, jsonData); err != nil {
+			builder.spinner.Errorf(err, "Unable to create SBOM viewer for image %s", sbomImg.identifier)
 			return err
 		}
-
-		if err = builder.createSBOMViewerAsset(refInfo.Reference, jsonData); err != nil {
-			builder.spinner.Errorf(err, "Unable to create SBOM viewer for image %s", refInfo.Reference)
-			return err
-		}
-
-		currImage++
 	}
 
 	currComponent := 1
@@ -139,9 +137,50 @@ func Catalog(componentSBOMs map[string]*layout.ComponentSBOM, imageList []transf
 	return nil
 }
 
+// sbomImage is an image to generate an SBOM for.
+type sbomImage struct {
+	// identifier names the SBOM files of the image
+	identifier string
+	reference  string
+	img        v1.Image
+}
+
+// loadSBOMImages loads the images to generate SBOMs for from the package. The image of every platform in an image
+// index is returned with the platform appended to its identifier.
+func loadSBOMImages(imagesPath string, imageList []transform.Image) ([]sbomImage, error) {
+	sbomImages := []sbomImage{}
+	for _, refInfo := range imageList {
+		m, err := utils.LoadOCIManifest(imagesPath, refInfo)
+		if err != nil {
+			return nil, err
+		}
+		idx, ok := m.(v1.ImageIndex)
+		if !ok {
+			sbomImages = append(sbomImages, sbomImage{identifier: refInfo.Reference, reference: refInfo.Reference, img: m.(v1.Image)})
+			continue
+		}
+		descs, err := utils.IndexPlatformImages(idx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the images of %s: %w", refInfo.Reference, err)
+		}
+		for _, desc := range descs {
+			img, err := idx.Image(desc.Digest)
+			if err != nil {
+				return nil, err
+			}
+			sbomImages = append(sbomImages, sbomImage{
+				identifier: fmt.Sprintf("%s-%s", refInfo.Reference, strings.ReplaceAll(desc.Platform.String(), "/", "-")),
+				reference:  refInfo.Reference,
+				img:        img,
+			})
+		}
+	}
+	return sbomImages, nil
+}
+
 // createImageSBOM uses syft to generate SBOM for an image,
 // some code/structure migrated from https://github.com/testifysec/go-witness/blob/v0.1.12/attestation/syft/syft.go.
-func (b *Builder) createImageSBOM(img v1.Image, src string) ([]byte, error) {
+func (b *Builder) createImageSBOM(img v1.Image, src string, identifier string) ([]byte, error) {
 	// Get the image reference.
 	refInfo, err := transform.ParseImageRef(src)
 	if err != nil {
@@ -188,8 +227,8 @@ func (b *Builder) createImageSBOM(img v1.Image, src string) ([]byte, error) {
 		return nil, err
 	}
 
-	// Write the sbom to disk using the image ref as the filename
-	filename := fmt.Sprintf("%s.json", refInfo.Reference)
+	// Write the sbom to disk using the image identifier as the filename
+	filename := fmt.Sprintf("%s.json", identifier)
 	sbomFile, err := b.createSBOMFile(filename)
 	if err != nil {
 		return nil, err
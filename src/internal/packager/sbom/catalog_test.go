@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package sbom
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+func TestLoadSBOMImages(t *testing.T) {
+	t.Parallel()
+
+	addenda := []mutate.IndexAddendum{}
+	for _, platform := range []v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64", Variant: "v8"},
+		{OS: "unknown", Architecture: "unknown"},
+	} {
+		img, err := random.Image(256, 1)
+		require.NoError(t, err)
+		addenda = append(addenda, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: &platform},
+		})
+	}
+	idx := mutate.AppendManifests(empty.Index, addenda...)
+	single, err := random.Image(256, 1)
+	require.NoError(t, err)
+
+	multiRef, err := transform.ParseImageRef("ghcr.io/stefanprodan/podinfo:6.4.0")
+	require.NoError(t, err)
+	singleRef, err := transform.ParseImageRef("ghcr.io/example/helper:1.0.0")
+	require.NoError(t, err)
+	dir := t.TempDir()
+	p, err := clayout.Write(dir, empty.Index)
+	require.NoError(t, err)
+	err = p.AppendIndex(idx, clayout.WithAnnotations(map[string]string{ocispec.AnnotationBaseImageName: multiRef.Reference}))
+	require.NoError(t, err)
+	err = p.AppendImage(single, clayout.WithAnnotations(map[string]string{ocispec.AnnotationBaseImageName: singleRef.Reference}))
+	require.NoError(t, err)
+
+	sbomImages, err := loadSBOMImages(dir, []transform.Image{multiRef, singleRef})
+	require.NoError(t, err)
+	identifiers := []string{}
+	for _, sbomImg := range sbomImages {
+		identifiers = append(identifiers, sbomImg.identifier)
+	}
+	expected := []string{
+		"ghcr.io/stefanprodan/podinfo:6.4.0-linux-amd64",
+		"ghcr.io/stefanprodan/podinfo:6.4.0-linux-arm64-v8",
+		"ghcr.io/example/helper:1.0.0",
+	}
+	require.Equal(t, expected, identifiers)
+	require.Equal(t, multiRef.Reference, sbomImages[1].reference)
+	singleDigest, err := single.Digest()
+	require.NoError(t, err)
+	loadedDigest, err := sbomImages[2].img.Digest()
+	require.NoError(t, err)
+	require.Equal(t, singleDigest, loadedDigest)
+}
@@ -6,6 +6,7 @@ package template
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/variables"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 )
 
 const (
@@ -27,8 +29,14 @@ const (
 
 // GetZarfVariableConfig gets a variable configuration specific to Zarf
 func GetZarfVariableConfig() *variables.VariableConfig {
+	return NewZarfVariableConfig(config.CommonOptions.Confirm)
+}
+
+// NewZarfVariableConfig gets a variable configuration specific to Zarf that uses the default values of variables
+// instead of prompting for them when confirm is set.
+func NewZarfVariableConfig(confirm bool) *variables.VariableConfig {
 	prompt := func(variable v1alpha1.InteractiveVariable) (value string, err error) {
-		if config.CommonOptions.Confirm {
+		if confirm {
 			return variable.Default, nil
 		}
 		return interactive.PromptVariable(variable)
@@ -73,6 +81,11 @@ func GetZarfTemplates(componentName string, state *types.ZarfState) (templateMap
 			builtinMap["AGENT_CRT"] = base64.StdEncoding.EncodeToString(agentTLS.Cert)
 			builtinMap["AGENT_KEY"] = base64.StdEncoding.EncodeToString(agentTLS.Key)
 			builtinMap["AGENT_CA"] = base64.StdEncoding.EncodeToString(agentTLS.CA)
+			crdRules, err := generateAgentCRDRules(state.AgentCRDMutations)
+			if err != nil {
+				return templateMap, err
+			}
+			builtinMap["AGENT_CRD_RULES"] = crdRules
 
 		case "zarf-seed-registry", "zarf-registry":
 			builtinMap["SEED_REGISTRY"] = fmt.Sprintf("%s:%s", helpers.IPV4Localhost, config.ZarfSeedPort)
@@ -125,6 +138,26 @@ func generateHtpasswd(regInfo *types.RegistryInfo) (string, error) {
 	return "", nil
 }
 
+// generateAgentCRDRules returns the agent webhook rules for the custom resource mutations as inline JSON.
+func generateAgentCRDRules(mutations []types.AgentCRDMutation) (string, error) {
+	rules := []admissionregistrationv1.RuleWithOperations{}
+	for _, mutation := range mutations {
+		rules = append(rules, admissionregistrationv1.RuleWithOperations{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{mutation.Group},
+				APIVersions: []string{mutation.Version},
+				Resources:   []string{mutation.Resource},
+			},
+		})
+	}
+	b, err := json.Marshal(rules)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate the agent custom resource rules: %w", err)
+	}
+	return string(b), nil
+}
+
 func debugPrintTemplateMap(templateMap map[string]*variables.TextTemplate) {
 	debugText := "templateMap = { "
 
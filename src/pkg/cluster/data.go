@@ -5,10 +5,19 @@
 package cluster
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -16,8 +25,11 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	utilexec "k8s.io/client-go/util/exec"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 
@@ -25,21 +37,15 @@ import (
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/message"
-	"github.com/zarf-dev/zarf/src/pkg/utils"
-	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
-// HandleDataInjection waits for the target pod(s) to come up and inject the data into them
-// todo:  this currently requires kubectl but we should have enough k8s work to make this native now.
+// HandleDataInjection waits for the target pod(s) to come up and inject the data into them.
 func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDataInjection, componentPath *layout.ComponentPaths, dataIdx int) error {
-	injectionCompletionMarker := filepath.Join(componentPath.DataInjections, config.GetDataInjectionMarker())
-	if err := os.WriteFile(injectionCompletionMarker, []byte("🦄"), helpers.ReadWriteUser); err != nil {
-		return fmt.Errorf("unable to create the data injection completion marker: %w", err)
-	}
-
-	tarCompressFlag := ""
-	if data.Compress {
-		tarCompressFlag = "-z"
+	source, err := prepareDataInjection(data, componentPath, dataIdx)
+	if err != nil {
+		return err
 	}
 
 	// Pod filter to ensure we only use the current deployment's pods
@@ -52,27 +58,12 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 		return strings.Contains(string(b), config.GetDataInjectionMarker())
 	}
 
-	// Get the OS shell to execute commands in
-	shell, shellArgs := exec.GetOSShell(v1alpha1.Shell{Windows: "cmd"})
-
-	if _, _, err := exec.Cmd(shell, append(shellArgs, "tar --version")...); err != nil {
-		return fmt.Errorf("unable to execute tar, ensure it is installed in the $PATH: %w", err)
-	}
-
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 			message.Debugf("Attempting to inject data into %s", data.Target)
-			source := filepath.Join(componentPath.DataInjections, filepath.Base(data.Target.Path))
-			if helpers.InvalidPath(source) {
-				// The path is likely invalid because of how we compose OCI components, add an index suffix to the filename
-				source = filepath.Join(componentPath.DataInjections, strconv.Itoa(dataIdx), filepath.Base(data.Target.Path))
-				if helpers.InvalidPath(source) {
-					return fmt.Errorf("could not find the data injection source path %s", source)
-				}
-			}
 
 			target := podLookup{
 				Namespace: data.Target.Namespace,
@@ -91,48 +82,21 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 
 			// Inject into all the pods
 			for _, pod := range pods {
-				// Try to use the embedded kubectl if we can
-				zarfCommand, err := utils.GetFinalExecutableCommand()
-				kubectlBinPath := "kubectl"
-				if err != nil {
-					message.Warnf("Unable to get the zarf executable path, falling back to host kubectl: %s", err)
-				} else {
-					kubectlBinPath = fmt.Sprintf("%s tools kubectl", zarfCommand)
+				injectTarget := injectionTarget{
+					Namespace: data.Target.Namespace,
+					Pod:       pod.Name,
+					Container: data.Target.Container,
+					Path:      data.Target.Path,
 				}
-				kubectlCmd := fmt.Sprintf("%s exec -i -n %s %s -c %s ", kubectlBinPath, data.Target.Namespace, pod.Name, data.Target.Container)
-
-				// Note that each command flag is separated to provide the widest cross-platform tar support
-				tarCmd := fmt.Sprintf("tar -c %s -f -", tarCompressFlag)
-				untarCmd := fmt.Sprintf("tar -x %s -v -f - -C %s", tarCompressFlag, data.Target.Path)
-
-				// Must create the target directory before trying to change to it for untar
-				mkdirCmd := fmt.Sprintf("%s -- mkdir -p %s", kubectlCmd, data.Target.Path)
-				if err := exec.CmdWithPrint(shell, append(shellArgs, mkdirCmd)...); err != nil {
-					return fmt.Errorf("unable to create the data injection target directory %s in pod %s: %w", data.Target.Path, pod.Name, err)
-				}
-
-				cpPodCmd := fmt.Sprintf("%s -C %s . | %s -- %s",
-					tarCmd,
-					source,
-					kubectlCmd,
-					untarCmd,
-				)
 
 				// Do the actual data injection
-				if err := exec.CmdWithPrint(shell, append(shellArgs, cpPodCmd)...); err != nil {
+				if _, err := c.injectData(ctx, injectTarget, source, nil, data.Compress); err != nil {
 					return fmt.Errorf("could not copy data into the pod %s: %w", pod.Name, err)
 				}
 
 				// Leave a marker in the target container for pods to track the sync action
-				cpPodCmd = fmt.Sprintf("%s -C %s %s | %s -- %s",
-					tarCmd,
-					componentPath.DataInjections,
-					config.GetDataInjectionMarker(),
-					kubectlCmd,
-					untarCmd,
-				)
-
-				if err := exec.CmdWithPrint(shell, append(shellArgs, cpPodCmd)...); err != nil {
+				marker := []string{config.GetDataInjectionMarker()}
+				if _, err := c.injectData(ctx, injectTarget, componentPath.DataInjections, marker, data.Compress); err != nil {
 					return fmt.Errorf("could not save the Zarf sync completion file after injection into pod %s: %w", pod.Name, err)
 				}
 			}
@@ -163,6 +127,24 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 	}
 }
 
+// prepareDataInjection writes the data injection completion marker and returns the path to the data to inject.
+func prepareDataInjection(data v1alpha1.ZarfDataInjection, componentPath *layout.ComponentPaths, dataIdx int) (string, error) {
+	injectionCompletionMarker := filepath.Join(componentPath.DataInjections, config.GetDataInjectionMarker())
+	if err := os.WriteFile(injectionCompletionMarker, []byte("🦄"), helpers.ReadWriteUser); err != nil {
+		return "", fmt.Errorf("unable to create the data injection completion marker: %w", err)
+	}
+
+	source := filepath.Join(componentPath.DataInjections, filepath.Base(data.Target.Path))
+	if helpers.InvalidPath(source) {
+		// The path is likely invalid because of how we compose OCI components, add an index suffix to the filename
+		source = filepath.Join(componentPath.DataInjections, strconv.Itoa(dataIdx), filepath.Base(data.Target.Path))
+		if helpers.InvalidPath(source) {
+			return "", fmt.Errorf("could not find the data injection source path %s", source)
+		}
+	}
+	return source, nil
+}
+
 // podLookup is a struct for specifying a pod to target for data injection or lookups.
 type podLookup struct {
 	Namespace string
@@ -253,3 +235,469 @@ func waitForPodsAndContainers(ctx context.Context, clientset kubernetes.Interfac
 		}
 	}
 }
+
+// injectionChecksumBatchSize is the number of injected files checksummed by a single sha256sum command.
+const injectionChecksumBatchSize = 256
+
+// injectionTarget is a directory in a pod container that data is injected into.
+type injectionTarget struct {
+	Namespace string
+	Pod       string
+	Container string
+	Path      string
+}
+
+// injectData streams the files under root, or only the named entries of root when names is set, into the target
+// directory through an in-process tar archive and verifies the checksums of the copied files inside the container.
+// It returns the sha256 checksums of the injected files keyed by their slash separated path.
+func (c *Cluster) injectData(ctx context.Context, target injectionTarget, root string, names []string, compress bool) (map[string]string, error) {
+	if err := c.execInPod(ctx, target.Namespace, target.Pod, target.Container, []string{"mkdir", "-p", target.Path}, nil, nil); err != nil {
+		return nil, fmt.Errorf("unable to create the data injection target directory %s: %w", target.Path, err)
+	}
+
+	var total int64
+	if names == nil {
+		size, err := helpers.GetDirSize(root)
+		if err != nil {
+			return nil, err
+		}
+		total = size
+	}
+	for _, name := range names {
+		size, err := helpers.GetDirSize(filepath.Join(root, name))
+		if err != nil {
+			return nil, err
+		}
+		total += size
+	}
+	progressBar := message.NewProgressBar(total, fmt.Sprintf("Injecting data into %s/%s", target.Pod, target.Container))
+	defer progressBar.Close()
+
+	// Note that each command flag is separated to provide the widest cross-platform tar support
+	untarCmd := []string{"tar", "-x"}
+	if compress {
+		untarCmd = append(untarCmd, "-z")
+	}
+	untarCmd = append(untarCmd, "-f", "-", "-C", target.Path)
+
+	type archiveResult struct {
+		checksums map[string]string
+		err       error
+	}
+	pr, pw := io.Pipe()
+	archiveDone := make(chan archiveResult, 1)
+	go func() {
+		checksums, err := writeInjectionArchive(pw, root, names, compress, progressBar)
+		pw.CloseWithError(err)
+		archiveDone <- archiveResult{checksums, err}
+	}()
+	err := c.execInPod(ctx, target.Namespace, target.Pod, target.Container, untarCmd, pr, nil)
+	// Unblock the archive writer if the stream ended early
+	pr.CloseWithError(io.ErrClosedPipe)
+	archive := <-archiveDone
+	if err != nil {
+		return nil, err
+	}
+	if archive.err != nil {
+		return nil, fmt.Errorf("unable to archive %s: %w", root, archive.err)
+	}
+
+	if len(archive.checksums) == 0 {
+		progressBar.Successf("Injected data into %s/%s", target.Pod, target.Container)
+		return archive.checksums, nil
+	}
+
+	// Probe for sha256sum on its own so that a missing tool is not confused with a failed check
+	probeCmd := []string{"sha256sum", "/dev/null"}
+	err = c.execInPod(ctx, target.Namespace, target.Pod, target.Container, probeCmd, nil, io.Discard)
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) {
+		progressBar.Successf("Injected data into %s/%s", target.Pod, target.Container)
+		message.Warnf("Unable to verify the data injected into %s/%s, sha256sum is not available in the container", target.Pod, target.Container)
+		return archive.checksums, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to checksum the injected data: %w", err)
+	}
+
+	names = make([]string, 0, len(archive.checksums))
+	for name := range archive.checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sums := &sha256sumProgress{progressBar: progressBar, total: len(names)}
+	// Checksum the files in batches to stay within the argument limits of the container
+	for i := 0; i < len(names); i += injectionChecksumBatchSize {
+		sumCmd := []string{"sha256sum", "--"}
+		for _, name := range names[i:min(i+injectionChecksumBatchSize, len(names))] {
+			sumCmd = append(sumCmd, path.Join(target.Path, name))
+		}
+		err = c.execInPod(ctx, target.Namespace, target.Pod, target.Container, sumCmd, nil, sums)
+		if err != nil {
+			return nil, fmt.Errorf("unable to checksum the injected data: %w", err)
+		}
+	}
+	if err := verifyInjectionChecksums(target.Path, archive.checksums, sums.output.String()); err != nil {
+		return nil, err
+	}
+
+	progressBar.Successf("Injected and verified %d files in %s/%s", len(archive.checksums), target.Pod, target.Container)
+	return archive.checksums, nil
+}
+
+// sha256sumProgress collects the output of sha256sum and reports each checksummed file on a progress bar.
+type sha256sumProgress struct {
+	progressBar *message.ProgressBar
+	output      bytes.Buffer
+	pending     []byte
+	verified    int
+	total       int
+}
+
+func (s *sha256sumProgress) Write(p []byte) (int, error) {
+	s.output.Write(p)
+	s.pending = append(s.pending, p...)
+	for {
+		i := bytes.IndexByte(s.pending, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := string(s.pending[:i])
+		s.pending = s.pending[i+1:]
+		if len(line) < 66 {
+			continue
+		}
+		s.verified++
+		s.progressBar.Updatef("Verifying %s (%d/%d)", strings.TrimLeft(line[64:], " *"), s.verified, s.total)
+	}
+}
+
+// writeInjectionArchive writes the files under root, or only the named entries of root when names is set, to w as a
+// tar archive. It returns the sha256 checksums of the regular files keyed by their slash separated archive path.
+func writeInjectionArchive(w io.Writer, root string, names []string, compress bool, progressBar *message.ProgressBar) (map[string]string, error) {
+	out := w
+	var gzw *gzip.Writer
+	if compress {
+		gzw = gzip.NewWriter(w)
+		out = gzw
+	}
+	tw := tar.NewWriter(out)
+
+	checksums := map[string]string{}
+	addEntry := func(entryPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, entryPath)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(entryPath); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		progressBar.Updatef("Injecting %s", name)
+		f, err := os.Open(entryPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		hash := sha256.New()
+		if _, err := io.Copy(io.MultiWriter(tw, hash, progressBar), f); err != nil {
+			return err
+		}
+		checksums[name] = hex.EncodeToString(hash.Sum(nil))
+		return nil
+	}
+
+	if names == nil {
+		if err := filepath.WalkDir(root, addEntry); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range names {
+		if err := filepath.WalkDir(filepath.Join(root, name), addEntry); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if gzw != nil {
+		if err := gzw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return checksums, nil
+}
+
+// verifyInjectionChecksums compares the local checksums of the injected files against the sha256sum output from the
+// target container, where the file paths are rooted at the target path.
+func verifyInjectionChecksums(targetPath string, checksums map[string]string, sha256sumOutput string) error {
+	prefix := strings.TrimSuffix(path.Clean(targetPath), "/") + "/"
+	remote := map[string]string{}
+	for _, line := range strings.Split(sha256sumOutput, "\n") {
+		// Lines are the 64 character hex digest, a separator and the file path
+		if len(line) < 66 {
+			continue
+		}
+		name := strings.TrimLeft(line[64:], " *")
+		remote[strings.TrimPrefix(name, prefix)] = line[:64]
+	}
+
+	mismatched := []string{}
+	for name, checksum := range checksums {
+		if remote[name] != checksum {
+			mismatched = append(mismatched, name)
+		}
+	}
+	if len(mismatched) > 0 {
+		sort.Strings(mismatched)
+		return fmt.Errorf("checksum verification failed for %s", strings.Join(mismatched, ", "))
+	}
+	return nil
+}
+
+// HandlePVCDataInjection injects data directly into a PersistentVolumeClaim through a short-lived helper pod and returns
+// a record of the injected files. The helper pod runs the image of the data injection from the Zarf registry in the
+// given state, or as is when the state has no registry.
+func (c *Cluster) HandlePVCDataInjection(ctx context.Context, data v1alpha1.ZarfDataInjection, componentPath *layout.ComponentPaths, dataIdx int, state *types.ZarfState) (types.DeployedDataInjection, error) {
+	source, err := prepareDataInjection(data, componentPath, dataIdx)
+	if err != nil {
+		return types.DeployedDataInjection{}, err
+	}
+
+	image := data.Target.Image
+	if state.RegistryInfo.Address != "" {
+		image, err = transform.ImageTransformHost(state.RegistryInfo.Address, image)
+		if err != nil {
+			return types.DeployedDataInjection{}, err
+		}
+		// The pull secret is otherwise only created when a chart or manifest is deployed to the namespace
+		if err := c.ensureRegistryPullSecret(ctx, data.Target.Namespace, state.RegistryInfo); err != nil {
+			return types.DeployedDataInjection{}, fmt.Errorf("unable to create the image pull secret for the data injection helper pod: %w", err)
+		}
+	}
+
+	pod := buildDataInjectionPod(data.Target.Namespace, data.Target.PersistentVolumeClaim, image)
+	pod, err = c.Clientset.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return types.DeployedDataInjection{}, fmt.Errorf("unable to create the data injection helper pod: %w", err)
+	}
+	defer func() {
+		// Use a context that outlives cancellation so the helper pod is always cleaned up
+		err := c.Clientset.CoreV1().Pods(pod.Namespace).Delete(context.WithoutCancel(ctx), pod.Name, metav1.DeleteOptions{})
+		if err != nil {
+			message.Debugf("unable to delete the data injection helper pod %s: %s", pod.Name, err.Error())
+		}
+	}()
+
+	message.Debugf("Waiting for the data injection helper pod %s to mount %s", pod.Name, data.Target.PersistentVolumeClaim)
+	if err := waitForPodRunning(ctx, c.Clientset, pod.Namespace, pod.Name); err != nil {
+		return types.DeployedDataInjection{}, err
+	}
+
+	target := injectionTarget{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Container: dataInjectionContainerName,
+		Path:      path.Join(dataInjectionMountPath, data.Target.Path),
+	}
+	checksums, err := c.injectData(ctx, target, source, nil, data.Compress)
+	if err != nil {
+		return types.DeployedDataInjection{}, fmt.Errorf("could not copy data into the persistent volume claim %s: %w", data.Target.PersistentVolumeClaim, err)
+	}
+	marker := []string{config.GetDataInjectionMarker()}
+	if _, err := c.injectData(ctx, target, componentPath.DataInjections, marker, data.Compress); err != nil {
+		return types.DeployedDataInjection{}, fmt.Errorf("could not save the Zarf sync completion file in the persistent volume claim %s: %w", data.Target.PersistentVolumeClaim, err)
+	}
+
+	// Cleanup now to reduce disk pressure
+	if err := os.RemoveAll(source); err != nil {
+		return types.DeployedDataInjection{}, err
+	}
+
+	return types.DeployedDataInjection{
+		Namespace:             data.Target.Namespace,
+		PersistentVolumeClaim: data.Target.PersistentVolumeClaim,
+		Path:                  data.Target.Path,
+		Files:                 len(checksums),
+		Digest:                injectionDigest(checksums),
+		InjectedAt:            time.Now().UTC(),
+	}, nil
+}
+
+// ensureRegistryPullSecret creates the Zarf image pull secret in the namespace unless it already exists.
+func (c *Cluster) ensureRegistryPullSecret(ctx context.Context, namespace string, registryInfo types.RegistryInfo) error {
+	_, err := c.Clientset.CoreV1().Secrets(namespace).Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return err
+	}
+	secret, err := c.GenerateRegistryPullCreds(ctx, namespace, config.ZarfImagePullSecretName, registryInfo)
+	if err != nil {
+		return err
+	}
+	_, err = c.Clientset.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+const (
+	dataInjectionContainerName = "data-injection"
+	dataInjectionMountPath     = "/zarf-data"
+	// dataInjectionUserID is the unprivileged user of the helper pod, the claim is made writable to it through fsGroup.
+	dataInjectionUserID int64 = 65532
+)
+
+func buildDataInjectionPod(namespace, claimName, image string) *corev1.Pod {
+	userID := dataInjectionUserID
+	fsGroupChangePolicy := corev1.FSGroupChangeOnRootMismatch
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "zarf-data-injection-",
+			Namespace:    namespace,
+			Labels: map[string]string{
+				"app":      "zarf-data-injection",
+				AgentLabel: "ignore",
+			},
+		},
+		Spec: corev1.PodSpec{
+			// Do not try to restart the pod as it is deleted once the injection completes.
+			RestartPolicy: corev1.RestartPolicyNever,
+			ImagePullSecrets: []corev1.LocalObjectReference{
+				{
+					Name: config.ZarfImagePullSecretName,
+				},
+			},
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:           &userID,
+				RunAsGroup:          &userID,
+				RunAsNonRoot:        helpers.BoolPtr(true),
+				FSGroup:             &userID,
+				FSGroupChangePolicy: &fsGroupChangePolicy,
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            dataInjectionContainerName,
+					Image:           image,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Command:         []string{"sleep", "86400"},
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: helpers.BoolPtr(false),
+						ReadOnlyRootFilesystem:   helpers.BoolPtr(true),
+						Capabilities: &corev1.Capabilities{
+							Drop: []corev1.Capability{"ALL"},
+						},
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("100m"),
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("256Mi"),
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "data",
+							MountPath: dataInjectionMountPath,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claimName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// waitForPodRunning waits up to five minutes for the pod to start running.
+func waitForPodRunning(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("timed out waiting for pod %s to start running: %w", name, waitCtx.Err())
+		case <-timer.C:
+			pod, err := clientset.CoreV1().Pods(namespace).Get(waitCtx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			switch pod.Status.Phase {
+			case corev1.PodRunning:
+				return nil
+			case corev1.PodFailed, corev1.PodSucceeded:
+				return fmt.Errorf("pod %s exited before the data injection with phase %s", name, pod.Status.Phase)
+			}
+			timer.Reset(2 * time.Second)
+		}
+	}
+}
+
+// injectionDigest returns a single sha256 digest over the checksums of the injected files.
+func injectionDigest(checksums map[string]string) string {
+	names := make([]string, 0, len(checksums))
+	for name := range checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s  %s\n", checksums[name], name)
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil))
+}
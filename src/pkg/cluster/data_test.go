@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestWriteInjectionArchive(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	err := os.MkdirAll(filepath.Join(root, "tiles", "z1"), 0o755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(root, "weights.bin"), []byte("weights"), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(root, "tiles", "z1", "0.png"), []byte("tile"), 0o644)
+	require.NoError(t, err)
+	err = os.Symlink("weights.bin", filepath.Join(root, "latest.bin"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		names             []string
+		compress          bool
+		expectedEntries   []string
+		expectedChecksums map[string]string
+	}{
+		{
+			name:            "whole directory",
+			expectedEntries: []string{"latest.bin", "tiles/", "tiles/z1/", "tiles/z1/0.png", "weights.bin"},
+			expectedChecksums: map[string]string{
+				"weights.bin":    sha256Hex("weights"),
+				"tiles/z1/0.png": sha256Hex("tile"),
+			},
+		},
+		{
+			name:            "compressed named entries",
+			names:           []string{"tiles"},
+			compress:        true,
+			expectedEntries: []string{"tiles/", "tiles/z1/", "tiles/z1/0.png"},
+			expectedChecksums: map[string]string{
+				"tiles/z1/0.png": sha256Hex("tile"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			progressBar := message.NewProgressBar(0, "test")
+			checksums, err := writeInjectionArchive(&buf, root, tt.names, tt.compress, progressBar)
+			require.NoError(t, err)
+			require.NoError(t, progressBar.Close())
+			require.Equal(t, tt.expectedChecksums, checksums)
+
+			var r io.Reader = &buf
+			if tt.compress {
+				r, err = gzip.NewReader(&buf)
+				require.NoError(t, err)
+			}
+			tr := tar.NewReader(r)
+			entries := []string{}
+			for {
+				hdr, err := tr.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				entries = append(entries, hdr.Name)
+				if hdr.Name == "latest.bin" {
+					require.Equal(t, byte(tar.TypeSymlink), hdr.Typeflag)
+					require.Equal(t, "weights.bin", hdr.Linkname)
+				}
+			}
+			require.Equal(t, tt.expectedEntries, entries)
+		})
+	}
+}
+
+func TestVerifyInjectionChecksums(t *testing.T) {
+	t.Parallel()
+
+	checksums := map[string]string{
+		"weights.bin":    sha256Hex("weights"),
+		"tiles/z1/0.png": sha256Hex("tile"),
+	}
+
+	tests := []struct {
+		name        string
+		targetPath  string
+		output      string
+		errContains string
+	}{
+		{
+			name:       "all files match",
+			targetPath: "/data/",
+			output: sha256Hex("weights") + "  /data/weights.bin\n" +
+				sha256Hex("tile") + "  /data/tiles/z1/0.png\n" +
+				sha256Hex("other") + "  /data/unrelated.txt\n",
+		},
+		{
+			name:        "corrupted file",
+			targetPath:  "/data",
+			output:      sha256Hex("weights") + "  /data/weights.bin\n" + sha256Hex("partial") + " */data/tiles/z1/0.png\n",
+			errContains: "checksum verification failed for tiles/z1/0.png",
+		},
+		{
+			name:        "missing files",
+			targetPath:  "/data",
+			output:      "",
+			errContains: "checksum verification failed for tiles/z1/0.png, weights.bin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := verifyInjectionChecksums(tt.targetPath, checksums, tt.output)
+			if tt.errContains != "" {
+				require.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSha256sumProgress(t *testing.T) {
+	t.Parallel()
+
+	progressBar := message.NewProgressBar(0, "test")
+	defer progressBar.Close()
+	sums := &sha256sumProgress{progressBar: progressBar, total: 2}
+
+	// Output split across writes is counted once each line is complete
+	output := sha256Hex("weights") + "  /data/weights.bin\n" + sha256Hex("tile") + "  /data/tiles/z1/0.png\n"
+	_, err := sums.Write([]byte(output[:70]))
+	require.NoError(t, err)
+	require.Equal(t, 0, sums.verified)
+	_, err = sums.Write([]byte(output[70:100]))
+	require.NoError(t, err)
+	require.Equal(t, 1, sums.verified)
+	_, err = sums.Write([]byte(output[100:]))
+	require.NoError(t, err)
+	require.Equal(t, 2, sums.verified)
+	require.Equal(t, output, sums.output.String())
+}
+
+func TestInjectionDigest(t *testing.T) {
+	t.Parallel()
+
+	checksums := map[string]string{
+		"weights.bin":    sha256Hex("weights"),
+		"tiles/z1/0.png": sha256Hex("tile"),
+	}
+	expected := "sha256:" + sha256Hex(sha256Hex("tile")+"  tiles/z1/0.png\n"+sha256Hex("weights")+"  weights.bin\n")
+	require.Equal(t, expected, injectionDigest(checksums))
+	require.Equal(t, "sha256:"+sha256Hex(""), injectionDigest(nil))
+}
+
+func TestBuildDataInjectionPod(t *testing.T) {
+	t.Parallel()
+
+	pod := buildDataInjectionPod("models", "model-weights", "127.0.0.1:31999/library/busybox:1.36-zarf-1234")
+	require.Equal(t, "models", pod.Namespace)
+	require.Equal(t, "ignore", pod.Labels[AgentLabel])
+	require.Len(t, pod.Spec.Containers, 1)
+	container := pod.Spec.Containers[0]
+	require.Equal(t, "127.0.0.1:31999/library/busybox:1.36-zarf-1234", container.Image)
+	require.Equal(t, dataInjectionMountPath, container.VolumeMounts[0].MountPath)
+	require.Equal(t, "model-weights", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+
+	// The helper pod runs unprivileged with bounded resources
+	require.True(t, *pod.Spec.SecurityContext.RunAsNonRoot)
+	require.Equal(t, dataInjectionUserID, *pod.Spec.SecurityContext.FSGroup)
+	require.False(t, *container.SecurityContext.AllowPrivilegeEscalation)
+	require.True(t, *container.SecurityContext.ReadOnlyRootFilesystem)
+	require.Equal(t, []corev1.Capability{"ALL"}, container.SecurityContext.Capabilities.Drop)
+	require.False(t, container.Resources.Limits.Cpu().IsZero())
+	require.False(t, container.Resources.Limits.Memory().IsZero())
+}
+
+func TestEnsureRegistryPullSecret(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewSimpleClientset()}
+	registryInfo := types.RegistryInfo{Address: "127.0.0.1:31999", PullUsername: "zarf-pull", PullPassword: "password"}
+
+	err := c.ensureRegistryPullSecret(ctx, "models", registryInfo)
+	require.NoError(t, err)
+	secret, err := c.Clientset.CoreV1().Secrets("models").Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+
+	// An existing secret is left untouched
+	secret.Data = map[string][]byte{".dockerconfigjson": []byte("{}")}
+	_, err = c.Clientset.CoreV1().Secrets("models").Update(ctx, secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	err = c.ensureRegistryPullSecret(ctx, "models", registryInfo)
+	require.NoError(t, err)
+	secret, err = c.Clientset.CoreV1().Secrets("models").Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []byte("{}"), secret.Data[".dockerconfigjson"])
+}
@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// PackageHistoryLimit is the number of package revisions kept in the history of a deployed package.
+const PackageHistoryLimit = 10
+
+// packageRevisionSecretName returns the name of the secret holding a revision of a package.
+// Package names cannot contain a '.' so this never collides with the secret of another package.
+func packageRevisionSecretName(packageName string, generation int) string {
+	return fmt.Sprintf("%s%s.history.%d", config.ZarfPackagePrefix, packageName, generation)
+}
+
+// packageHistorySelector selects the revision secrets of a package.
+func packageHistorySelector(packageName string) string {
+	return labels.SelectorFromSet(labels.Set{ZarfPackageHistoryLabel: packageName}).String()
+}
+
+// GetPackageHistory returns the recorded revisions of a deployed package ordered from oldest to newest.
+func (c *Cluster) GetPackageHistory(ctx context.Context, packageName string) ([]types.DeployedPackageRevision, error) {
+	secrets, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).List(ctx, metav1.ListOptions{LabelSelector: packageHistorySelector(packageName)})
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets.Items) == 0 {
+		return nil, kerrors.NewNotFound(corev1.Resource("secrets"), packageRevisionSecretName(packageName, 1))
+	}
+	revisions := []types.DeployedPackageRevision{}
+	for _, secret := range secrets.Items {
+		revision, err := decodePackageRevision(secret.Data["data"])
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the history of package %s from secret '%s': %w", packageName, secret.Name, err)
+		}
+		revisions = append(revisions, revision)
+	}
+	slices.SortFunc(revisions, func(a, b types.DeployedPackageRevision) int {
+		return a.Generation - b.Generation
+	})
+	return revisions, nil
+}
+
+// RecordPackageRevision stores the given deployed package as a revision in the package history, replacing any revision
+// with the same generation while keeping the time it was first recorded, and drops the oldest revisions beyond the
+// PackageHistoryLimit. Each revision is stored compressed in its own secret to stay within the size limit of a secret.
+func (c *Cluster) RecordPackageRevision(ctx context.Context, deployedPackage types.DeployedPackage, variables map[string]string) error {
+	secretName := packageRevisionSecretName(deployedPackage.Name, deployedPackage.Generation)
+	timestamp := time.Now().UTC()
+	exists := false
+	existing, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, secretName, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+	case err != nil:
+		return err
+	default:
+		exists = true
+		if previous, err := decodePackageRevision(existing.Data["data"]); err == nil {
+			timestamp = previous.Timestamp
+		}
+	}
+
+	revision := types.DeployedPackageRevision{
+		Generation:         deployedPackage.Generation,
+		CLIVersion:         deployedPackage.CLIVersion,
+		Timestamp:          timestamp,
+		Data:               deployedPackage.Data,
+		DeployedComponents: deployedPackage.DeployedComponents,
+		ConnectStrings:     deployedPackage.ConnectStrings,
+		Variables:          variables,
+	}
+	if err := c.savePackageRevision(ctx, deployedPackage.Name, revision, exists); err != nil {
+		return err
+	}
+	return c.prunePackageHistory(ctx, deployedPackage.Name)
+}
+
+// RestorePackageRevision stores a revision exported from the history of a package as is.
+func (c *Cluster) RestorePackageRevision(ctx context.Context, packageName string, revision types.DeployedPackageRevision) error {
+	_, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, packageRevisionSecretName(packageName, revision.Generation), metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	return c.savePackageRevision(ctx, packageName, revision, err == nil)
+}
+
+// savePackageRevision writes a revision to its secret, updating the secret when it already exists.
+func (c *Cluster) savePackageRevision(ctx context.Context, packageName string, revision types.DeployedPackageRevision, exists bool) error {
+	revisionData, err := encodePackageRevision(revision)
+	if err != nil {
+		return err
+	}
+	revisionSecret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      packageRevisionSecretName(packageName, revision.Generation),
+			Namespace: ZarfNamespaceName,
+			Labels: map[string]string{
+				ZarfManagedByLabel:      "zarf",
+				ZarfPackageHistoryLabel: packageName,
+			},
+			Annotations: map[string]string{
+				ZarfPackageGenerationAnnotation: strconv.Itoa(revision.Generation),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"data": revisionData,
+		},
+	}
+	if exists {
+		_, err = c.Clientset.CoreV1().Secrets(revisionSecret.Namespace).Update(ctx, revisionSecret, metav1.UpdateOptions{})
+	} else {
+		_, err = c.Clientset.CoreV1().Secrets(revisionSecret.Namespace).Create(ctx, revisionSecret, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record package history in secret '%s': %w", revisionSecret.Name, err)
+	}
+	return nil
+}
+
+// prunePackageHistory removes the oldest revisions of a package beyond the PackageHistoryLimit.
+func (c *Cluster) prunePackageHistory(ctx context.Context, packageName string) error {
+	secrets, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).List(ctx, metav1.ListOptions{LabelSelector: packageHistorySelector(packageName)})
+	if err != nil {
+		return err
+	}
+	generations := []int{}
+	for _, secret := range secrets.Items {
+		generation, err := strconv.Atoi(secret.Annotations[ZarfPackageGenerationAnnotation])
+		if err != nil {
+			return fmt.Errorf("unable to read the generation of the package revision in secret '%s': %w", secret.Name, err)
+		}
+		generations = append(generations, generation)
+	}
+	slices.Sort(generations)
+	for len(generations) > PackageHistoryLimit {
+		if err := c.DeletePackageRevision(ctx, packageName, generations[0]); err != nil {
+			return err
+		}
+		generations = generations[1:]
+	}
+	return nil
+}
+
+// DeletePackageRevision removes a single revision from the history of a package.
+func (c *Cluster) DeletePackageRevision(ctx context.Context, packageName string, generation int) error {
+	err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Delete(ctx, packageRevisionSecretName(packageName, generation), metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// DeletePackageHistory removes the recorded history of a package.
+func (c *Cluster) DeletePackageHistory(ctx context.Context, packageName string) error {
+	secrets, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).List(ctx, metav1.ListOptions{LabelSelector: packageHistorySelector(packageName)})
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets.Items {
+		err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodePackageRevision returns the gzip compressed JSON of a package revision.
+func encodePackageRevision(revision types.DeployedPackageRevision) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(revision); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodePackageRevision reads a package revision written by encodePackageRevision.
+func decodePackageRevision(data []byte) (types.DeployedPackageRevision, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return types.DeployedPackageRevision{}, err
+	}
+	defer zr.Close()
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		return types.DeployedPackageRevision{}, err
+	}
+	revision := types.DeployedPackageRevision{}
+	if err := json.Unmarshal(b, &revision); err != nil {
+		return types.DeployedPackageRevision{}, err
+	}
+	return revision, nil
+}
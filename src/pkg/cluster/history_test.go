@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestPackageHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewSimpleClientset()}
+
+	_, err := c.GetPackageHistory(ctx, "test")
+	require.True(t, kerrors.IsNotFound(err))
+
+	for generation := 1; generation <= PackageHistoryLimit+2; generation++ {
+		deployedPackage := types.DeployedPackage{Name: "test", Generation: generation}
+		err := c.RecordPackageRevision(ctx, deployedPackage, map[string]string{"DOMAIN": "zarf.dev"})
+		require.NoError(t, err)
+	}
+	history, err := c.GetPackageHistory(ctx, "test")
+	require.NoError(t, err)
+	recordedAt := history[len(history)-1].Timestamp
+
+	// Recording the same generation again replaces the existing revision but keeps the time it was first recorded
+	deployedPackage := types.DeployedPackage{
+		Name:               "test",
+		Generation:         PackageHistoryLimit + 2,
+		DeployedComponents: []types.DeployedComponent{{Name: "web", Status: types.ComponentStatusSucceeded}},
+	}
+	err = c.RecordPackageRevision(ctx, deployedPackage, nil)
+	require.NoError(t, err)
+
+	history, err = c.GetPackageHistory(ctx, "test")
+	require.NoError(t, err)
+	require.Len(t, history, PackageHistoryLimit)
+	require.Equal(t, 3, history[0].Generation)
+	require.Equal(t, map[string]string{"DOMAIN": "zarf.dev"}, history[0].Variables)
+	require.Equal(t, PackageHistoryLimit+2, history[len(history)-1].Generation)
+	require.Equal(t, deployedPackage.DeployedComponents, history[len(history)-1].DeployedComponents)
+	require.True(t, recordedAt.Equal(history[len(history)-1].Timestamp))
+
+	// Each revision is stored in its own secret
+	secrets, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, secrets.Items, PackageHistoryLimit)
+
+	err = c.DeletePackageRevision(ctx, "test", PackageHistoryLimit+2)
+	require.NoError(t, err)
+	history, err = c.GetPackageHistory(ctx, "test")
+	require.NoError(t, err)
+	require.Len(t, history, PackageHistoryLimit-1)
+	require.Equal(t, PackageHistoryLimit+1, history[len(history)-1].Generation)
+
+	// The history secret is not reported as a deployed package
+	deployedPackages, err := c.GetDeployedZarfPackages(ctx)
+	require.NoError(t, err)
+	require.Empty(t, deployedPackages)
+
+	err = c.DeletePackageHistory(ctx, "test")
+	require.NoError(t, err)
+	_, err = c.GetPackageHistory(ctx, "test")
+	require.True(t, kerrors.IsNotFound(err))
+	err = c.DeletePackageHistory(ctx, "test")
+	require.NoError(t, err)
+}
+
+func TestPackageRevisionEncoding(t *testing.T) {
+	t.Parallel()
+
+	// Package definitions compress well, which keeps large packages within the size limit of a secret
+	components := []v1alpha1.ZarfComponent{}
+	for i := range 2000 {
+		components = append(components, v1alpha1.ZarfComponent{
+			Name:   fmt.Sprintf("component-%d", i),
+			Images: []string{"ghcr.io/stefanprodan/podinfo:6.4.0", "ghcr.io/stefanprodan/podinfo:6.4.1"},
+		})
+	}
+	revision := types.DeployedPackageRevision{
+		Generation: 3,
+		Data:       v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test"}, Components: components},
+		Variables:  map[string]string{"DOMAIN": "zarf.dev"},
+	}
+	raw, err := json.Marshal(revision)
+	require.NoError(t, err)
+	require.Greater(t, len(raw), 256*1024)
+
+	data, err := encodePackageRevision(revision)
+	require.NoError(t, err)
+	require.Less(t, len(data), len(raw)/10)
+	decoded, err := decodePackageRevision(data)
+	require.NoError(t, err)
+	require.Equal(t, revision, decoded)
+}
@@ -7,6 +7,8 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -32,8 +34,28 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 )
 
+// Injection modes for delivering the seed image to the injector pod.
+const (
+	// InjectionModeConfigMap splits the seed image across ConfigMaps mounted into the injector pod.
+	InjectionModeConfigMap = "configmap"
+	// InjectionModePortForward uploads the seed image to the injector pod through a port-forward.
+	InjectionModePortForward = "port-forward"
+)
+
+// injectorUploadVersion is the first injector version that accepts payload uploads.
+const injectorUploadVersion = "0.6.0"
+
 // StartInjection initializes a Zarf injection into the cluster.
-func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string, injectorSeedSrcs []string) error {
+func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string, injectorSeedSrcs []string, mode string) error {
+	tarPath, shasum, err := createPayload(tmpDir, imagesDir, injectorSeedSrcs)
+	if err != nil {
+		return fmt.Errorf("unable to generate the injector payload: %w", err)
+	}
+	return c.startInjection(ctx, tmpDir, tarPath, shasum, mode)
+}
+
+// startInjection starts the injector pod with the given payload tarball.
+func (c *Cluster) startInjection(ctx context.Context, tmpDir, tarPath, shasum, mode string) error {
 	// Stop any previous running injection before starting.
 	err := c.StopInjection(ctx)
 	if err != nil {
@@ -58,9 +80,13 @@ func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string,
 		return err
 	}
 
-	payloadCmNames, shasum, err := c.createPayloadConfigMaps(ctx, spinner, tmpDir, imagesDir, injectorSeedSrcs)
-	if err != nil {
-		return fmt.Errorf("unable to generate the injector payload configmaps: %w", err)
+	// The port-forward mode leaves the payload off the pod and uploads it once the injector is ready
+	var payloadCmNames []string
+	if mode != InjectionModePortForward {
+		payloadCmNames, err = c.createPayloadConfigMaps(ctx, spinner, tarPath)
+		if err != nil {
+			return fmt.Errorf("unable to generate the injector payload configmaps: %w", err)
+		}
 	}
 
 	b, err := os.ReadFile(filepath.Join(tmpDir, "zarf-injector"))
@@ -115,17 +141,125 @@ func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string,
 		return fmt.Errorf("error creating pod in cluster: %w", err)
 	}
 
+	// Any failure of the port-forward mode starts over with the configmap mode
+	fallBack := func(err error) error {
+		message.Warnf("Unable to upload the seed image through a port-forward, falling back to configmaps: %s", err.Error())
+		spinner.Stop()
+		return c.startInjection(ctx, tmpDir, tarPath, shasum, InjectionModeConfigMap)
+	}
+
 	waitCtx, waitCancel := context.WithTimeout(ctx, 60*time.Second)
 	defer waitCancel()
+	// Injectors without the upload endpoint exit as soon as they start without a payload, which stops the wait early
+	var exitErr error
+	exitDone := make(chan struct{})
+	if mode == InjectionModePortForward {
+		go func() {
+			defer close(exitDone)
+			if err := c.waitForInjectorExit(waitCtx); err != nil {
+				exitErr = err
+				waitCancel()
+			}
+		}()
+	} else {
+		close(exitDone)
+	}
 	err = pkgkubernetes.WaitForReadyRuntime(waitCtx, c.Watcher, []runtime.Object{pod})
+	waitCancel()
+	<-exitDone
+	if exitErr != nil {
+		return fallBack(exitErr)
+	}
 	if err != nil {
+		if mode == InjectionModePortForward {
+			return fallBack(fmt.Errorf("the injector pod did not become ready: %w", err))
+		}
 		return err
 	}
 
+	if mode == InjectionModePortForward {
+		spinner.Updatef("Uploading the seed image to the injector through a port-forward")
+		if err := c.uploadPayload(ctx, tarPath); err != nil {
+			return fallBack(err)
+		}
+	}
+
 	spinner.Success()
 	return nil
 }
 
+// waitForInjectorExit returns an error once the container of the injector pod has exited, which happens when the
+// injector does not support payload uploads, or nil when the context is done first.
+func (c *Cluster) waitForInjectorExit(ctx context.Context) error {
+	var exitErr error
+	err := wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
+		pod, err := c.Clientset.CoreV1().Pods(ZarfNamespaceName).Get(ctx, "injector", metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.RestartCount > 0 || status.State.Terminated != nil {
+				exitErr = fmt.Errorf("the injector exited before the payload was uploaded, the port-forward mode requires an injector with payload upload support (%s or newer)", injectorUploadVersion)
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil
+	}
+	return exitErr
+}
+
+// uploadPayload sends the payload tarball to the injector pod, which checks and extracts it into its seed directory.
+func (c *Cluster) uploadPayload(ctx context.Context, tarPath string) error {
+	tunnel, err := c.NewTunnel(ZarfNamespaceName, PodResource, "injector", "", 0, ZarfInjectorPort)
+	if err != nil {
+		return err
+	}
+	_, err = tunnel.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer tunnel.Close()
+
+	return tunnel.Wrap(func() error {
+		return putPayload(ctx, tunnel.HTTPEndpoint(), tarPath)
+	})
+}
+
+// putPayload streams the payload tarball to the upload endpoint of the injector at the given address.
+func putPayload(ctx context.Context, endpoint, tarPath string) error {
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint+"/zarf-payload", f)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	req.ContentLength = fi.Size()
+	req.Header.Set("Content-Type", "application/gzip")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("the injector rejected the payload with status %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
 // StopInjection handles cleanup once the seed registry is up.
 func (c *Cluster) StopInjection(ctx context.Context) error {
 	err := c.Clientset.CoreV1().Pods(ZarfNamespaceName).Delete(ctx, "injector", metav1.DeleteOptions{})
@@ -186,48 +320,57 @@ func (c *Cluster) StopInjection(ctx context.Context) error {
 	return nil
 }
 
-func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.Spinner, tmpDir, imagesDir string, injectorSeedSrcs []string) ([]string, string, error) {
+// createPayload archives the seed images into an OCI layout tarball and returns its path and sha256 checksum.
+func createPayload(tmpDir, imagesDir string, injectorSeedSrcs []string) (string, string, error) {
 	tarPath := filepath.Join(tmpDir, "payload.tar.gz")
 	seedImagesDir := filepath.Join(tmpDir, "seed-images")
 	if err := helpers.CreateDirectory(seedImagesDir, helpers.ReadWriteExecuteUser); err != nil {
-		return nil, "", fmt.Errorf("unable to create the seed images directory: %w", err)
+		return "", "", fmt.Errorf("unable to create the seed images directory: %w", err)
 	}
 
 	localReferenceToDigest := map[string]string{}
 	for _, src := range injectorSeedSrcs {
 		ref, err := transform.ParseImageRef(src)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to create ref for image %s: %w", src, err)
+			return "", "", fmt.Errorf("failed to create ref for image %s: %w", src, err)
 		}
 		img, err := utils.LoadOCIImage(imagesDir, ref)
 		if err != nil {
-			return nil, "", err
+			return "", "", err
 		}
 		if err := crane.SaveOCI(img, seedImagesDir); err != nil {
-			return nil, "", err
+			return "", "", err
 		}
 		imgDigest, err := img.Digest()
 		if err != nil {
-			return nil, "", err
+			return "", "", err
 		}
 		localReferenceToDigest[ref.Path+ref.TagOrDigest] = imgDigest.String()
 	}
 	if err := utils.AddImageNameAnnotation(seedImagesDir, localReferenceToDigest); err != nil {
-		return nil, "", fmt.Errorf("unable to format OCI layout: %w", err)
+		return "", "", fmt.Errorf("unable to format OCI layout: %w", err)
 	}
 
-	// Chunk size has to accommodate base64 encoding & etcd 1MB limit
 	tarFileList, err := filepath.Glob(filepath.Join(seedImagesDir, "*"))
 	if err != nil {
-		return nil, "", err
+		return "", "", err
 	}
 	if err := archiver.Archive(tarFileList, tarPath); err != nil {
-		return nil, "", err
+		return "", "", err
+	}
+	shasum, err := helpers.GetSHA256OfFile(tarPath)
+	if err != nil {
+		return "", "", err
 	}
+	return tarPath, shasum, nil
+}
+
+func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.Spinner, tarPath string) ([]string, error) {
+	// Chunk size has to accommodate base64 encoding & etcd 1MB limit
 	payloadChunkSize := 1024 * 768
-	chunks, shasum, err := helpers.ReadFileByChunks(tarPath, payloadChunkSize)
+	chunks, _, err := helpers.ReadFileByChunks(tarPath, payloadChunkSize)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
 	cmNames := []string{}
@@ -250,14 +393,14 @@ func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.
 		}
 		_, err = c.Clientset.CoreV1().ConfigMaps(ZarfNamespaceName).Create(ctx, cm, metav1.CreateOptions{})
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
 		cmNames = append(cmNames, fileName)
 
 		// Give the control plane a 250ms buffer between each configmap
 		time.Sleep(250 * time.Millisecond)
 	}
-	return cmNames, shasum, nil
+	return cmNames, nil
 }
 
 // getImagesAndNodesForInjection checks for images on schedulable nodes within a cluster.
@@ -5,12 +5,19 @@ package cluster
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/random"
@@ -21,18 +28,19 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+	"sigs.k8s.io/cli-utils/pkg/object"
 
 	pkgkubernetes "github.com/defenseunicorns/pkg/kubernetes"
 )
 
-func TestInjector(t *testing.T) {
-	ctx := context.Background()
+// newInjectorTestClientset returns a fake clientset with a schedulable node running a pod that the injector can use.
+func newInjectorTestClientset(ctx context.Context, t *testing.T) *fake.Clientset {
+	t.Helper()
+
 	cs := fake.NewSimpleClientset()
-	c := &Cluster{
-		Clientset: cs,
-		Watcher:   pkgkubernetes.NewImmediateWatcher(status.CurrentStatus),
-	}
 	cs.PrependReactor("delete-collection", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
 		delAction, ok := action.(k8stesting.DeleteCollectionActionImpl)
 		if !ok {
@@ -89,22 +97,39 @@ func TestInjector(t *testing.T) {
 	}
 	_, err = cs.CoreV1().Pods(pod.ObjectMeta.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 	require.NoError(t, err)
+	return cs
+}
 
-	err = c.StopInjection(ctx)
+// writeInjectorTestPayload writes an injector binary and a seed image to a new temporary directory.
+func writeInjectorTestPayload(t *testing.T) (string, []byte) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	binData := []byte("foobar")
+	err := os.WriteFile(filepath.Join(tmpDir, "zarf-injector"), binData, 0o644)
+	require.NoError(t, err)
+	idx, err := random.Index(1, 1, 1)
 	require.NoError(t, err)
+	_, err = layout.Write(filepath.Join(tmpDir, "seed-images"), idx)
+	require.NoError(t, err)
+	return tmpDir, binData
+}
 
-	for range 2 {
-		tmpDir := t.TempDir()
-		binData := []byte("foobar")
-		err := os.WriteFile(filepath.Join(tmpDir, "zarf-injector"), binData, 0o644)
-		require.NoError(t, err)
+func TestInjector(t *testing.T) {
+	ctx := context.Background()
+	cs := newInjectorTestClientset(ctx, t)
+	c := &Cluster{
+		Clientset: cs,
+		Watcher:   pkgkubernetes.NewImmediateWatcher(status.CurrentStatus),
+	}
 
-		idx, err := random.Index(1, 1, 1)
-		require.NoError(t, err)
-		_, err = layout.Write(filepath.Join(tmpDir, "seed-images"), idx)
-		require.NoError(t, err)
+	err := c.StopInjection(ctx)
+	require.NoError(t, err)
+
+	for range 2 {
+		tmpDir, binData := writeInjectorTestPayload(t)
 
-		err = c.StartInjection(ctx, tmpDir, t.TempDir(), nil)
+		err := c.StartInjection(ctx, tmpDir, t.TempDir(), nil, InjectionModeConfigMap)
 		require.NoError(t, err)
 
 		podList, err := cs.CoreV1().Pods(ZarfNamespaceName).List(ctx, metav1.ListOptions{})
@@ -145,6 +170,183 @@ func TestInjector(t *testing.T) {
 	require.Empty(t, cmList.Items)
 }
 
+// failFirstWatcher reports an error for the first watch and the current status for every later watch.
+type failFirstWatcher struct {
+	watched int
+}
+
+func (w *failFirstWatcher) Watch(_ context.Context, objs object.ObjMetadataSet, _ watcher.Options) <-chan event.Event {
+	w.watched++
+	eventCh := make(chan event.Event, len(objs))
+	for _, obj := range objs {
+		if w.watched == 1 {
+			eventCh <- event.Event{Type: event.ErrorEvent, Error: errors.New("image pull back off")}
+			continue
+		}
+		eventCh <- event.Event{
+			Type:     event.ResourceUpdateEvent,
+			Resource: &event.ResourceStatus{Identifier: obj, Status: status.CurrentStatus},
+		}
+	}
+	close(eventCh)
+	return eventCh
+}
+
+func TestInjectorPortForwardFallback(t *testing.T) {
+	ctx := context.Background()
+	cs := newInjectorTestClientset(ctx, t)
+	w := &failFirstWatcher{}
+	c := &Cluster{
+		Clientset: cs,
+		Watcher:   w,
+	}
+
+	// The injector pod of the port-forward mode does not become ready so the configmap mode is used instead
+	tmpDir, _ := writeInjectorTestPayload(t)
+	err := c.StartInjection(ctx, tmpDir, t.TempDir(), nil, InjectionModePortForward)
+	require.NoError(t, err)
+	require.Equal(t, 2, w.watched)
+
+	pod, err := cs.CoreV1().Pods(ZarfNamespaceName).Get(ctx, "injector", metav1.GetOptions{})
+	require.NoError(t, err)
+	payloadVolumes := 0
+	for _, volume := range pod.Spec.Volumes {
+		if strings.HasPrefix(volume.Name, "zarf-payload-") {
+			payloadVolumes++
+		}
+	}
+	require.Equal(t, 1, payloadVolumes)
+	cmList, err := cs.CoreV1().ConfigMaps(ZarfNamespaceName).List(ctx, metav1.ListOptions{LabelSelector: "zarf-injector=payload"})
+	require.NoError(t, err)
+	require.Len(t, cmList.Items, 1)
+}
+
+// blockFirstWatcher reports nothing for the first watch until it is cancelled and the current status for every later watch.
+type blockFirstWatcher struct {
+	watched int
+}
+
+func (w *blockFirstWatcher) Watch(ctx context.Context, objs object.ObjMetadataSet, _ watcher.Options) <-chan event.Event {
+	w.watched++
+	eventCh := make(chan event.Event, len(objs))
+	if w.watched == 1 {
+		go func() {
+			<-ctx.Done()
+			close(eventCh)
+		}()
+		return eventCh
+	}
+	for _, obj := range objs {
+		eventCh <- event.Event{
+			Type:     event.ResourceUpdateEvent,
+			Resource: &event.ResourceStatus{Identifier: obj, Status: status.CurrentStatus},
+		}
+	}
+	close(eventCh)
+	return eventCh
+}
+
+func TestInjectorPortForwardExit(t *testing.T) {
+	ctx := context.Background()
+	cs := newInjectorTestClientset(ctx, t)
+	// Injectors without upload support crash as soon as they start without a payload
+	cs.PrependReactor("get", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		getAction := action.(k8stesting.GetActionImpl)
+		if getAction.Name != "injector" {
+			return false, nil, nil
+		}
+		obj, err := cs.Tracker().Get(getAction.Resource, getAction.Namespace, getAction.Name)
+		if err != nil {
+			return true, nil, err
+		}
+		pod := obj.(*corev1.Pod).DeepCopy()
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "injector", RestartCount: 1}}
+		return true, pod, nil
+	})
+	w := &blockFirstWatcher{}
+	c := &Cluster{
+		Clientset: cs,
+		Watcher:   w,
+	}
+
+	// The exit of the injector falls back to the configmap mode without waiting for the readiness timeout
+	tmpDir, _ := writeInjectorTestPayload(t)
+	start := time.Now()
+	err := c.StartInjection(ctx, tmpDir, t.TempDir(), nil, InjectionModePortForward)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 30*time.Second)
+	require.Equal(t, 2, w.watched)
+
+	cmList, err := cs.CoreV1().ConfigMaps(ZarfNamespaceName).List(ctx, metav1.ListOptions{LabelSelector: "zarf-injector=payload"})
+	require.NoError(t, err)
+	require.Len(t, cmList.Items, 1)
+}
+
+func TestPutPayload(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte("seed image payload")
+	tarPath := filepath.Join(t.TempDir(), "payload.tar.gz")
+	err := os.WriteFile(tarPath, payload, 0o644)
+	require.NoError(t, err)
+
+	// The server checks the upload like the injector does
+	newInjector := func(expectedSha string) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut || r.URL.Path != "/zarf-payload" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.ContentLength != int64(len(payload)) {
+				w.WriteHeader(http.StatusLengthRequired)
+				return
+			}
+			hash := sha256.New()
+			if _, err := io.Copy(hash, r.Body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if sum := hex.EncodeToString(hash.Sum(nil)); sum != expectedSha {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "payload checksum %s does not match %s\n", sum, expectedSha)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+
+	tests := []struct {
+		name        string
+		expectedSha string
+		expectedErr string
+	}{
+		{
+			name:        "accepted upload",
+			expectedSha: sha256Hex(string(payload)),
+		},
+		{
+			name:        "rejected upload",
+			expectedSha: sha256Hex("other payload"),
+			expectedErr: "the injector rejected the payload with status 400 Bad Request: payload checksum " + sha256Hex(string(payload)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newInjector(tt.expectedSha)
+			err := putPayload(context.Background(), srv.URL, tarPath)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestBuildInjectionPod(t *testing.T) {
 	t.Parallel()
 
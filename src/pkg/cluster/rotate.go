@@ -0,0 +1,370 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+const (
+	// DefaultCredentialLifetime is how long generated registry and git server passwords stay valid.
+	DefaultCredentialLifetime = 90 * 24 * time.Hour
+	// CredentialRotationName is the name of the resources that rotate credentials on a schedule.
+	CredentialRotationName = "zarf-credential-rotation"
+
+	agentDeploymentName = "agent-hook"
+)
+
+// CredentialsDueForRotation returns the services whose Zarf generated credentials expire before the deadline.
+// Credentials with an unknown expiration are always due, credentials of external services are never due.
+func CredentialsDueForRotation(state *types.ZarfState, services []string, deadline time.Time) ([]string, error) {
+	due := []string{}
+	for _, service := range services {
+		switch service {
+		case message.RegistryKey:
+			if !state.RegistryInfo.IsInternal() {
+				continue
+			}
+			if expiresAt := state.CredentialExpirations.Registry; expiresAt == nil || expiresAt.Before(deadline) {
+				due = append(due, service)
+			}
+		case message.GitKey:
+			if !state.GitServer.IsInternal() {
+				continue
+			}
+			if expiresAt := state.CredentialExpirations.Git; expiresAt == nil || expiresAt.Before(deadline) {
+				due = append(due, service)
+			}
+		case message.AgentKey:
+			expiresAt, err := state.AgentTLS.ExpiresAt()
+			if err != nil {
+				return nil, fmt.Errorf("unable to read the agent certificate expiration: %w", err)
+			}
+			if expiresAt.Before(deadline) {
+				due = append(due, service)
+			}
+		default:
+			return nil, fmt.Errorf("credentials for %s cannot be rotated", service)
+		}
+	}
+	return due, nil
+}
+
+// SetCredentialLifetime sets the expiration of the rotated registry and git server credentials, credentials of services
+// that were not rotated and of external services keep their expiration.
+func SetCredentialLifetime(state *types.ZarfState, rotated []string, expiresAt time.Time) {
+	if slices.Contains(rotated, message.RegistryKey) && state.CredentialExpirations.Registry != nil {
+		state.CredentialExpirations.Registry = &expiresAt
+	}
+	if slices.Contains(rotated, message.GitKey) && state.CredentialExpirations.Git != nil {
+		state.CredentialExpirations.Git = &expiresAt
+	}
+}
+
+// ScheduleCredentialRotation creates or updates a CronJob that runs the Zarf agent image with the given arguments on
+// the schedule, along with the service account it runs as and the roles that limit it to the resources rotation touches.
+func (c *Cluster) ScheduleCredentialRotation(ctx context.Context, schedule string, args []string) error {
+	agent, err := c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Get(ctx, agentDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to find the Zarf agent image: %w", err)
+	}
+	if len(agent.Spec.Template.Spec.Containers) == 0 {
+		return fmt.Errorf("unable to find the Zarf agent image")
+	}
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CredentialRotationName,
+			Namespace: ZarfNamespaceName,
+			Labels:    map[string]string{ZarfManagedByLabel: "zarf"},
+		},
+	}
+	_, err = c.Clientset.CoreV1().ServiceAccounts(sa.Namespace).Create(ctx, sa, metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	role, roleBinding, clusterRole, clusterRoleBinding := buildCredentialRotationRBAC()
+	_, err = c.Clientset.RbacV1().Roles(role.Namespace).Create(ctx, role, metav1.CreateOptions{})
+	if kerrors.IsAlreadyExists(err) {
+		_, err = c.Clientset.RbacV1().Roles(role.Namespace).Update(ctx, role, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	_, err = c.Clientset.RbacV1().RoleBindings(roleBinding.Namespace).Create(ctx, roleBinding, metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+	_, err = c.Clientset.RbacV1().ClusterRoles().Create(ctx, clusterRole, metav1.CreateOptions{})
+	if kerrors.IsAlreadyExists(err) {
+		_, err = c.Clientset.RbacV1().ClusterRoles().Update(ctx, clusterRole, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	// The role of a binding cannot be changed, so replace the binding to cluster-admin created by earlier versions
+	existing, err := c.Clientset.RbacV1().ClusterRoleBindings().Get(ctx, clusterRoleBinding.Name, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+	case err != nil:
+		return err
+	case existing.RoleRef != clusterRoleBinding.RoleRef:
+		err = c.Clientset.RbacV1().ClusterRoleBindings().Delete(ctx, clusterRoleBinding.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+	_, err = c.Clientset.RbacV1().ClusterRoleBindings().Create(ctx, clusterRoleBinding, metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	cronJob := buildCredentialRotationCronJob(schedule, agent.Spec.Template.Spec.Containers[0].Image, agent.Spec.Template.Spec.ImagePullSecrets, args)
+	_, err = c.Clientset.BatchV1().CronJobs(cronJob.Namespace).Create(ctx, cronJob, metav1.CreateOptions{})
+	if kerrors.IsAlreadyExists(err) {
+		_, err = c.Clientset.BatchV1().CronJobs(cronJob.Namespace).Update(ctx, cronJob, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to schedule the credential rotation: %w", err)
+	}
+	return nil
+}
+
+// UnscheduleCredentialRotation removes the credential rotation CronJob, its service account and its roles.
+func (c *Cluster) UnscheduleCredentialRotation(ctx context.Context) error {
+	propagation := metav1.DeletePropagationBackground
+	err := c.Clientset.BatchV1().CronJobs(ZarfNamespaceName).Delete(ctx, CredentialRotationName, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	err = c.Clientset.RbacV1().ClusterRoleBindings().Delete(ctx, CredentialRotationName, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	err = c.Clientset.RbacV1().ClusterRoles().Delete(ctx, CredentialRotationName, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	err = c.Clientset.RbacV1().RoleBindings(ZarfNamespaceName).Delete(ctx, CredentialRotationName, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	err = c.Clientset.RbacV1().Roles(ZarfNamespaceName).Delete(ctx, CredentialRotationName, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	err = c.Clientset.CoreV1().ServiceAccounts(ZarfNamespaceName).Delete(ctx, CredentialRotationName, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// buildCredentialRotationRBAC returns the roles of the credential rotation service account and their bindings.
+// The Role covers the Zarf state, the Zarf Helm releases and the git server tunnel in the Zarf namespace, the
+// ClusterRole only allows updating the Zarf pull secrets of other namespaces and the webhooks of the agent release.
+func buildCredentialRotationRBAC() (*rbacv1.Role, *rbacv1.RoleBinding, *rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding) {
+	labels := map[string]string{ZarfManagedByLabel: "zarf"}
+	subjects := []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      CredentialRotationName,
+			Namespace: ZarfNamespaceName,
+		},
+	}
+	readVerbs := []string{"get", "list", "watch"}
+	updateVerbs := []string{"get", "list", "watch", "update", "patch"}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CredentialRotationName,
+			Namespace: ZarfNamespaceName,
+			Labels:    labels,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				// The Zarf state, the chart secrets and the Helm release records
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+			},
+			{
+				APIGroups: []string{"apps"},
+				Resources: []string{"deployments"},
+				Verbs:     updateVerbs,
+			},
+			{
+				// Helm reads every object of a release on upgrade and waits for its workloads
+				APIGroups: []string{""},
+				Resources: []string{"configmaps", "pods", "persistentvolumeclaims", "serviceaccounts", "services"},
+				Verbs:     readVerbs,
+			},
+			{
+				APIGroups: []string{"apps"},
+				Resources: []string{"replicasets"},
+				Verbs:     readVerbs,
+			},
+			{
+				APIGroups: []string{"autoscaling"},
+				Resources: []string{"horizontalpodautoscalers"},
+				Verbs:     readVerbs,
+			},
+			{
+				APIGroups: []string{"policy"},
+				Resources: []string{"poddisruptionbudgets"},
+				Verbs:     readVerbs,
+			},
+			{
+				APIGroups: []string{rbacv1.GroupName},
+				Resources: []string{"roles", "rolebindings"},
+				Verbs:     readVerbs,
+			},
+			{
+				// Tunnel to the git server to update its users
+				APIGroups: []string{""},
+				Resources: []string{"pods/portforward"},
+				Verbs:     []string{"create"},
+			},
+		},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CredentialRotationName,
+			Namespace: ZarfNamespaceName,
+			Labels:    labels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+		Subjects: subjects,
+	}
+
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   CredentialRotationName,
+			Labels: labels,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"namespaces", "services"},
+				Verbs:     []string{"get", "list"},
+			},
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{config.ZarfImagePullSecretName, config.ZarfGitServerSecretName},
+				Verbs:         []string{"get", "update"},
+			},
+			{
+				// The agent release holds the CA of the agent certificate in its webhooks
+				APIGroups:     []string{"admissionregistration.k8s.io"},
+				Resources:     []string{"mutatingwebhookconfigurations", "validatingwebhookconfigurations"},
+				ResourceNames: []string{"zarf"},
+				Verbs:         []string{"get", "update", "patch"},
+			},
+			{
+				APIGroups: []string{rbacv1.GroupName},
+				Resources: []string{"clusterroles", "clusterrolebindings"},
+				Verbs:     []string{"get"},
+			},
+		},
+	}
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   CredentialRotationName,
+			Labels: labels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRole.Name,
+		},
+		Subjects: subjects,
+	}
+	return role, roleBinding, clusterRole, clusterRoleBinding
+}
+
+func buildCredentialRotationCronJob(schedule, image string, pullSecrets []corev1.LocalObjectReference, args []string) *batchv1.CronJob {
+	backoffLimit := int32(0)
+	historyLimit := int32(3)
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CredentialRotationName,
+			Namespace: ZarfNamespaceName,
+			Labels:    map[string]string{ZarfManagedByLabel: "zarf"},
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: &historyLimit,
+			FailedJobsHistoryLimit:     &historyLimit,
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					BackoffLimit: &backoffLimit,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								"app":      CredentialRotationName,
+								AgentLabel: "ignore",
+							},
+						},
+						Spec: corev1.PodSpec{
+							ServiceAccountName: CredentialRotationName,
+							RestartPolicy:      corev1.RestartPolicyNever,
+							ImagePullSecrets:   pullSecrets,
+							Containers: []corev1.Container{
+								{
+									Name:            "rotate",
+									Image:           image,
+									ImagePullPolicy: corev1.PullIfNotPresent,
+									Command:         append([]string{"/zarf", "tools", "rotate"}, args...),
+									Env: []corev1.EnvVar{
+										{
+											// Helm and the Zarf cache need a writable home directory
+											Name:  "HOME",
+											Value: "/tmp",
+										},
+										zarfStateKeyEnv(),
+									},
+									VolumeMounts: []corev1.VolumeMount{
+										{
+											Name:      "tmp",
+											MountPath: "/tmp",
+										},
+									},
+								},
+							},
+							Volumes: []corev1.Volume{
+								{
+									Name: "tmp",
+									VolumeSource: corev1.VolumeSource{
+										EmptyDir: &corev1.EmptyDirVolumeSource{},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
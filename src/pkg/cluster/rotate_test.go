@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/pki"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestCredentialsDueForRotation(t *testing.T) {
+	t.Parallel()
+
+	agentTLS, err := pki.GeneratePKI("agent-hook.zarf.svc")
+	require.NoError(t, err)
+	soon := time.Now().Add(24 * time.Hour)
+	later := time.Now().Add(60 * 24 * time.Hour)
+	services := []string{message.RegistryKey, message.GitKey, message.AgentKey}
+
+	tests := []struct {
+		name     string
+		state    types.ZarfState
+		deadline time.Time
+		expected []string
+	}{
+		{
+			name: "nothing expires before the deadline",
+			state: types.ZarfState{
+				RegistryInfo:          types.RegistryInfo{Address: "127.0.0.1:31999", NodePort: 31999},
+				GitServer:             types.GitServerInfo{Address: types.ZarfInClusterGitServiceURL},
+				AgentTLS:              agentTLS,
+				CredentialExpirations: types.CredentialExpirations{Registry: &later, Git: &later},
+			},
+			deadline: time.Now().Add(30 * 24 * time.Hour),
+			expected: []string{},
+		},
+		{
+			name: "expiring and unknown expirations are due",
+			state: types.ZarfState{
+				RegistryInfo:          types.RegistryInfo{Address: "127.0.0.1:31999", NodePort: 31999},
+				GitServer:             types.GitServerInfo{Address: types.ZarfInClusterGitServiceURL},
+				AgentTLS:              agentTLS,
+				CredentialExpirations: types.CredentialExpirations{Registry: &soon},
+			},
+			deadline: time.Now().Add(30 * 24 * time.Hour),
+			expected: []string{message.RegistryKey, message.GitKey},
+		},
+		{
+			name: "external services are never due",
+			state: types.ZarfState{
+				RegistryInfo: types.RegistryInfo{Address: "registry.example.com"},
+				GitServer:    types.GitServerInfo{Address: "https://git.example.com"},
+				AgentTLS:     agentTLS,
+			},
+			deadline: time.Now().Add(400 * 24 * time.Hour),
+			expected: []string{message.AgentKey},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			due, err := CredentialsDueForRotation(&tt.state, services, tt.deadline)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, due)
+		})
+	}
+
+	_, err = CredentialsDueForRotation(&types.ZarfState{}, []string{message.ArtifactKey}, time.Now())
+	require.EqualError(t, err, "credentials for artifact cannot be rotated")
+}
+
+func TestSetCredentialLifetime(t *testing.T) {
+	t.Parallel()
+
+	agentTLS, err := pki.GeneratePKI("agent-hook.zarf.svc")
+	require.NoError(t, err)
+	registryExpiresAt := time.Now().Add(24 * time.Hour)
+	gitExpiresAt := time.Now().Add(60 * 24 * time.Hour)
+	oldState := &types.ZarfState{
+		Distro:                "k3s",
+		RegistryInfo:          types.RegistryInfo{Address: "127.0.0.1:31999", NodePort: 31999, PushUsername: "zarf-push", PushPassword: "push", PullUsername: "zarf-pull", PullPassword: "pull"},
+		GitServer:             types.GitServerInfo{Address: types.ZarfInClusterGitServiceURL, PushUsername: "zarf-git-user", PushPassword: "push", PullUsername: "zarf-git-read-user", PullPassword: "pull"},
+		AgentTLS:              agentTLS,
+		CredentialExpirations: types.CredentialExpirations{Registry: &registryExpiresAt, Git: &gitExpiresAt},
+	}
+
+	// Rotating the registry alone leaves the git expiration so that its credentials are still due on schedule
+	rotated := []string{message.RegistryKey}
+	newState, err := MergeZarfState(oldState, types.ZarfInitOptions{}, rotated)
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(30 * 24 * time.Hour)
+	SetCredentialLifetime(newState, rotated, expiresAt)
+	require.NotEqual(t, oldState.RegistryInfo.PushPassword, newState.RegistryInfo.PushPassword)
+	require.Equal(t, expiresAt, *newState.CredentialExpirations.Registry)
+	require.Equal(t, oldState.GitServer.PushPassword, newState.GitServer.PushPassword)
+	require.Equal(t, gitExpiresAt, *newState.CredentialExpirations.Git)
+
+	// External services do not get an expiration
+	external := &types.ZarfState{}
+	SetCredentialLifetime(external, []string{message.RegistryKey, message.GitKey}, expiresAt)
+	require.Nil(t, external.CredentialExpirations.Registry)
+	require.Nil(t, external.CredentialExpirations.Git)
+}
+
+func TestScheduleCredentialRotation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{
+		Clientset: fake.NewSimpleClientset(),
+	}
+
+	err := c.ScheduleCredentialRotation(ctx, "0 3 * * 0", []string{"--confirm"})
+	require.ErrorContains(t, err, "unable to find the Zarf agent image")
+
+	agent := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentDeploymentName,
+			Namespace: ZarfNamespaceName,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "private-registry"}},
+					Containers:       []corev1.Container{{Name: "server", Image: "127.0.0.1:31999/zarf-dev/zarf/agent:v0.36.0"}},
+				},
+			},
+		},
+	}
+	_, err = c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Create(ctx, agent, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// A binding to cluster-admin from an earlier version is replaced
+	legacy := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: CredentialRotationName},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+	}
+	_, err = c.Clientset.RbacV1().ClusterRoleBindings().Create(ctx, legacy, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// Scheduling twice updates the existing CronJob
+	err = c.ScheduleCredentialRotation(ctx, "0 3 * * 0", []string{"--confirm"})
+	require.NoError(t, err)
+	err = c.ScheduleCredentialRotation(ctx, "0 4 * * 0", []string{"--confirm", "registry"})
+	require.NoError(t, err)
+
+	cronJob, err := c.Clientset.BatchV1().CronJobs(ZarfNamespaceName).Get(ctx, CredentialRotationName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "0 4 * * 0", cronJob.Spec.Schedule)
+	podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	require.Equal(t, CredentialRotationName, podSpec.ServiceAccountName)
+	require.Equal(t, "private-registry", podSpec.ImagePullSecrets[0].Name)
+	require.Equal(t, "127.0.0.1:31999/zarf-dev/zarf/agent:v0.36.0", podSpec.Containers[0].Image)
+	require.Equal(t, []string{"/zarf", "tools", "rotate", "--confirm", "registry"}, podSpec.Containers[0].Command)
+
+	crb, err := c.Clientset.RbacV1().ClusterRoleBindings().Get(ctx, CredentialRotationName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: CredentialRotationName}, crb.RoleRef)
+	clusterRole, err := c.Clientset.RbacV1().ClusterRoles().Get(ctx, CredentialRotationName, metav1.GetOptions{})
+	require.NoError(t, err)
+	for _, rule := range clusterRole.Rules {
+		if slices.Contains(rule.Resources, "secrets") {
+			require.Equal(t, []string{"private-registry", "private-git-server"}, rule.ResourceNames)
+		}
+	}
+	rb, err := c.Clientset.RbacV1().RoleBindings(ZarfNamespaceName).Get(ctx, CredentialRotationName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: CredentialRotationName}, rb.RoleRef)
+	require.Equal(t, CredentialRotationName, rb.Subjects[0].Name)
+	_, err = c.Clientset.RbacV1().Roles(ZarfNamespaceName).Get(ctx, CredentialRotationName, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	err = c.UnscheduleCredentialRotation(ctx)
+	require.NoError(t, err)
+	_, err = c.Clientset.BatchV1().CronJobs(ZarfNamespaceName).Get(ctx, CredentialRotationName, metav1.GetOptions{})
+	require.Error(t, err)
+	_, err = c.Clientset.RbacV1().ClusterRoleBindings().Get(ctx, CredentialRotationName, metav1.GetOptions{})
+	require.Error(t, err)
+	_, err = c.Clientset.RbacV1().ClusterRoles().Get(ctx, CredentialRotationName, metav1.GetOptions{})
+	require.Error(t, err)
+	_, err = c.Clientset.RbacV1().Roles(ZarfNamespaceName).Get(ctx, CredentialRotationName, metav1.GetOptions{})
+	require.Error(t, err)
+}
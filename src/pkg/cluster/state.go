@@ -19,6 +19,7 @@ import (
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/encryption"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/pki"
 	"github.com/zarf-dev/zarf/src/types"
@@ -26,13 +27,23 @@ import (
 
 // Zarf Cluster Constants.
 const (
-	ZarfManagedByLabel   = "app.kubernetes.io/managed-by"
-	ZarfNamespaceName    = "zarf"
-	ZarfStateSecretName  = "zarf-state"
-	ZarfStateDataKey     = "state"
-	ZarfPackageInfoLabel = "package-deploy-info"
+	ZarfManagedByLabel              = "app.kubernetes.io/managed-by"
+	ZarfNamespaceName               = "zarf"
+	ZarfStateSecretName             = "zarf-state"
+	ZarfStateDataKey                = "state"
+	ZarfStateEnvelopeKey            = "encryption"
+	ZarfStateKeySecretName          = "zarf-state-encryption"
+	ZarfStateKeySecretKey           = "key"
+	ZarfPackageInfoLabel            = "package-deploy-info"
+	ZarfPackageHistoryLabel         = "package-deploy-history"
+	ZarfPackageGenerationAnnotation = "zarf.dev/package-generation"
+
+	sanitizedValue = "**sanitized**"
 )
 
+// errAgeStateKey is returned when an age identity would have to be stored in the cluster for the agent to decrypt the state.
+var errAgeStateKey = errors.New("the Zarf agent must be able to decrypt the zarf state, use a KMS key URI instead of an age identity so the key is not stored in the cluster")
+
 // InitZarfState initializes the Zarf state with the given temporary directory and init configs.
 func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitOptions) error {
 	spinner := message.NewProgressSpinner("Gathering cluster state information")
@@ -165,6 +176,14 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 		state.RegistryInfo = initOptions.RegistryInfo
 		initOptions.ArtifactServer.FillInEmptyValues()
 		state.ArtifactServer = initOptions.ArtifactServer
+
+		expiresAt := time.Now().Add(DefaultCredentialLifetime)
+		if state.RegistryInfo.IsInternal() {
+			state.CredentialExpirations.Registry = &expiresAt
+		}
+		if state.GitServer.IsInternal() {
+			state.CredentialExpirations.Git = &expiresAt
+		}
 	} else {
 		if helpers.IsNotZeroAndNotEqual(initOptions.GitServer, state.GitServer) {
 			message.Warn("Detected a change in Git Server init options on a re-init. Ignoring... To update run:")
@@ -195,6 +214,25 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 		state.StorageClass = initOptions.StorageClass
 	}
 
+	if initOptions.ImageVerification.Enabled() {
+		state.ImageVerification = initOptions.ImageVerification
+	}
+
+	if !initOptions.AgentExclusions.IsEmpty() {
+		state.AgentExclusions = initOptions.AgentExclusions
+	}
+
+	if len(initOptions.AgentCRDMutations) > 0 {
+		state.AgentCRDMutations = initOptions.AgentCRDMutations
+	}
+
+	// Share the key before the agent is deployed so that it can read the encrypted state
+	if key := config.CommonOptions.StateEncryptionKey; key != "" {
+		if _, err := c.shareZarfStateKey(ctx, key); err != nil {
+			return err
+		}
+	}
+
 	spinner.Success()
 
 	// Save the state back to K8s
@@ -216,27 +254,92 @@ func (c *Cluster) LoadZarfState(ctx context.Context) (state *types.ZarfState, er
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", stateErr, err)
 	}
+	if envelopeData, ok := secret.Data[ZarfStateEnvelopeKey]; ok {
+		if err := decryptZarfState(ctx, state, envelopeData, config.CommonOptions.StateEncryptionKey); err != nil {
+			return nil, err
+		}
+	}
 	c.debugPrintZarfState(state)
 	return state, nil
 }
 
-func (c *Cluster) sanitizeZarfState(state *types.ZarfState) *types.ZarfState {
+// zarfStateSecrets returns the sensitive string fields of the state that are encrypted with the state encryption key,
+// keyed by the name of the field that encrypted values are bound to.
+func zarfStateSecrets(state *types.ZarfState) map[string]*string {
+	return map[string]*string{
+		"gitServer.pushPassword":    &state.GitServer.PushPassword,
+		"gitServer.pullPassword":    &state.GitServer.PullPassword,
+		"registryInfo.pushPassword": &state.RegistryInfo.PushPassword,
+		"registryInfo.pullPassword": &state.RegistryInfo.PullPassword,
+		"registryInfo.secret":       &state.RegistryInfo.Secret,
+		"artifactServer.pushToken":  &state.ArtifactServer.PushToken,
+	}
+}
+
+// transformZarfStateSecrets replaces the sensitive fields of the state with the result of transform, which is given
+// the name of each field. The AgentTLS key is replaced rather than modified so shallow copies of the state are left untouched.
+func transformZarfStateSecrets(state *types.ZarfState, transform func(field, value string) (string, error)) error {
+	for name, field := range zarfStateSecrets(state) {
+		value, err := transform(name, *field)
+		if err != nil {
+			return err
+		}
+		*field = value
+	}
+	if len(state.AgentTLS.Key) > 0 {
+		key, err := transform("agentTLS.key", string(state.AgentTLS.Key))
+		if err != nil {
+			return err
+		}
+		state.AgentTLS.Key = []byte(key)
+	}
+	return nil
+}
+
+func decryptZarfState(ctx context.Context, state *types.ZarfState, envelopeData []byte, key string) error {
+	var envelope encryption.Envelope
+	if err := json.Unmarshal(envelopeData, &envelope); err != nil {
+		return fmt.Errorf("unable to read the zarf state encryption envelope: %w", err)
+	}
+	if key == "" {
+		return fmt.Errorf("the zarf state is encrypted with %s key %s, set ZARF_STATE_ENCRYPTION_KEY to decrypt it", envelope.Provider, envelope.KeyID)
+	}
+	provider, err := encryption.NewKeyProvider(ctx, key)
+	if err != nil {
+		return err
+	}
+	dataKey, err := envelope.DataKey(ctx, provider)
+	if err != nil {
+		return err
+	}
+	err = transformZarfStateSecrets(state, func(field, value string) (string, error) {
+		return encryption.Decrypt(dataKey, field, value)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to decrypt the zarf state: %w", err)
+	}
+	return nil
+}
+
+// SanitizeZarfState overwrites the credentials and TLS material of the state in place and returns it.
+// Pass a shallow copy to keep the original state intact.
+func SanitizeZarfState(state *types.ZarfState) *types.ZarfState {
 	// Overwrite the AgentTLS information
-	state.AgentTLS.CA = []byte("**sanitized**")
-	state.AgentTLS.Cert = []byte("**sanitized**")
-	state.AgentTLS.Key = []byte("**sanitized**")
+	state.AgentTLS.CA = []byte(sanitizedValue)
+	state.AgentTLS.Cert = []byte(sanitizedValue)
+	state.AgentTLS.Key = []byte(sanitizedValue)
 
 	// Overwrite the GitServer passwords
-	state.GitServer.PushPassword = "**sanitized**"
-	state.GitServer.PullPassword = "**sanitized**"
+	state.GitServer.PushPassword = sanitizedValue
+	state.GitServer.PullPassword = sanitizedValue
 
 	// Overwrite the RegistryInfo passwords
-	state.RegistryInfo.PushPassword = "**sanitized**"
-	state.RegistryInfo.PullPassword = "**sanitized**"
-	state.RegistryInfo.Secret = "**sanitized**"
+	state.RegistryInfo.PushPassword = sanitizedValue
+	state.RegistryInfo.PullPassword = sanitizedValue
+	state.RegistryInfo.Secret = sanitizedValue
 
 	// Overwrite the ArtifactServer secret
-	state.ArtifactServer.PushToken = "**sanitized**"
+	state.ArtifactServer.PushToken = sanitizedValue
 
 	return state
 }
@@ -247,7 +350,7 @@ func (c *Cluster) debugPrintZarfState(state *types.ZarfState) {
 	}
 	// this is a shallow copy, nested pointers WILL NOT be copied
 	oldState := *state
-	sanitized := c.sanitizeZarfState(&oldState)
+	sanitized := SanitizeZarfState(&oldState)
 	b, err := json.MarshalIndent(sanitized, "", "  ")
 	if err != nil {
 		return
@@ -256,10 +359,164 @@ func (c *Cluster) debugPrintZarfState(state *types.ZarfState) {
 }
 
 // SaveZarfState takes a given state and persists it to the Zarf/zarf-state secret.
+// Sensitive fields are encrypted when a state encryption key is configured.
 func (c *Cluster) SaveZarfState(ctx context.Context, state *types.ZarfState) error {
+	key := config.CommonOptions.StateEncryptionKey
+	if key == "" {
+		// Refuse to silently write plaintext credentials over an encrypted state.
+		secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretName, metav1.GetOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && secret.Data[ZarfStateEnvelopeKey] != nil {
+			return errors.New("the zarf state is encrypted, set ZARF_STATE_ENCRYPTION_KEY to update it or use 'zarf tools state migrate-encryption --decrypt' to store it in plaintext")
+		}
+		return c.saveZarfState(ctx, state, nil)
+	}
+	if !encryption.IsKMSKey(key) {
+		return errAgeStateKey
+	}
+	provider, err := encryption.NewKeyProvider(ctx, key)
+	if err != nil {
+		return err
+	}
+	return c.saveZarfState(ctx, state, provider)
+}
+
+// MigrateZarfStateEncryption re-encrypts the Zarf state with a new key, or stores it in plaintext when newKey is empty.
+// The key is shared with in-cluster Zarf workloads through the zarf-state-encryption secret and the agent is restarted to pick it up.
+func (c *Cluster) MigrateZarfStateEncryption(ctx context.Context, newKey string) error {
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return err
+	}
+	var provider encryption.KeyProvider
+	if newKey != "" {
+		// Share the new key before switching the state so the agent can always read it.
+		provider, err = c.shareZarfStateKey(ctx, newKey)
+		if err != nil {
+			return err
+		}
+	}
+	if err := c.saveZarfState(ctx, state, provider); err != nil {
+		return err
+	}
+	if newKey == "" {
+		err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Delete(ctx, ZarfStateKeySecretName, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete the zarf state encryption key secret: %w", err)
+		}
+	}
+	return c.restartAgent(ctx)
+}
+
+// shareZarfStateKey stores the KMS key URI in the zarf-state-encryption secret that the agent and the credential
+// rotation CronJob read it from and returns its provider. Age identities are refused because storing them next to the
+// state would let anyone who can read the secrets of the Zarf namespace decrypt it.
+func (c *Cluster) shareZarfStateKey(ctx context.Context, key string) (encryption.KeyProvider, error) {
+	key, err := encryption.ResolveKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !encryption.IsKMSKey(key) {
+		return nil, errAgeStateKey
+	}
+	provider, err := encryption.NewKeyProvider(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.saveZarfStateKeySecret(ctx, key); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+func (c *Cluster) saveZarfStateKeySecret(ctx context.Context, key string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ZarfStateKeySecretName,
+			Namespace: ZarfNamespaceName,
+			Labels: map[string]string{
+				ZarfManagedByLabel: "zarf",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			ZarfStateKeySecretKey: []byte(key),
+		},
+	}
+	_, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("unable to create the zarf state encryption key secret: %w", err)
+	}
+	if err == nil {
+		return nil
+	}
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to update the zarf state encryption key secret: %w", err)
+	}
+	return nil
+}
+
+// restartAgent triggers a rolling update of the Zarf agent if it is deployed.
+func (c *Cluster) restartAgent(ctx context.Context) error {
+	deployment, err := c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Get(ctx, agentDeploymentName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations["zarf.dev/restartedAt"] = time.Now().UTC().Format(time.RFC3339)
+	_, err = c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Update(ctx, deployment, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to restart the zarf agent: %w", err)
+	}
+	return nil
+}
+
+// zarfStateKeyEnv exposes the state encryption key to in-cluster Zarf workloads when one has been configured.
+func zarfStateKeyEnv() corev1.EnvVar {
+	optional := true
+	return corev1.EnvVar{
+		Name: "ZARF_STATE_ENCRYPTION_KEY",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: ZarfStateKeySecretName},
+				Key:                  ZarfStateKeySecretKey,
+				Optional:             &optional,
+			},
+		},
+	}
+}
+
+func (c *Cluster) saveZarfState(ctx context.Context, state *types.ZarfState, provider encryption.KeyProvider) error {
 	c.debugPrintZarfState(state)
 
-	data, err := json.Marshal(&state)
+	stored := *state
+	var envelopeData []byte
+	if provider != nil {
+		envelope, dataKey, err := encryption.NewEnvelope(ctx, provider)
+		if err != nil {
+			return err
+		}
+		err = transformZarfStateSecrets(&stored, func(field, value string) (string, error) {
+			return encryption.Encrypt(dataKey, field, value)
+		})
+		if err != nil {
+			return fmt.Errorf("unable to encrypt the zarf state: %w", err)
+		}
+		envelopeData, err = json.Marshal(envelope)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(&stored)
 	if err != nil {
 		return err
 	}
@@ -280,6 +537,9 @@ func (c *Cluster) SaveZarfState(ctx context.Context, state *types.ZarfState) err
 			ZarfStateDataKey: data,
 		},
 	}
+	if envelopeData != nil {
+		secret.Data[ZarfStateEnvelopeKey] = envelopeData
+	}
 
 	// Attempt to create or update the secret and return.
 	_, err = c.Clientset.CoreV1().Secrets(secret.Namespace).Create(ctx, secret, metav1.CreateOptions{})
@@ -315,6 +575,11 @@ func MergeZarfState(oldState *types.ZarfState, initOptions types.ZarfInitOptions
 				return nil, fmt.Errorf("%s: %w", lang.ErrUnableToGenerateRandomSecret, err)
 			}
 		}
+		newState.CredentialExpirations.Registry = nil
+		if newState.RegistryInfo.IsInternal() {
+			expiresAt := time.Now().Add(DefaultCredentialLifetime)
+			newState.CredentialExpirations.Registry = &expiresAt
+		}
 	}
 	if slices.Contains(services, message.GitKey) {
 		// TODO: Replace use of reflections with explicit setting
@@ -331,6 +596,11 @@ func MergeZarfState(oldState *types.ZarfState, initOptions types.ZarfInitOptions
 				return nil, fmt.Errorf("%s: %w", lang.ErrUnableToGenerateRandomSecret, err)
 			}
 		}
+		newState.CredentialExpirations.Git = nil
+		if newState.GitServer.IsInternal() {
+			expiresAt := time.Now().Add(DefaultCredentialLifetime)
+			newState.CredentialExpirations.Git = &expiresAt
+		}
 	}
 	if slices.Contains(services, message.ArtifactKey) {
 		// TODO: Replace use of reflections with explicit setting
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/invopop/jsonschema"
+	"github.com/xeipuuv/gojsonschema"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+var zarfStateSchema = sync.OnceValues(func() ([]byte, error) {
+	reflector := jsonschema.Reflector{ExpandedStruct: true}
+	return json.Marshal(reflector.Reflect(&types.ZarfState{}))
+})
+
+// ValidateZarfState validates a JSON encoded Zarf state against the schema generated from the ZarfState type.
+func ValidateZarfState(data []byte) error {
+	schema, err := zarfStateSchema()
+	if err != nil {
+		return fmt.Errorf("unable to generate the zarf state schema: %w", err)
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return fmt.Errorf("unable to validate the zarf state: %w", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	errs := []error{}
+	for _, resultErr := range result.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", resultErr.Field(), resultErr.Description()))
+	}
+	return fmt.Errorf("the zarf state is invalid: %w", errors.Join(errs...))
+}
+
+// SetZarfStateValues returns a copy of the state with the values set at their JSON paths, e.g. registryInfo.address.
+// Values replacing strings are used as is, other values are parsed as YAML. The result is validated against the schema.
+func SetZarfStateValues(state *types.ZarfState, values map[string]string) (*types.ZarfState, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	doc := map[string]any{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	for path, value := range values {
+		if err := setJSONPath(doc, strings.Split(path, "."), value); err != nil {
+			return nil, err
+		}
+	}
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateZarfState(data); err != nil {
+		return nil, err
+	}
+	var updated types.ZarfState
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func setJSONPath(doc map[string]any, path []string, value string) error {
+	node := doc
+	for i, key := range path[:len(path)-1] {
+		next, ok := node[key].(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s is not an object in the zarf state", strings.Join(path[:i+1], "."))
+		}
+		node = next
+	}
+	key := path[len(path)-1]
+	if key == "" {
+		return fmt.Errorf("invalid zarf state path %q", strings.Join(path, "."))
+	}
+	if _, ok := node[key].(string); ok {
+		node[key] = value
+		return nil
+	}
+	var parsed any
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+		return fmt.Errorf("unable to parse the value of %s: %w", strings.Join(path, "."), err)
+	}
+	node[key] = parsed
+	return nil
+}
+
+// ExportZarfState returns a backup of the Zarf state, the deployed package records and their history, sanitizing the
+// credentials and the deploy time variables of the history when redact is set.
+func (c *Cluster) ExportZarfState(ctx context.Context, redact bool) (*types.ZarfStateBackup, error) {
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deployedPackages, err := c.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	packageHistory := map[string][]types.DeployedPackageRevision{}
+	for _, deployedPackage := range deployedPackages {
+		revisions, err := c.GetPackageHistory(ctx, deployedPackage.Name)
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		packageHistory[deployedPackage.Name] = revisions
+	}
+	if redact {
+		SanitizeZarfState(state)
+		for _, revisions := range packageHistory {
+			for _, revision := range revisions {
+				for name := range revision.Variables {
+					revision.Variables[name] = sanitizedValue
+				}
+			}
+		}
+	}
+	backup := &types.ZarfStateBackup{
+		CLIVersion:       config.CLIVersion,
+		CreatedAt:        time.Now().UTC(),
+		Redacted:         redact,
+		State:            *state,
+		DeployedPackages: deployedPackages,
+		PackageHistory:   packageHistory,
+	}
+	return backup, nil
+}
+
+// ReadZarfStateBackup parses a JSON or YAML Zarf state backup and validates its state against the schema.
+func ReadZarfStateBackup(data []byte) (*types.ZarfStateBackup, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the zarf state backup: %w", err)
+	}
+	var doc struct {
+		State json.RawMessage `json:"state"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse the zarf state backup: %w", err)
+	}
+	if len(doc.State) == 0 || string(doc.State) == "null" {
+		return nil, errors.New("the zarf state backup does not contain a state")
+	}
+	if err := ValidateZarfState(doc.State); err != nil {
+		return nil, err
+	}
+	var backup types.ZarfStateBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, fmt.Errorf("unable to parse the zarf state backup: %w", err)
+	}
+	if backup.Redacted || isSanitizedZarfState(&backup.State) {
+		return nil, errors.New("the zarf state backup was exported with redacted credentials and cannot be restored")
+	}
+	return &backup, nil
+}
+
+func isSanitizedZarfState(state *types.ZarfState) bool {
+	for _, field := range zarfStateSecrets(state) {
+		if *field == sanitizedValue {
+			return true
+		}
+	}
+	return string(state.AgentTLS.Key) == sanitizedValue
+}
+
+// ImportZarfState restores the Zarf state, the deployed package records and their history of a backup, creating the
+// Zarf namespace if needed.
+func (c *Cluster) ImportZarfState(ctx context.Context, backup *types.ZarfStateBackup) error {
+	_, err := c.Clientset.CoreV1().Namespaces().Create(ctx, NewZarfManagedNamespace(ZarfNamespaceName), metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("unable to create the Zarf namespace: %w", err)
+	}
+	if err := c.SaveZarfState(ctx, &backup.State); err != nil {
+		return err
+	}
+	return c.ImportDeployedPackages(ctx, backup)
+}
+
+// ImportDeployedPackages restores the deployed package records and their history of a backup without the Zarf state.
+func (c *Cluster) ImportDeployedPackages(ctx context.Context, backup *types.ZarfStateBackup) error {
+	for _, deployedPackage := range backup.DeployedPackages {
+		if err := c.RestoreDeployedPackage(ctx, deployedPackage); err != nil {
+			return err
+		}
+	}
+	for packageName, revisions := range backup.PackageHistory {
+		for _, revision := range revisions {
+			if err := c.RestorePackageRevision(ctx, packageName, revision); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func testZarfState() *types.ZarfState {
+	return &types.ZarfState{
+		Distro:       DistroIsK3d,
+		Architecture: "amd64",
+		AgentTLS: types.GeneratedPKI{
+			CA:   []byte("ca"),
+			Cert: []byte("cert"),
+			Key:  []byte("key"),
+		},
+		GitServer: types.GitServerInfo{
+			PushUsername: "zarf-git-user",
+			PushPassword: "git-push-password",
+			Address:      "http://zarf-gitea-http.zarf.svc.cluster.local:3000",
+		},
+		RegistryInfo: types.RegistryInfo{
+			PushUsername: "zarf-push",
+			PushPassword: "registry-push-password",
+			PullUsername: "zarf-pull",
+			PullPassword: "registry-pull-password",
+			Address:      "127.0.0.1:31999",
+			NodePort:     31999,
+			Secret:       "registry-secret",
+		},
+	}
+}
+
+func TestValidateZarfState(t *testing.T) {
+	t.Parallel()
+
+	valid, err := json.Marshal(testZarfState())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		expectedErr string
+	}{
+		{
+			name: "valid state",
+			data: valid,
+		},
+		{
+			name:        "wrong type",
+			data:        []byte(`{"distro": 1}`),
+			expectedErr: "distro: Invalid type",
+		},
+		{
+			name:        "unknown field",
+			data:        []byte(`{"distroo": "k3d"}`),
+			expectedErr: "Additional property distroo is not allowed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateZarfState(tt.data)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSetZarfStateValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		values      map[string]string
+		expected    func(state *types.ZarfState)
+		expectedErr string
+	}{
+		{
+			name:   "string and number values",
+			values: map[string]string{"registryInfo.address": "127.0.0.1:31888", "registryInfo.nodePort": "31888", "registryInfo.pushPassword": "1234"},
+			expected: func(state *types.ZarfState) {
+				state.RegistryInfo.Address = "127.0.0.1:31888"
+				state.RegistryInfo.NodePort = 31888
+				state.RegistryInfo.PushPassword = "1234"
+			},
+		},
+		{
+			name:        "invalid type",
+			values:      map[string]string{"registryInfo.nodePort": "high"},
+			expectedErr: "registryInfo.nodePort: Invalid type",
+		},
+		{
+			name:        "unknown field",
+			values:      map[string]string{"registryInfo.port": "31888"},
+			expectedErr: "Additional property port is not allowed",
+		},
+		{
+			name:        "not an object",
+			values:      map[string]string{"distro.name": "k3s"},
+			expectedErr: "distro is not an object in the zarf state",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := testZarfState()
+			updated, err := SetZarfStateValues(state, tt.values)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testZarfState(), state)
+			expected := testZarfState()
+			tt.expected(expected)
+			require.Equal(t, expected, updated)
+		})
+	}
+}
+
+func TestExportImportZarfState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	source := &Cluster{Clientset: fake.NewSimpleClientset()}
+	err := source.SaveZarfState(ctx, testZarfState())
+	require.NoError(t, err)
+	deployedPackage := types.DeployedPackage{
+		Name:       "podinfo",
+		CLIVersion: "v0.36.0",
+		Generation: 3,
+		Data: v1alpha1.ZarfPackage{
+			Kind:     v1alpha1.ZarfPackageConfig,
+			Metadata: v1alpha1.ZarfMetadata{Name: "podinfo"},
+		},
+		DeployedComponents: []types.DeployedComponent{{Name: "podinfo"}},
+	}
+	err = source.RestoreDeployedPackage(ctx, deployedPackage)
+	require.NoError(t, err)
+	for generation := 2; generation <= 3; generation++ {
+		revision := deployedPackage
+		revision.Generation = generation
+		err = source.RecordPackageRevision(ctx, revision, map[string]string{"DOMAIN": "example.com"})
+		require.NoError(t, err)
+	}
+	history, err := source.GetPackageHistory(ctx, deployedPackage.Name)
+	require.NoError(t, err)
+
+	backup, err := source.ExportZarfState(ctx, false)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]types.DeployedPackageRevision{"podinfo": history}, backup.PackageHistory)
+	data, err := yaml.Marshal(backup)
+	require.NoError(t, err)
+
+	restored, err := ReadZarfStateBackup(data)
+	require.NoError(t, err)
+	target := &Cluster{Clientset: fake.NewSimpleClientset()}
+	err = target.ImportZarfState(ctx, restored)
+	require.NoError(t, err)
+
+	state, err := target.LoadZarfState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, testZarfState(), state)
+	deployedPackages, err := target.GetDeployedZarfPackages(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []types.DeployedPackage{deployedPackage}, deployedPackages)
+	restoredHistory, err := target.GetPackageHistory(ctx, deployedPackage.Name)
+	require.NoError(t, err)
+	require.Equal(t, history, restoredHistory)
+
+	redacted, err := source.ExportZarfState(ctx, true)
+	require.NoError(t, err)
+	require.Equal(t, "**sanitized**", redacted.State.RegistryInfo.PushPassword)
+	require.Equal(t, map[string]string{"DOMAIN": "**sanitized**"}, redacted.PackageHistory["podinfo"][1].Variables)
+	data, err = json.Marshal(redacted)
+	require.NoError(t, err)
+	_, err = ReadZarfStateBackup(data)
+	require.ErrorContains(t, err, "cannot be restored")
+
+	_, err = ReadZarfStateBackup([]byte("cliVersion: v0.36.0\n"))
+	require.ErrorContains(t, err, "does not contain a state")
+}
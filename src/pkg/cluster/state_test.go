@@ -5,19 +5,29 @@
 package cluster
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"filippo.io/age"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/encryption"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/pki"
 	"github.com/zarf-dev/zarf/src/types"
@@ -229,6 +239,97 @@ func TestInitZarfState(t *testing.T) {
 	}
 }
 
+// testKMS is a KMS key provider that wraps data keys with the key URI so only the same URI unwraps them.
+type testKMS struct {
+	keyURI string
+}
+
+func (testKMS) Name() string    { return "testkms" }
+func (p testKMS) KeyID() string { return p.keyURI }
+func (p testKMS) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	return append([]byte(p.keyURI), dataKey...), nil
+}
+func (p testKMS) UnwrapKey(_ context.Context, wrappedKey []byte) ([]byte, error) {
+	dataKey, ok := bytes.CutPrefix(wrappedKey, []byte(p.keyURI))
+	if !ok {
+		return nil, errors.New("wrapped by another key")
+	}
+	return dataKey, nil
+}
+
+func TestInitZarfStateEncryption(t *testing.T) {
+	ctx := context.Background()
+	encryption.RegisterKMS("testkms", func(_ context.Context, keyURI string) (encryption.KeyProvider, error) {
+		return testKMS{keyURI: keyURI}, nil
+	})
+	t.Cleanup(func() {
+		config.CommonOptions.StateEncryptionKey = ""
+	})
+
+	newCluster := func(t *testing.T) *Cluster {
+		t.Helper()
+		cs := fake.NewSimpleClientset()
+		_, err := cs.CoreV1().Nodes().Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+		_, err = cs.CoreV1().Namespaces().Create(ctx, NewZarfManagedNamespace(ZarfNamespaceName), metav1.CreateOptions{})
+		require.NoError(t, err)
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: ZarfNamespaceName, Name: "default"}}
+		_, err = cs.CoreV1().ServiceAccounts(ZarfNamespaceName).Create(ctx, sa, metav1.CreateOptions{})
+		require.NoError(t, err)
+		return &Cluster{Clientset: cs}
+	}
+
+	// Age identities are never copied into the cluster
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "zarf-state.key")
+	err = os.WriteFile(keyPath, []byte(identity.String()), 0600)
+	require.NoError(t, err)
+	config.CommonOptions.StateEncryptionKey = keyPath
+	c := newCluster(t)
+	err = c.InitZarfState(ctx, types.ZarfInitOptions{})
+	require.ErrorIs(t, err, errAgeStateKey)
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateKeySecretName, metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+	err = c.SaveZarfState(ctx, &types.ZarfState{})
+	require.ErrorIs(t, err, errAgeStateKey)
+
+	config.CommonOptions.StateEncryptionKey = "testkms://zarf"
+	c = newCluster(t)
+	err = c.InitZarfState(ctx, types.ZarfInitOptions{})
+	require.NoError(t, err)
+
+	state, err := c.LoadZarfState(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, state.RegistryInfo.PushPassword)
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, string(secret.Data[ZarfStateDataKey]), state.RegistryInfo.PushPassword)
+
+	// The agent reads the key URI from the secret referenced by its manifest, as does the credential rotation CronJob
+	b, err := os.ReadFile("../../../packages/zarf-agent/manifests/deployment.yaml")
+	require.NoError(t, err)
+	var agent appsv1.Deployment
+	err = yaml.Unmarshal(b, &agent)
+	require.NoError(t, err)
+	var keyRef *corev1.SecretKeySelector
+	for _, env := range agent.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "ZARF_STATE_ENCRYPTION_KEY" {
+			keyRef = env.ValueFrom.SecretKeyRef
+		}
+	}
+	require.NotNil(t, keyRef)
+	require.Equal(t, zarfStateKeyEnv().ValueFrom.SecretKeyRef, keyRef)
+	keySecret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, keyRef.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "testkms://zarf", string(keySecret.Data[keyRef.Key]))
+
+	config.CommonOptions.StateEncryptionKey = string(keySecret.Data[keyRef.Key])
+	agentState, err := (&Cluster{Clientset: c.Clientset}).LoadZarfState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, state, agentState)
+}
+
 // TODO: Change password gen method to make testing possible.
 func TestMergeZarfStateRegistry(t *testing.T) {
 	t.Parallel()
@@ -488,3 +589,77 @@ func TestMergeZarfStateAgent(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEqual(t, oldState.AgentTLS, newState.AgentTLS)
 }
+
+func TestZarfStateEncryption(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	otherIdentity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	provider, err := encryption.NewAgeProvider(identity.String())
+	require.NoError(t, err)
+
+	state := &types.ZarfState{
+		Distro: DistroIsK3d,
+		GitServer: types.GitServerInfo{
+			PushUsername: "zarf-git-user",
+			PushPassword: "git-push-password",
+			PullPassword: "git-pull-password",
+		},
+		RegistryInfo: types.RegistryInfo{
+			PushPassword: "registry-push-password",
+			PullPassword: "registry-pull-password",
+			Secret:       "registry-secret",
+		},
+		AgentTLS: types.GeneratedPKI{
+			CA:   []byte("ca"),
+			Cert: []byte("cert"),
+			Key:  []byte("agent-key"),
+		},
+	}
+	expected := *state
+	expected.AgentTLS.Key = []byte("agent-key")
+
+	c := &Cluster{Clientset: fake.NewSimpleClientset()}
+	err = c.saveZarfState(ctx, state, provider)
+	require.NoError(t, err)
+	require.Equal(t, expected, *state)
+
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, string(secret.Data[ZarfStateDataKey]), "password")
+	require.NotContains(t, string(secret.Data[ZarfStateDataKey]), "registry-secret")
+	require.Contains(t, string(secret.Data[ZarfStateDataKey]), "zarf-git-user")
+	envelopeData, ok := secret.Data[ZarfStateEnvelopeKey]
+	require.True(t, ok)
+
+	var stored types.ZarfState
+	err = json.Unmarshal(secret.Data[ZarfStateDataKey], &stored)
+	require.NoError(t, err)
+	require.True(t, encryption.IsEncrypted(stored.GitServer.PushPassword))
+	require.Empty(t, stored.ArtifactServer.PushToken)
+
+	err = decryptZarfState(ctx, &stored, envelopeData, "")
+	require.ErrorContains(t, err, "set ZARF_STATE_ENCRYPTION_KEY")
+	err = decryptZarfState(ctx, &stored, envelopeData, otherIdentity.String())
+	require.ErrorContains(t, err, "unable to unwrap the data key")
+
+	// Encrypted values are bound to their field
+	swapped := stored
+	swapped.GitServer.PushPassword, swapped.GitServer.PullPassword = stored.GitServer.PullPassword, stored.GitServer.PushPassword
+	err = decryptZarfState(ctx, &swapped, envelopeData, identity.String())
+	require.ErrorContains(t, err, "unable to decrypt the value")
+
+	err = decryptZarfState(ctx, &stored, envelopeData, identity.String())
+	require.NoError(t, err)
+	require.Equal(t, expected, stored)
+
+	err = c.saveZarfState(ctx, state, nil)
+	require.NoError(t, err)
+	secret, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, secret.Data, ZarfStateEnvelopeKey)
+	require.Contains(t, string(secret.Data[ZarfStateDataKey]), "git-push-password")
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/collector"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// GetResourceStatuses returns the current status of the objects as computed by the status watcher. Unlike
+// WaitForReady it does not wait for the objects to become ready, it returns once the watcher has synced.
+func (c *Cluster) GetResourceStatuses(ctx context.Context, objs []object.ObjMetadata) (map[object.ObjMetadata]status.Status, error) {
+	cancelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	eventCh := c.Watcher.Watch(cancelCtx, objs, watcher.Options{})
+	statusCollector := collector.NewResourceStatusCollector(objs)
+	done := statusCollector.ListenWithObserver(eventCh, collector.ObserverFunc(
+		func(_ *collector.ResourceStatusCollector, e event.Event) {
+			if e.Type == event.SyncEvent {
+				cancel()
+			}
+		}),
+	)
+	<-done
+	if statusCollector.Error != nil {
+		return nil, statusCollector.Error
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	statuses := map[object.ObjMetadata]status.Status{}
+	for _, rs := range statusCollector.LatestObservation().ResourceStatuses {
+		statuses[rs.Identifier] = rs.Status
+	}
+	return statuses, nil
+}
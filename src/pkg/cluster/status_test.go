@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	pkgkubernetes "github.com/defenseunicorns/pkg/kubernetes"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestGetResourceStatuses(t *testing.T) {
+	t.Parallel()
+
+	objs := []object.ObjMetadata{
+		{GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"}, Namespace: "podinfo", Name: "podinfo"},
+		{GroupKind: schema.GroupKind{Kind: "Service"}, Namespace: "podinfo", Name: "podinfo"},
+	}
+	c := &Cluster{Watcher: pkgkubernetes.NewImmediateWatcher(status.NotFoundStatus)}
+	statuses, err := c.GetResourceStatuses(context.Background(), objs)
+	require.NoError(t, err)
+	require.Equal(t, map[object.ObjMetadata]status.Status{
+		objs[0]: status.NotFoundStatus,
+		objs[1]: status.NotFoundStatus,
+	}, statuses)
+}
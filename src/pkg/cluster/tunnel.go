@@ -177,6 +177,20 @@ func (c *Cluster) ConnectToZarfRegistryEndpoint(ctx context.Context, registryInf
 	return registryEndpoint, tunnel, nil
 }
 
+// InClusterRegistryAddress returns the in-cluster service address for a registry that is exposed on a localhost
+// NodePort. Addresses that do not match a NodePort service are returned unchanged and false is returned.
+func (c *Cluster) InClusterRegistryAddress(ctx context.Context, address string) (string, bool, error) {
+	serviceList, err := c.Clientset.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", false, err
+	}
+	svc, port, err := serviceInfoFromNodePortURL(serviceList.Items, address)
+	if err != nil {
+		return address, false, nil
+	}
+	return fmt.Sprintf("%s.%s.svc.cluster.local:%d", svc.Name, svc.Namespace, port), true, nil
+}
+
 // checkForZarfConnectLabel looks in the cluster for a connect name that matches the target
 func (c *Cluster) checkForZarfConnectLabel(ctx context.Context, name string) (TunnelInfo, error) {
 	var err error
@@ -311,7 +325,8 @@ type Tunnel struct {
 	attempt      int
 	stopChan     chan struct{}
 	readyChan    chan struct{}
-	errChan      chan error
+	done         chan struct{}
+	err          error
 }
 
 // NewTunnel will create a new Tunnel struct.
@@ -335,18 +350,18 @@ func (c *Cluster) NewTunnel(namespace, resourceType, resourceName, urlSuffix str
 
 // Wrap takes a function that returns an error and wraps it to check for tunnel errors as well.
 func (tunnel *Tunnel) Wrap(function func() error) error {
-	var err error
-	funcErrChan := make(chan error)
+	// Buffered so that the function does not block forever when the tunnel ends first.
+	funcErrChan := make(chan error, 1)
 
 	go func() {
 		funcErrChan <- function()
 	}()
 
 	select {
-	case err = <-funcErrChan:
-		return err
-	case err = <-tunnel.ErrChan():
+	case err := <-funcErrChan:
 		return err
+	case <-tunnel.Done():
+		return tunnel.Err()
 	}
 }
 
@@ -392,9 +407,19 @@ func (tunnel *Tunnel) Endpoint() string {
 	return fmt.Sprintf("%s:%d", helpers.IPV4Localhost, tunnel.localPort)
 }
 
-// ErrChan returns the tunnel's error channel
-func (tunnel *Tunnel) ErrChan() chan error {
-	return tunnel.errChan
+// Done returns a channel that is closed when the tunnel ends, it can be waited on by any number of callers.
+func (tunnel *Tunnel) Done() <-chan struct{} {
+	return tunnel.done
+}
+
+// Err returns the error the tunnel ended with, or nil while the tunnel is open.
+func (tunnel *Tunnel) Err() error {
+	select {
+	case <-tunnel.done:
+		return tunnel.err
+	default:
+		return nil
+	}
 }
 
 // HTTPEndpoint returns the tunnel endpoint as a HTTP URL string.
@@ -495,8 +520,13 @@ func (tunnel *Tunnel) establish(ctx context.Context) (string, error) {
 		tunnel.localPort = localPort
 		url := tunnel.FullURL()
 
-		// Store the error channel to listen for errors
-		tunnel.errChan = errChan
+		// Broadcast the error that ends the tunnel to everyone waiting on it
+		done := make(chan struct{})
+		tunnel.done = done
+		go func() {
+			tunnel.err = <-errChan
+			close(done)
+		}()
 
 		message.Debugf("Creating port forwarding tunnel at %s", url)
 		return url, nil
@@ -5,6 +5,7 @@ package cluster
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -167,3 +168,63 @@ func TestServiceInfoFromNodePortURL(t *testing.T) {
 		})
 	}
 }
+
+func TestInClusterRegistryAddress(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := &Cluster{Clientset: fake.NewSimpleClientset()}
+	svc := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ZarfNamespaceName,
+			Name:      ZarfRegistryName,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeNodePort,
+			Ports: []corev1.ServicePort{{Port: 5000, NodePort: 31999}},
+		},
+	}
+	_, err := c.Clientset.CoreV1().Services(svc.Namespace).Create(ctx, &svc, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	address, ok, err := c.InClusterRegistryAddress(ctx, "127.0.0.1:31999")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "zarf-docker-registry.zarf.svc.cluster.local:5000", address)
+
+	address, ok, err = c.InClusterRegistryAddress(ctx, "registry.example.com")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, "registry.example.com", address)
+}
+
+func TestTunnelWrap(t *testing.T) {
+	t.Parallel()
+
+	tunnel := &Tunnel{done: make(chan struct{})}
+	err := tunnel.Wrap(func() error {
+		return errors.New("function error")
+	})
+	require.EqualError(t, err, "function error")
+
+	// Every concurrent caller is released with the error the tunnel ended with.
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	callers := 5
+	errs := make(chan error, callers)
+	for range callers {
+		go func() {
+			errs <- tunnel.Wrap(func() error {
+				<-release
+				return nil
+			})
+		}()
+	}
+	require.NoError(t, tunnel.Err())
+	tunnel.err = errors.New("lost connection")
+	close(tunnel.done)
+	for range callers {
+		require.EqualError(t, <-errs, "lost connection")
+	}
+	require.EqualError(t, tunnel.Err(), "lost connection")
+}
@@ -203,6 +203,16 @@ func (c *Cluster) RecordPackageDeployment(ctx context.Context, pkg v1alpha1.Zarf
 		ComponentWebhooks:  componentWebhooks,
 	}
 
+	return c.saveDeployedPackage(ctx, deployedPackage)
+}
+
+// RestoreDeployedPackage writes a deployed package record, such as one from a Zarf state backup, back to the cluster as is.
+func (c *Cluster) RestoreDeployedPackage(ctx context.Context, deployedPackage types.DeployedPackage) error {
+	_, err := c.saveDeployedPackage(ctx, &deployedPackage)
+	return err
+}
+
+func (c *Cluster) saveDeployedPackage(ctx context.Context, deployedPackage *types.DeployedPackage) (*types.DeployedPackage, error) {
 	packageData, err := json.Marshal(deployedPackage)
 	if err != nil {
 		return nil, err
@@ -215,11 +225,11 @@ func (c *Cluster) RecordPackageDeployment(ctx context.Context, pkg v1alpha1.Zarf
 			Kind:       "Secret",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      config.ZarfPackagePrefix + packageName,
+			Name:      config.ZarfPackagePrefix + deployedPackage.Name,
 			Namespace: ZarfNamespaceName,
 			Labels: map[string]string{
 				ZarfManagedByLabel:   "zarf",
-				ZarfPackageInfoLabel: packageName,
+				ZarfPackageInfoLabel: deployedPackage.Name,
 			},
 		},
 		Type: corev1.SecretTypeOpaque,
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package encryption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// AWSKMSScheme is the scheme of AWS KMS key URIs.
+const AWSKMSScheme = "awskms"
+
+type awsKMSClient interface {
+	Encrypt(ctx context.Context, params *kms.EncryptInput, optFns ...func(*kms.Options)) (*kms.EncryptOutput, error)
+	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+// NewAWSKMSProvider creates a key provider for an AWS KMS key URI of the form awskms://[endpoint]/<key>, where the key
+// is a key ID, an alias (alias/<name>) or a key or alias ARN. Credentials and the region are read from the default AWS
+// configuration chain; the region of an ARN takes precedence.
+func NewAWSKMSProvider(ctx context.Context, keyURI string) (KeyProvider, error) {
+	endpoint, keyID, err := parseAWSKMSKeyURI(keyURI)
+	if err != nil {
+		return nil, err
+	}
+	var opts []func(*awsconfig.LoadOptions) error
+	if arn := strings.Split(keyID, ":"); len(arn) > 3 && arn[0] == "arn" && arn[3] != "" {
+		opts = append(opts, awsconfig.WithRegion(arn[3]))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the AWS configuration: %w", err)
+	}
+	client := kms.NewFromConfig(cfg, func(o *kms.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String("https://" + endpoint)
+		}
+	})
+	return &awsKMSProvider{client: client, keyURI: keyURI, keyID: keyID}, nil
+}
+
+func parseAWSKMSKeyURI(keyURI string) (string, string, error) {
+	rest, ok := strings.CutPrefix(keyURI, AWSKMSScheme+"://")
+	if !ok {
+		return "", "", fmt.Errorf("%s is not an %s:// key URI", keyURI, AWSKMSScheme)
+	}
+	endpoint, keyID, _ := strings.Cut(rest, "/")
+	if keyID == "" {
+		return "", "", errors.New("the AWS KMS key URI must be of the form awskms://[endpoint]/<key-id|alias/name|arn>")
+	}
+	return endpoint, keyID, nil
+}
+
+type awsKMSProvider struct {
+	client awsKMSClient
+	keyURI string
+	keyID  string
+}
+
+func (p *awsKMSProvider) Name() string {
+	return AWSKMSScheme
+}
+
+func (p *awsKMSProvider) KeyID() string {
+	return p.keyURI
+}
+
+func (p *awsKMSProvider) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	out, err := p.client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(p.keyID),
+		Plaintext: dataKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.CiphertextBlob, nil
+}
+
+func (p *awsKMSProvider) UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	out, err := p.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(p.keyID),
+		CiphertextBlob: wrappedKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Plaintext, nil
+}
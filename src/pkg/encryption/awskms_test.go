@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAWSKMS struct {
+	keyID string
+}
+
+func (f fakeAWSKMS) Encrypt(_ context.Context, params *kms.EncryptInput, _ ...func(*kms.Options)) (*kms.EncryptOutput, error) {
+	if aws.ToString(params.KeyId) != f.keyID {
+		return nil, errors.New("unknown key")
+	}
+	return &kms.EncryptOutput{CiphertextBlob: append([]byte(f.keyID+":"), params.Plaintext...)}, nil
+}
+
+func (f fakeAWSKMS) Decrypt(_ context.Context, params *kms.DecryptInput, _ ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+	plaintext, ok := bytes.CutPrefix(params.CiphertextBlob, []byte(aws.ToString(params.KeyId)+":"))
+	if !ok {
+		return nil, errors.New("unable to decrypt with this key")
+	}
+	return &kms.DecryptOutput{Plaintext: plaintext}, nil
+}
+
+func TestParseAWSKMSKeyURI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		keyURI           string
+		expectedEndpoint string
+		expectedKeyID    string
+		expectedErr      string
+	}{
+		{
+			name:          "alias",
+			keyURI:        "awskms:///alias/zarf",
+			expectedKeyID: "alias/zarf",
+		},
+		{
+			name:          "arn",
+			keyURI:        "awskms:///arn:aws:kms:us-east-1:111122223333:key/1234abcd",
+			expectedKeyID: "arn:aws:kms:us-east-1:111122223333:key/1234abcd",
+		},
+		{
+			name:             "endpoint",
+			keyURI:           "awskms://localhost:4566/1234abcd",
+			expectedEndpoint: "localhost:4566",
+			expectedKeyID:    "1234abcd",
+		},
+		{
+			name:        "missing key",
+			keyURI:      "awskms://alias",
+			expectedErr: "the AWS KMS key URI must be of the form",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint, keyID, err := parseAWSKMSKeyURI(tt.keyURI)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedEndpoint, endpoint)
+			require.Equal(t, tt.expectedKeyID, keyID)
+		})
+	}
+}
+
+func TestAWSKMSProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider := &awsKMSProvider{client: fakeAWSKMS{keyID: "alias/zarf"}, keyURI: "awskms:///alias/zarf", keyID: "alias/zarf"}
+	envelope, dataKey, err := NewEnvelope(ctx, provider)
+	require.NoError(t, err)
+	require.Equal(t, AWSKMSScheme, envelope.Provider)
+	require.Equal(t, "awskms:///alias/zarf", envelope.KeyID)
+
+	unwrapped, err := envelope.DataKey(ctx, provider)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, unwrapped)
+
+	other := &awsKMSProvider{client: fakeAWSKMS{keyID: "alias/other"}, keyURI: "awskms:///alias/other", keyID: "alias/other"}
+	_, err = envelope.DataKey(ctx, other)
+	require.ErrorContains(t, err, "unable to unwrap the data key with awskms:///alias/other")
+}
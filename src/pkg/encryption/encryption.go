@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package encryption provides envelope encryption of values with data keys wrapped by an age key or an external KMS.
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"filippo.io/age"
+)
+
+const (
+	// AgeProviderName is the name of the built-in age key provider.
+	AgeProviderName = "age"
+
+	encryptedPrefix = "ENC[AES256_GCM,"
+	encryptedSuffix = "]"
+	dataKeySize     = 32
+)
+
+// KeyProvider wraps and unwraps the data keys that encrypt values.
+type KeyProvider interface {
+	// Name returns the name of the provider recorded in the envelope, e.g. "age" or a KMS scheme.
+	Name() string
+	// KeyID returns a non-secret identifier of the key, such as an age recipient or a KMS key URI.
+	KeyID() string
+	// WrapKey encrypts a data key.
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key wrapped by WrapKey.
+	UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error)
+}
+
+// KMSFactory creates a key provider for a KMS key URI.
+type KMSFactory func(ctx context.Context, keyURI string) (KeyProvider, error)
+
+var (
+	kmsMu        sync.RWMutex
+	kmsFactories = map[string]KMSFactory{}
+)
+
+// RegisterKMS registers a KMS key provider for key URIs with the given scheme, e.g. "awskms" for "awskms:///alias/zarf".
+func RegisterKMS(scheme string, factory KMSFactory) {
+	kmsMu.Lock()
+	defer kmsMu.Unlock()
+	kmsFactories[scheme] = factory
+}
+
+// ResolveKey returns the key reference with the contents of an age identity file in place of its path.
+func ResolveKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", errors.New("no encryption key was provided")
+	}
+	if IsKMSKey(key) || strings.HasPrefix(key, "AGE-SECRET-KEY-") {
+		return key, nil
+	}
+	b, err := os.ReadFile(key)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the age identity file: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// NewKeyProvider creates the key provider for a key reference. A reference is either a URI with a registered KMS
+// scheme, an age identity (AGE-SECRET-KEY-1...) or the path to an age identity file.
+func NewKeyProvider(ctx context.Context, key string) (KeyProvider, error) {
+	key, err := ResolveKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !IsKMSKey(key) {
+		return NewAgeProvider(key)
+	}
+	scheme, _, _ := strings.Cut(key, "://")
+	kmsMu.RLock()
+	factory, ok := kmsFactories[scheme]
+	kmsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no KMS is registered for %s:// key URIs", scheme)
+	}
+	return factory(ctx, key)
+}
+
+// IsKMSKey returns true if the key reference is the URI of a KMS key rather than key material.
+func IsKMSKey(key string) bool {
+	return strings.Contains(key, "://")
+}
+
+// NewAgeProvider creates a key provider from an age X25519 identity, ignoring comments and blank lines.
+func NewAgeProvider(identity string) (KeyProvider, error) {
+	identities, err := age.ParseIdentities(strings.NewReader(identity))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the age identity: %w", err)
+	}
+	if len(identities) != 1 {
+		return nil, fmt.Errorf("expected a single age identity, found %d", len(identities))
+	}
+	x25519, ok := identities[0].(*age.X25519Identity)
+	if !ok {
+		return nil, errors.New("only X25519 age identities are supported")
+	}
+	return &ageProvider{identity: x25519}, nil
+}
+
+type ageProvider struct {
+	identity *age.X25519Identity
+}
+
+func (p *ageProvider) Name() string {
+	return AgeProviderName
+}
+
+func (p *ageProvider) KeyID() string {
+	return p.identity.Recipient().String()
+}
+
+func (p *ageProvider) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, p.identity.Recipient())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(dataKey); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (p *ageProvider) UnwrapKey(_ context.Context, wrappedKey []byte) ([]byte, error) {
+	r, err := age.Decrypt(bytes.NewReader(wrappedKey), p.identity)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+// Envelope records how the data key of encrypted values was wrapped.
+type Envelope struct {
+	Provider   string `json:"provider"`
+	KeyID      string `json:"keyID"`
+	WrappedKey []byte `json:"wrappedKey"`
+}
+
+// NewEnvelope generates a data key and wraps it with the key provider.
+func NewEnvelope(ctx context.Context, provider KeyProvider) (Envelope, []byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return Envelope{}, nil, fmt.Errorf("unable to generate a data key: %w", err)
+	}
+	wrappedKey, err := provider.WrapKey(ctx, dataKey)
+	if err != nil {
+		return Envelope{}, nil, fmt.Errorf("unable to wrap the data key: %w", err)
+	}
+	envelope := Envelope{
+		Provider:   provider.Name(),
+		KeyID:      provider.KeyID(),
+		WrappedKey: wrappedKey,
+	}
+	return envelope, dataKey, nil
+}
+
+// DataKey unwraps the data key of the envelope with the key provider.
+func (e Envelope) DataKey(ctx context.Context, provider KeyProvider) ([]byte, error) {
+	if e.Provider != provider.Name() {
+		return nil, fmt.Errorf("the data key was wrapped by %s but the provided key is for %s", e.Provider, provider.Name())
+	}
+	dataKey, err := provider.UnwrapKey(ctx, e.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unwrap the data key with %s: %w", provider.KeyID(), err)
+	}
+	if len(dataKey) != dataKeySize {
+		return nil, errors.New("the unwrapped data key has an invalid size")
+	}
+	return dataKey, nil
+}
+
+// IsEncrypted returns true if the value was encrypted by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix) && strings.HasSuffix(value, encryptedSuffix)
+}
+
+// Encrypt encrypts a value with AES-256-GCM under the data key. The name of the field holding the value is
+// authenticated as additional data so the value cannot be moved to another field. Empty values are left empty.
+func Encrypt(dataKey []byte, field, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), []byte(field))
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed) + encryptedSuffix, nil
+}
+
+// Decrypt decrypts a value encrypted by Encrypt for the same field. Values that are not encrypted are returned as is.
+func Decrypt(dataKey []byte, field, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(value, encryptedPrefix), encryptedSuffix))
+	if err != nil {
+		return "", fmt.Errorf("unable to decode the encrypted value: %w", err)
+	}
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("the encrypted value is too short")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], []byte(field))
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt the value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+func newGCM(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package encryption
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/require"
+)
+
+type testKMS struct{}
+
+func (testKMS) Name() string  { return "testkms" }
+func (testKMS) KeyID() string { return "testkms://key" }
+func (testKMS) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	return append([]byte{}, dataKey...), nil
+}
+func (testKMS) UnwrapKey(_ context.Context, wrappedKey []byte) ([]byte, error) {
+	return wrappedKey, nil
+}
+
+func TestNewKeyProvider(t *testing.T) {
+	t.Parallel()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	identityPath := filepath.Join(t.TempDir(), "zarf-state.key")
+	err = os.WriteFile(identityPath, []byte("# created: today\n"+identity.String()+"\n"), 0o600)
+	require.NoError(t, err)
+
+	RegisterKMS("testkms", func(_ context.Context, _ string) (KeyProvider, error) {
+		return testKMS{}, nil
+	})
+
+	tests := []struct {
+		name             string
+		key              string
+		expectedProvider string
+		expectedKeyID    string
+		expectedErr      string
+	}{
+		{
+			name:             "age identity",
+			key:              identity.String(),
+			expectedProvider: AgeProviderName,
+			expectedKeyID:    identity.Recipient().String(),
+		},
+		{
+			name:             "age identity file",
+			key:              identityPath,
+			expectedProvider: AgeProviderName,
+			expectedKeyID:    identity.Recipient().String(),
+		},
+		{
+			name:             "registered KMS",
+			key:              "testkms://key",
+			expectedProvider: "testkms",
+			expectedKeyID:    "testkms://key",
+		},
+		{
+			name:        "unregistered KMS",
+			key:         "gcpkms://projects/zarf/locations/global/keyRings/zarf/cryptoKeys/state",
+			expectedErr: "no KMS is registered for gcpkms:// key URIs",
+		},
+		{
+			name:        "missing identity file",
+			key:         filepath.Join(t.TempDir(), "missing.key"),
+			expectedErr: "unable to read the age identity file",
+		},
+		{
+			name:        "empty key",
+			expectedErr: "no encryption key was provided",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, err := NewKeyProvider(context.Background(), tt.key)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedProvider, provider.Name())
+			require.Equal(t, tt.expectedKeyID, provider.KeyID())
+		})
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	provider, err := NewAgeProvider(identity.String())
+	require.NoError(t, err)
+	otherIdentity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	otherProvider, err := NewAgeProvider(otherIdentity.String())
+	require.NoError(t, err)
+
+	envelope, dataKey, err := NewEnvelope(ctx, provider)
+	require.NoError(t, err)
+	require.Equal(t, AgeProviderName, envelope.Provider)
+	require.Equal(t, identity.Recipient().String(), envelope.KeyID)
+	require.NotContains(t, string(envelope.WrappedKey), string(dataKey))
+
+	unwrapped, err := envelope.DataKey(ctx, provider)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, unwrapped)
+
+	_, err = envelope.DataKey(ctx, otherProvider)
+	require.ErrorContains(t, err, "unable to unwrap the data key")
+
+	_, err = envelope.DataKey(ctx, testKMS{})
+	require.ErrorContains(t, err, "the data key was wrapped by age but the provided key is for testkms")
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	dataKey := make([]byte, dataKeySize)
+	otherKey := make([]byte, dataKeySize)
+	otherKey[0] = 1
+
+	encrypted, err := Encrypt(dataKey, "registryInfo.pushPassword", "registry-password")
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(encrypted))
+	require.NotContains(t, encrypted, "registry-password")
+
+	again, err := Encrypt(dataKey, "registryInfo.pushPassword", "registry-password")
+	require.NoError(t, err)
+	require.NotEqual(t, encrypted, again)
+
+	decrypted, err := Decrypt(dataKey, "registryInfo.pushPassword", encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "registry-password", decrypted)
+
+	_, err = Decrypt(otherKey, "registryInfo.pushPassword", encrypted)
+	require.ErrorContains(t, err, "unable to decrypt the value")
+
+	// A value moved to another field must not decrypt
+	_, err = Decrypt(dataKey, "registryInfo.pullPassword", encrypted)
+	require.ErrorContains(t, err, "unable to decrypt the value")
+
+	empty, err := Encrypt(dataKey, "registryInfo.pushPassword", "")
+	require.NoError(t, err)
+	require.Empty(t, empty)
+
+	plaintext, err := Decrypt(dataKey, "registryInfo.pushPassword", "not-encrypted")
+	require.NoError(t, err)
+	require.Equal(t, "not-encrypted", plaintext)
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// HashiVaultScheme is the scheme of HashiCorp Vault transit key URIs.
+const HashiVaultScheme = "hashivault"
+
+// NewHashiVaultProvider creates a key provider for a HashiCorp Vault transit key URI of the form hashivault://<key>.
+// The Vault address and token are read from VAULT_ADDR and VAULT_TOKEN and the transit engine is mounted at
+// TRANSIT_SECRET_ENGINE_PATH, which defaults to "transit".
+func NewHashiVaultProvider(_ context.Context, keyURI string) (KeyProvider, error) {
+	client, err := vault.NewClient(vault.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the Vault client: %w", err)
+	}
+	if client.Token() == "" {
+		return nil, errors.New("set VAULT_TOKEN to use a Vault transit key")
+	}
+	mountPath := os.Getenv("TRANSIT_SECRET_ENGINE_PATH")
+	if mountPath == "" {
+		mountPath = "transit"
+	}
+	return newHashiVaultProvider(client, mountPath, keyURI)
+}
+
+func newHashiVaultProvider(client *vault.Client, mountPath, keyURI string) (KeyProvider, error) {
+	keyName, ok := strings.CutPrefix(keyURI, HashiVaultScheme+"://")
+	if !ok || keyName == "" || strings.Contains(keyName, "/") {
+		return nil, errors.New("the Vault key URI must be of the form hashivault://<key>")
+	}
+	return &hashiVaultProvider{
+		logical:   client.Logical(),
+		mountPath: strings.Trim(mountPath, "/"),
+		keyURI:    keyURI,
+		keyName:   keyName,
+	}, nil
+}
+
+type hashiVaultProvider struct {
+	logical   *vault.Logical
+	mountPath string
+	keyURI    string
+	keyName   string
+}
+
+func (p *hashiVaultProvider) Name() string {
+	return HashiVaultScheme
+}
+
+func (p *hashiVaultProvider) KeyID() string {
+	return p.keyURI
+}
+
+func (p *hashiVaultProvider) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	path := fmt.Sprintf("%s/encrypt/%s", p.mountPath, p.keyName)
+	secret, err := p.logical.WriteWithContext(ctx, path, map[string]interface{}{
+		"plaintext": base64.StdEncoding.EncodeToString(dataKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := secretString(secret, "ciphertext")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(ciphertext), nil
+}
+
+func (p *hashiVaultProvider) UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	path := fmt.Sprintf("%s/decrypt/%s", p.mountPath, p.keyName)
+	secret, err := p.logical.WriteWithContext(ctx, path, map[string]interface{}{
+		"ciphertext": string(wrappedKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := secretString(secret, "plaintext")
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(plaintext)
+}
+
+func secretString(secret *vault.Secret, key string) (string, error) {
+	if secret == nil {
+		return "", errors.New("vault returned an empty response")
+	}
+	value, ok := secret.Data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("vault did not return a %s", key)
+	}
+	return value, nil
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package encryption
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	vault "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/require"
+)
+
+// transitServer emulates the encrypt and decrypt endpoints of a Vault transit engine mounted at transit.
+func transitServer(t *testing.T, keyName string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data := map[string]string{}
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/" + keyName:
+			data["ciphertext"] = "vault:v1:" + body["plaintext"]
+		case "/v1/transit/decrypt/" + keyName:
+			plaintext, ok := strings.CutPrefix(body["ciphertext"], "vault:v1:")
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			data["plaintext"] = plaintext
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		//nolint:errcheck // ignore
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+}
+
+func TestHashiVaultProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := transitServer(t, "zarf")
+	t.Cleanup(srv.Close)
+	client, err := vault.NewClient(&vault.Config{Address: srv.URL})
+	require.NoError(t, err)
+	client.SetToken("token")
+
+	_, err = newHashiVaultProvider(client, "transit", "hashivault://")
+	require.ErrorContains(t, err, "the Vault key URI must be of the form hashivault://<key>")
+
+	provider, err := newHashiVaultProvider(client, "transit", "hashivault://zarf")
+	require.NoError(t, err)
+	envelope, dataKey, err := NewEnvelope(ctx, provider)
+	require.NoError(t, err)
+	require.Equal(t, HashiVaultScheme, envelope.Provider)
+	require.Equal(t, "hashivault://zarf", envelope.KeyID)
+	require.True(t, strings.HasPrefix(string(envelope.WrappedKey), "vault:v1:"))
+
+	unwrapped, err := envelope.DataKey(ctx, provider)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, unwrapped)
+
+	other, err := newHashiVaultProvider(client, "transit", "hashivault://other")
+	require.NoError(t, err)
+	_, err = envelope.DataKey(ctx, other)
+	require.ErrorContains(t, err, "unable to unwrap the data key with hashivault://other")
+}
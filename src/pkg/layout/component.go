@@ -38,19 +38,18 @@ type Components struct {
 // ErrNotLoaded is returned when a path is not loaded.
 var ErrNotLoaded = fmt.Errorf("not loaded")
 
-// Archive archives a component.
-func (c *Components) Archive(component v1alpha1.ZarfComponent, cleanupTemp bool) (err error) {
-	name := component.Name
-	if _, ok := c.Dirs[name]; !ok {
+// Archive archives the component stored under key, see v1alpha1.ZarfPackage.ComponentKey.
+func (c *Components) Archive(key string, cleanupTemp bool) (err error) {
+	if _, ok := c.Dirs[key]; !ok {
 		return &fs.PathError{
 			Op:   "check dir map for",
-			Path: name,
+			Path: key,
 			Err:  ErrNotLoaded,
 		}
 	}
-	base := c.Dirs[name].Base
+	base := c.Dirs[key].Base
 	if cleanupTemp {
-		_ = os.RemoveAll(c.Dirs[name].Temp)
+		_ = os.RemoveAll(c.Dirs[key].Temp)
 	}
 	size, err := helpers.GetDirSize(base)
 	if err != nil {
@@ -58,30 +57,29 @@ func (c *Components) Archive(component v1alpha1.ZarfComponent, cleanupTemp bool)
 	}
 	if size > 0 {
 		tb := fmt.Sprintf("%s.tar", base)
-		message.Debugf("Archiving %q", name)
-		if err := helpers.CreateReproducibleTarballFromDir(base, name, tb); err != nil {
+		message.Debugf("Archiving %q", key)
+		if err := helpers.CreateReproducibleTarballFromDir(base, key, tb); err != nil {
 			return err
 		}
 		if c.Tarballs == nil {
 			c.Tarballs = make(map[string]string)
 		}
-		c.Tarballs[name] = tb
+		c.Tarballs[key] = tb
 	} else {
-		message.Debugf("Component %q is empty, skipping archiving", name)
+		message.Debugf("Component %q is empty, skipping archiving", key)
 	}
 
-	delete(c.Dirs, name)
+	delete(c.Dirs, key)
 	return os.RemoveAll(base)
 }
 
-// Unarchive unarchives a component.
-func (c *Components) Unarchive(component v1alpha1.ZarfComponent) (err error) {
-	name := component.Name
-	tb, ok := c.Tarballs[name]
+// Unarchive unarchives a component stored under key, see v1alpha1.ZarfPackage.ComponentKey.
+func (c *Components) Unarchive(key string, component v1alpha1.ZarfComponent) (err error) {
+	tb, ok := c.Tarballs[key]
 	if !ok {
 		return &fs.PathError{
 			Op:   "check tarball map for",
-			Path: name,
+			Path: key,
 			Err:  ErrNotLoaded,
 		}
 	}
@@ -95,7 +93,7 @@ func (c *Components) Unarchive(component v1alpha1.ZarfComponent) (err error) {
 	}
 
 	cs := &ComponentPaths{
-		Base: filepath.Join(c.Base, name),
+		Base: filepath.Join(c.Base, key),
 	}
 	if len(component.Files) > 0 {
 		cs.Files = filepath.Join(cs.Base, FilesDir)
@@ -121,12 +119,12 @@ func (c *Components) Unarchive(component v1alpha1.ZarfComponent) (err error) {
 	if c.Dirs == nil {
 		c.Dirs = make(map[string]*ComponentPaths)
 	}
-	c.Dirs[name] = cs
-	delete(c.Tarballs, name)
+	c.Dirs[key] = cs
+	delete(c.Tarballs, key)
 
 	// if the component is already unarchived, skip
 	if !helpers.InvalidPath(cs.Base) {
-		message.Debugf("Component %q already unarchived", name)
+		message.Debugf("Component %q already unarchived", key)
 		return nil
 	}
 
@@ -137,16 +135,15 @@ func (c *Components) Unarchive(component v1alpha1.ZarfComponent) (err error) {
 	return os.Remove(tb)
 }
 
-// Create creates a new component directory structure.
-func (c *Components) Create(component v1alpha1.ZarfComponent) (cp *ComponentPaths, err error) {
-	name := component.Name
+// Create creates a new component directory structure stored under key, see v1alpha1.ZarfPackage.ComponentKey.
+func (c *Components) Create(key string, component v1alpha1.ZarfComponent) (cp *ComponentPaths, err error) {
 
-	_, ok := c.Tarballs[name]
+	_, ok := c.Tarballs[key]
 	if ok {
 		return nil, &fs.PathError{
 			Op:   "create component paths",
-			Path: name,
-			Err:  fmt.Errorf("component tarball for %q exists, use Unarchive instead", name),
+			Path: key,
+			Err:  fmt.Errorf("component tarball for %q exists, use Unarchive instead", key),
 		}
 	}
 
@@ -154,7 +151,7 @@ func (c *Components) Create(component v1alpha1.ZarfComponent) (cp *ComponentPath
 		return nil, err
 	}
 
-	base := filepath.Join(c.Base, name)
+	base := filepath.Join(c.Base, key)
 
 	if err = helpers.CreateDirectory(base, helpers.ReadWriteExecuteUser); err != nil {
 		return nil, err
@@ -217,6 +214,6 @@ func (c *Components) Create(component v1alpha1.ZarfComponent) (cp *ComponentPath
 		c.Dirs = make(map[string]*ComponentPaths)
 	}
 
-	c.Dirs[name] = cp
+	c.Dirs[key] = cp
 	return cp, nil
 }
@@ -59,3 +59,37 @@ func (i *Images) AddV1Image(img v1.Image) error {
 
 	return nil
 }
+
+// AddV1ImageIndex adds a v1.ImageIndex and the images it contains to the Images struct.
+func (i *Images) AddV1ImageIndex(idx v1.ImageIndex) error {
+	idxManifest, err := idx.IndexManifest()
+	if err != nil {
+		return err
+	}
+	for _, desc := range idxManifest.Manifests {
+		if desc.MediaType.IsIndex() {
+			child, err := idx.ImageIndex(desc.Digest)
+			if err != nil {
+				return err
+			}
+			if err := i.AddV1ImageIndex(child); err != nil {
+				return err
+			}
+			continue
+		}
+		img, err := idx.Image(desc.Digest)
+		if err != nil {
+			return err
+		}
+		if err := i.AddV1Image(img); err != nil {
+			return err
+		}
+	}
+	idxSha, err := idx.Digest()
+	if err != nil {
+		return err
+	}
+	i.AddBlob(idxSha.Hex)
+
+	return nil
+}
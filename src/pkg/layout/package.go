@@ -147,7 +147,7 @@ func (pp *PackagePaths) MigrateLegacy() (err error) {
 	// Migration of paths within components occurs during `deploy`
 	// no other operation should need to know about legacy component paths
 	for _, component := range pkg.Components {
-		_, err := pp.Components.Create(component)
+		_, err := pp.Components.Create(pkg.ComponentKey(component), component)
 		if err != nil {
 			return err
 		}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
@@ -57,7 +58,15 @@ func lintComponents(ctx context.Context, pkg v1alpha1.ZarfPackage, createOpts ty
 	var findings []PackageFinding
 
 	for i, component := range pkg.Components {
-		arch := config.GetArch(pkg.Metadata.Architecture)
+		pkgArch := pkg.Metadata.Architecture
+		if pkgArch == "" && len(pkg.Metadata.Architectures) > 0 {
+			pkgArch = v1alpha1.ZarfPackageArchMulti
+		}
+		arch := config.GetArch(pkgArch)
+		// multi-architecture packages are linted with the components of every architecture they list
+		if arch == v1alpha1.ZarfPackageArchMulti && slices.Contains(pkg.Metadata.Architectures, component.Only.Cluster.Architecture) {
+			arch = component.Only.Cluster.Architecture
+		}
 		if !composer.CompatibleComponent(component, arch, createOpts.Flavor) {
 			continue
 		}
@@ -6,7 +6,9 @@ package message
 
 import (
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/pterm/pterm"
 	"github.com/zarf-dev/zarf/src/types"
@@ -22,12 +24,63 @@ const (
 	AgentKey        = "agent"
 )
 
-// PrintCredentialTable displays credentials in a table
-func PrintCredentialTable(state *types.ZarfState, componentsToDeploy []types.DeployedComponent) {
+// Credential contains the information used to access a Zarf managed service.
+type Credential struct {
+	Application string     `json:"application"`
+	Username    string     `json:"username"`
+	Password    string     `json:"password"`
+	Connect     string     `json:"connect"`
+	Key         string     `json:"key"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+// GetCredentials returns the credentials for the Zarf managed services used by the given components.
+func GetCredentials(state *types.ZarfState, componentsToDeploy []types.DeployedComponent) []Credential {
 	if len(componentsToDeploy) == 0 {
 		componentsToDeploy = []types.DeployedComponent{{Name: "git-server"}}
 	}
 
+	credentials := []Credential{}
+	if state.RegistryInfo.IsInternal() {
+		credentials = append(credentials, registryCredentials(state)...)
+	}
+
+	for _, component := range componentsToDeploy {
+		// Show message if including git-server
+		if component.Name == "git-server" {
+			credentials = append(credentials, gitCredentials(state)...)
+		}
+	}
+	return credentials
+}
+
+// GetComponentCredential returns the credential for a single get-creds key.
+func GetComponentCredential(state *types.ZarfState, key string) (Credential, error) {
+	for _, credential := range append(registryCredentials(state), gitCredentials(state)...) {
+		if credential.Key == strings.ToLower(key) {
+			return credential, nil
+		}
+	}
+	return Credential{}, fmt.Errorf("unknown component: %s", key)
+}
+
+func registryCredentials(state *types.ZarfState) []Credential {
+	return []Credential{
+		{"Registry", state.RegistryInfo.PushUsername, state.RegistryInfo.PushPassword, "zarf connect registry", RegistryKey, state.CredentialExpirations.Registry},
+		{"Registry (read-only)", state.RegistryInfo.PullUsername, state.RegistryInfo.PullPassword, "zarf connect registry", RegistryReadKey, state.CredentialExpirations.Registry},
+	}
+}
+
+func gitCredentials(state *types.ZarfState) []Credential {
+	return []Credential{
+		{"Git", state.GitServer.PushUsername, state.GitServer.PushPassword, "zarf connect git", GitKey, state.CredentialExpirations.Git},
+		{"Git (read-only)", state.GitServer.PullUsername, state.GitServer.PullPassword, "zarf connect git", GitReadKey, state.CredentialExpirations.Git},
+		{"Artifact Token", state.ArtifactServer.PushUsername, state.ArtifactServer.PushToken, "zarf connect git", ArtifactKey, nil},
+	}
+}
+
+// PrintCredentialTable displays credentials in a table
+func PrintCredentialTable(state *types.ZarfState, componentsToDeploy []types.DeployedComponent) {
 	// Pause the logfile's output to avoid credentials being printed to the log file
 	if logFile != nil {
 		logFile.Pause()
@@ -35,30 +88,30 @@ func PrintCredentialTable(state *types.ZarfState, componentsToDeploy []types.Dep
 	}
 
 	loginData := [][]string{}
-	if state.RegistryInfo.IsInternal() {
-		loginData = append(loginData,
-			[]string{"Registry", state.RegistryInfo.PushUsername, state.RegistryInfo.PushPassword, "zarf connect registry", RegistryKey},
-			[]string{"Registry (read-only)", state.RegistryInfo.PullUsername, state.RegistryInfo.PullPassword, "zarf connect registry", RegistryReadKey},
-		)
-	}
-
-	for _, component := range componentsToDeploy {
-		// Show message if including git-server
-		if component.Name == "git-server" {
-			loginData = append(loginData,
-				[]string{"Git", state.GitServer.PushUsername, state.GitServer.PushPassword, "zarf connect git", GitKey},
-				[]string{"Git (read-only)", state.GitServer.PullUsername, state.GitServer.PullPassword, "zarf connect git", GitReadKey},
-				[]string{"Artifact Token", state.ArtifactServer.PushUsername, state.ArtifactServer.PushToken, "zarf connect git", ArtifactKey},
-			)
+	for _, credential := range GetCredentials(state, componentsToDeploy) {
+		expires := "-"
+		if credential.ExpiresAt != nil {
+			expires = credential.ExpiresAt.Format(time.DateOnly)
 		}
+		loginData = append(loginData, []string{credential.Application, credential.Username, credential.Password, credential.Connect, credential.Key, expires})
 	}
 
 	if len(loginData) > 0 {
-		header := []string{"Application", "Username", "Password", "Connect", "Get-Creds Key"}
+		header := []string{"Application", "Username", "Password", "Connect", "Get-Creds Key", "Expires"}
 		Table(header, loginData)
 	}
 }
 
+// PrintCredentialsStructured writes credentials in a structured output format without recording them in the log file.
+func PrintCredentialsStructured(w io.Writer, format OutputFormat, credentials any) error {
+	// Pause the logfile's output to avoid credentials being printed to the log file
+	if logFile != nil {
+		logFile.Pause()
+		defer logFile.Resume()
+	}
+	return PrintStructured(w, format, credentials)
+}
+
 // PrintComponentCredential displays credentials for a single component
 func PrintComponentCredential(state *types.ZarfState, componentName string) {
 	switch strings.ToLower(componentName) {
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package message provides a rich set of functions for displaying messages to the user.
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"sigs.k8s.io/yaml"
+)
+
+// OutputFormat is the format used to print the results of a command.
+type OutputFormat string
+
+// The supported output formats for commands with machine-readable results.
+const (
+	OutputTable OutputFormat = "table"
+	OutputJSON  OutputFormat = "json"
+	OutputYAML  OutputFormat = "yaml"
+)
+
+var outputFormats = []OutputFormat{OutputTable, OutputJSON, OutputYAML}
+
+// String returns the output format as a string.
+func (o *OutputFormat) String() string {
+	return string(*o)
+}
+
+// Set validates and sets the output format from a flag value.
+func (o *OutputFormat) Set(value string) error {
+	if !slices.Contains(outputFormats, OutputFormat(value)) {
+		return fmt.Errorf("invalid output format %q, must be one of %v", value, outputFormats)
+	}
+	*o = OutputFormat(value)
+	return nil
+}
+
+// Type returns the type name shown in flag usage.
+func (o *OutputFormat) Type() string {
+	return "string"
+}
+
+// PrintStructured writes data to w as JSON or YAML. YAML is derived from the JSON encoding so both formats share the
+// same field names.
+func PrintStructured(w io.Writer, format OutputFormat, data any) error {
+	switch format {
+	case OutputJSON:
+		b, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case OutputYAML:
+		b, err := yaml.Marshal(data)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(w, string(b))
+		return err
+	default:
+		return fmt.Errorf("unsupported structured output format %q", format)
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package message
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestOutputFormatSet(t *testing.T) {
+	t.Parallel()
+
+	format := OutputTable
+	require.NoError(t, format.Set("json"))
+	require.Equal(t, OutputJSON, format)
+	require.EqualError(t, format.Set("xml"), `invalid output format "xml", must be one of [table json yaml]`)
+	require.Equal(t, OutputJSON, format)
+}
+
+func TestPrintStructured(t *testing.T) {
+	t.Parallel()
+
+	state := &types.ZarfState{
+		GitServer: types.GitServerInfo{PushUsername: "zarf-git-user", PushPassword: "secret"},
+	}
+	credential, err := GetComponentCredential(state, "GIT")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		format   OutputFormat
+		expected string
+	}{
+		{
+			name:   "json",
+			format: OutputJSON,
+			expected: `{
+  "application": "Git",
+  "username": "zarf-git-user",
+  "password": "secret",
+  "connect": "zarf connect git",
+  "key": "git"
+}
+`,
+		},
+		{
+			name:   "yaml",
+			format: OutputYAML,
+			expected: `application: Git
+connect: zarf connect git
+key: git
+password: secret
+username: zarf-git-user
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			err := PrintStructured(&buf, tt.format, credential)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, buf.String())
+		})
+	}
+
+	err = PrintStructured(&bytes.Buffer{}, OutputTable, credential)
+	require.EqualError(t, err, `unsupported structured output format "table"`)
+
+	_, err = GetComponentCredential(state, "unknown")
+	require.EqualError(t, err, "unknown component: unknown")
+}
+
+func TestPrintCredentialsStructured(t *testing.T) {
+	// Replaces the package log file so it cannot run in parallel
+	var logBuf bytes.Buffer
+	previousLogFile := logFile
+	logFile = NewPausableWriter(&logBuf)
+	t.Cleanup(func() { logFile = previousLogFile })
+
+	state := &types.ZarfState{
+		GitServer: types.GitServerInfo{PushUsername: "zarf-git-user", PushPassword: "secret"},
+	}
+	credential, err := GetComponentCredential(state, "git")
+	require.NoError(t, err)
+
+	// Output that is mirrored to the log file is dropped from it while credentials are written
+	var out bytes.Buffer
+	err = PrintCredentialsStructured(io.MultiWriter(&out, logFile), OutputJSON, credential)
+	require.NoError(t, err)
+	require.Contains(t, out.String(), `"password": "secret"`)
+	require.Empty(t, logBuf.String())
+
+	_, err = logFile.Write([]byte("resumed"))
+	require.NoError(t, err)
+	require.Equal(t, "resumed", logBuf.String())
+}
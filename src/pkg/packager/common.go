@@ -15,6 +15,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/packager/template"
@@ -38,6 +39,7 @@ type Packager struct {
 	hpaModified    bool
 	connectStrings types.ConnectStrings
 	source         sources.PackageSource
+	confirm        bool
 }
 
 // Modifier is a function that modifies the packager.
@@ -57,6 +59,13 @@ func WithCluster(cluster *cluster.Cluster) Modifier {
 	}
 }
 
+// WithConfirm confirms the actions of the packager without prompting, regardless of the --confirm flag.
+func WithConfirm() Modifier {
+	return func(p *Packager) {
+		p.confirm = true
+	}
+}
+
 // WithTemp sets the temp directory for the packager.
 //
 // This temp directory is used as the destination where p.source loads the package.
@@ -79,12 +88,11 @@ func New(cfg *types.PackagerConfig, mods ...Modifier) (*Packager, error) {
 	var (
 		err  error
 		pkgr = &Packager{
-			cfg: cfg,
+			cfg:     cfg,
+			confirm: config.CommonOptions.Confirm,
 		}
 	)
 
-	pkgr.variableConfig = template.GetZarfVariableConfig()
-
 	if config.CommonOptions.TempDirectory != "" {
 		// If the cache directory is within the temp directory, warn the user
 		if strings.HasPrefix(config.CommonOptions.CachePath, config.CommonOptions.TempDirectory) {
@@ -96,6 +104,8 @@ func New(cfg *types.PackagerConfig, mods ...Modifier) (*Packager, error) {
 		mod(pkgr)
 	}
 
+	pkgr.variableConfig = template.NewZarfVariableConfig(pkgr.confirm)
+
 	// Fill the source if it wasn't provided - note source can be nil if the package is being created
 	if pkgr.source == nil && pkgr.cfg.CreateOpts.BaseDir == "" {
 		pkgr.source, err = sources.New(&pkgr.cfg.PkgOpts)
@@ -184,29 +194,51 @@ func (p *Packager) validatePackageArchitecture(ctx context.Context) error {
 		return nil
 	}
 
-	// Get node architectures
+	architectures, err := p.nodeArchitectures(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Check if one of the package architectures is an architecture of the cluster.
+	pkgArchs := p.cfg.Pkg.SupportedArchitectures()
+	if !slices.ContainsFunc(pkgArchs, func(arch string) bool { return slices.Contains(architectures, arch) }) {
+		return fmt.Errorf(lang.CmdPackageDeployValidateArchitectureErr, strings.Join(pkgArchs, ", "), strings.Join(architectures, ", "))
+	}
+
+	return nil
+}
+
+// nodeArchitectures returns the architectures of the nodes of the cluster.
+func (p *Packager) nodeArchitectures(ctx context.Context) ([]string, error) {
 	nodeList, err := p.cluster.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
 	if err != nil {
-		return lang.ErrUnableToCheckArch
+		return nil, lang.ErrUnableToCheckArch
 	}
 	if len(nodeList.Items) == 0 {
-		return lang.ErrUnableToCheckArch
-	}
-	archMap := map[string]bool{}
-	for _, node := range nodeList.Items {
-		archMap[node.Status.NodeInfo.Architecture] = true
+		return nil, lang.ErrUnableToCheckArch
 	}
 	architectures := []string{}
-	for arch := range archMap {
-		architectures = append(architectures, arch)
+	for _, node := range nodeList.Items {
+		if !slices.Contains(architectures, node.Status.NodeInfo.Architecture) {
+			architectures = append(architectures, node.Status.NodeInfo.Architecture)
+		}
 	}
+	slices.Sort(architectures)
+	return architectures, nil
+}
 
-	// Check if the package architecture and the cluster architecture are the same.
-	if !slices.Contains(architectures, p.cfg.Pkg.Metadata.Architecture) {
-		return fmt.Errorf(lang.CmdPackageDeployValidateArchitectureErr, p.cfg.Pkg.Metadata.Architecture, strings.Join(architectures, ", "))
+// clusterArchitectures returns the architectures the components of a multi-architecture package are deployed for,
+// which are the architectures of the cluster nodes unless --architecture is set to one of them.
+func (p *Packager) clusterArchitectures(ctx context.Context) ([]string, error) {
+	if config.CLIArch != "" && config.CLIArch != v1alpha1.ZarfPackageArchMulti {
+		return []string{config.CLIArch}, nil
 	}
-
-	return nil
+	connectCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	if err := p.connectToCluster(connectCtx); err != nil {
+		return nil, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+	}
+	return p.nodeArchitectures(ctx)
 }
 
 // validateLastNonBreakingVersion validates the Zarf CLI version against a package's LastNonBreakingVersion.
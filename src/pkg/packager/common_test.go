@@ -14,8 +14,10 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/types"
 )
 
@@ -25,6 +27,7 @@ func TestValidatePackageArchitecture(t *testing.T) {
 	tests := []struct {
 		name         string
 		pkgArch      string
+		pkgArchs     []string
 		clusterArchs []string
 		images       []string
 		wantErr      error
@@ -56,6 +59,22 @@ func TestValidatePackageArchitecture(t *testing.T) {
 			clusterArchs: []string{"not evaluated"},
 			wantErr:      nil,
 		},
+		{
+			name:         "multi-architecture package with the cluster architecture",
+			pkgArch:      "multi",
+			pkgArchs:     []string{"amd64", "arm64"},
+			clusterArchs: []string{"arm64"},
+			images:       []string{"nginx"},
+			wantErr:      nil,
+		},
+		{
+			name:         "multi-architecture package without the cluster architecture",
+			pkgArch:      "multi",
+			pkgArchs:     []string{"amd64", "arm64"},
+			clusterArchs: []string{"s390x"},
+			images:       []string{"nginx"},
+			wantErr:      fmt.Errorf(lang.CmdPackageDeployValidateArchitectureErr, "amd64, arm64", "s390x"),
+		},
 		{
 			name:         "ignore validation when a package doesn't contain images",
 			pkgArch:      "amd64",
@@ -85,7 +104,7 @@ func TestValidatePackageArchitecture(t *testing.T) {
 				},
 				cfg: &types.PackagerConfig{
 					Pkg: v1alpha1.ZarfPackage{
-						Metadata: v1alpha1.ZarfMetadata{Architecture: tt.pkgArch},
+						Metadata: v1alpha1.ZarfMetadata{Architecture: tt.pkgArch, Architectures: tt.pkgArchs},
 						Components: []v1alpha1.ZarfComponent{
 							{
 								Images: tt.images,
@@ -183,3 +202,22 @@ func TestValidateLastNonBreakingVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestWithConfirm(t *testing.T) {
+	t.Parallel()
+
+	p, err := New(&types.PackagerConfig{}, WithSource(&sources.TarballSource{}), WithTemp(t.TempDir()), WithConfirm())
+	require.NoError(t, err)
+	require.True(t, p.confirm)
+	require.True(t, p.confirmAction(config.ZarfDeployStage, nil, nil))
+
+	// Prompted variables take their default instead of prompting
+	variables := []v1alpha1.InteractiveVariable{
+		{Variable: v1alpha1.Variable{Name: "DOMAIN"}, Default: "example.com", Prompt: true},
+	}
+	err = p.variableConfig.PopulateVariables(variables, nil)
+	require.NoError(t, err)
+	domain, ok := p.variableConfig.GetSetVariable("DOMAIN")
+	require.True(t, ok)
+	require.Equal(t, "example.com", domain.Value)
+}
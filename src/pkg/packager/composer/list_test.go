@@ -96,6 +96,15 @@ func TestCompose(t *testing.T) {
 					{Source: fmt.Sprintf("%s%sworld.txt", firstDirectory, string(os.PathSeparator))},
 					{Source: "hello.txt"},
 				},
+				// Images from image layouts should be appended with corrected directories
+				Images: []string{
+					fmt.Sprintf("oci-layout://%s%stoday:1.0.0", finalDirectory, string(os.PathSeparator)),
+					"ghcr.io/example/today:1.0.0",
+					fmt.Sprintf("oci-layout://%s%sworld:1.0.0", firstDirectory, string(os.PathSeparator)),
+					"ghcr.io/example/world:1.0.0",
+					"oci-layout://hello:1.0.0",
+					"ghcr.io/example/hello:1.0.0",
+				},
 				// Charts should be merged if names match and appended if not with corrected directories
 				Charts: []v1alpha1.ZarfChart{
 					{
@@ -453,6 +462,10 @@ func createDummyComponent(t *testing.T, name, importDir, subName string) v1alpha
 				Source: fmt.Sprintf("%s.txt", name),
 			},
 		},
+		Images: []string{
+			fmt.Sprintf("oci-layout://%s:1.0.0", name),
+			fmt.Sprintf("ghcr.io/example/%s:1.0.0", name),
+		},
 		Charts: []v1alpha1.ZarfChart{
 			{
 				Name:      subName,
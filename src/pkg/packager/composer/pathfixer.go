@@ -9,6 +9,7 @@ import (
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
 )
 
 func makePathRelativeTo(path, relativeTo string) string {
@@ -19,6 +20,20 @@ func makePathRelativeTo(path, relativeTo string) string {
 	return filepath.Join(relativeTo, path)
 }
 
+// makeLayoutImageRelativeTo rebases the relative image layout directory of an oci-layout:// image reference, any other
+// image is returned as is.
+func makeLayoutImageRelativeTo(image, relativeTo string) string {
+	refInfo, err := transform.ParseImageRef(image)
+	if err != nil || refInfo.LayoutPath == "" || filepath.IsAbs(refInfo.LayoutPath) {
+		return image
+	}
+	composed, err := transform.SetOCILayoutPath(image, makePathRelativeTo(refInfo.LayoutPath, relativeTo))
+	if err != nil {
+		return image
+	}
+	return composed
+}
+
 func fixPaths(child *v1alpha1.ZarfComponent, relativeToHead string) {
 	for fileIdx, file := range child.Files {
 		composed := makePathRelativeTo(file.Source, relativeToHead)
@@ -52,6 +67,10 @@ func fixPaths(child *v1alpha1.ZarfComponent, relativeToHead string) {
 		}
 	}
 
+	for imageIdx, image := range child.Images {
+		child.Images[imageIdx] = makeLayoutImageRelativeTo(image, relativeToHead)
+	}
+
 	for dataInjectionsIdx, dataInjection := range child.DataInjections {
 		composed := makePathRelativeTo(dataInjection.Source, relativeToHead)
 		child.DataInjections[dataInjectionsIdx].Source = composed
@@ -45,7 +45,7 @@ func (p *Packager) Create(ctx context.Context) error {
 		return fmt.Errorf("package creation canceled")
 	}
 
-	if err := pc.Assemble(ctx, p.layout, p.cfg.Pkg.Components, p.cfg.Pkg.Metadata.Architecture); err != nil {
+	if err := pc.Assemble(ctx, p.layout, p.cfg.Pkg); err != nil {
 		return err
 	}
 
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestCreateDeployMultiArchComponentVariants(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	lint.ZarfSchema = testutil.LoadSchema(t, "../../../zarf.schema.json")
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.Chdir(cwd)
+	})
+	baseDir := t.TempDir()
+	outputDir := t.TempDir()
+	deployDir := t.TempDir()
+
+	// Both variants share a name and a file target so only their architecture tells them apart
+	components := []v1alpha1.ZarfComponent{}
+	for _, arch := range []string{"amd64", "arm64"} {
+		err = os.WriteFile(filepath.Join(baseDir, arch+".txt"), []byte("built for "+arch), helpers.ReadWriteUser)
+		require.NoError(t, err)
+		components = append(components, v1alpha1.ZarfComponent{
+			Name:     "binary",
+			Required: helpers.BoolPtr(true),
+			Only:     v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Architecture: arch}},
+			Files: []v1alpha1.ZarfFile{{
+				Source: arch + ".txt",
+				Target: filepath.Join(deployDir, "binary.txt"),
+			}},
+		})
+	}
+	pkg := v1alpha1.ZarfPackage{
+		Kind: v1alpha1.ZarfPackageConfig,
+		Metadata: v1alpha1.ZarfMetadata{
+			Name:          "variants",
+			Architecture:  v1alpha1.ZarfPackageArchMulti,
+			Architectures: []string{"amd64", "arm64"},
+		},
+		Components: components,
+	}
+	err = utils.WriteYaml(filepath.Join(baseDir, "zarf.yaml"), pkg, helpers.ReadWriteUser)
+	require.NoError(t, err)
+
+	config.CommonOptions.Confirm = true
+	t.Cleanup(func() {
+		config.CommonOptions.Confirm = false
+		config.CLIArch = ""
+	})
+
+	createCfg := &types.PackagerConfig{
+		CreateOpts: types.ZarfCreateOptions{BaseDir: baseDir, Output: outputDir, SkipSBOM: true},
+	}
+	p, err := New(createCfg)
+	require.NoError(t, err)
+	defer p.ClearTempPaths()
+	err = p.Create(ctx)
+	require.NoError(t, err)
+	tarballs, err := filepath.Glob(filepath.Join(outputDir, "zarf-package-variants-*.tar.zst"))
+	require.NoError(t, err)
+	require.Len(t, tarballs, 1)
+
+	// Each variant is deployed from its own tarball
+	for _, arch := range []string{"amd64", "arm64"} {
+		config.CLIArch = arch
+		deployCfg := &types.PackagerConfig{
+			PkgOpts: types.ZarfPackageOptions{PackageSource: tarballs[0]},
+		}
+		p, err := New(deployCfg)
+		require.NoError(t, err)
+		defer p.ClearTempPaths()
+		err = p.Deploy(ctx)
+		require.NoError(t, err)
+		require.Len(t, p.cfg.Pkg.Components, 1)
+		require.Equal(t, arch, p.cfg.Pkg.Components[0].Only.Cluster.Architecture)
+		b, err := os.ReadFile(filepath.Join(deployDir, "binary.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "built for "+arch, strings.TrimSpace(string(b)))
+	}
+}
@@ -6,6 +6,9 @@ package creator
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"slices"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/packager/composer"
@@ -22,35 +25,65 @@ func ComposeComponents(ctx context.Context, pkg v1alpha1.ZarfPackage, flavor str
 	arch := pkg.Metadata.Architecture
 
 	for i, component := range pkg.Components {
+		// multi-architecture packages keep the components of every architecture they list, which are composed for
+		// their own architecture
+		componentArch := arch
+		if pkg.IsMultiArch() && component.Only.Cluster.Architecture != "" {
+			if !slices.Contains(pkg.Metadata.Architectures, component.Only.Cluster.Architecture) {
+				continue
+			}
+			componentArch = component.Only.Cluster.Architecture
+		}
+
 		// filter by architecture and flavor
-		if !composer.CompatibleComponent(component, arch, flavor) {
+		if !composer.CompatibleComponent(component, componentArch, flavor) {
 			continue
 		}
 
-		// if a match was found, strip flavor and architecture to reduce bloat in the package definition
-		component.Only.Cluster.Architecture = ""
+		// if a match was found, strip flavor and architecture to reduce bloat in the package definition,
+		// multi-architecture packages keep the architecture so the component is filtered on deploy
+		if !pkg.IsMultiArch() {
+			component.Only.Cluster.Architecture = ""
+		}
 		component.Only.Flavor = ""
 
-		// build the import chain
-		chain, err := composer.NewImportChain(ctx, component, i, pkg.Metadata.Name, arch, flavor)
-		if err != nil {
-			return v1alpha1.ZarfPackage{}, nil, err
+		// arch-agnostic components of multi-architecture packages that import a component are composed for each
+		// architecture, as the imported component can be limited to one
+		composeArchs := []string{componentArch}
+		if pkg.IsMultiArch() && component.Only.Cluster.Architecture == "" && (component.Import.Path != "" || component.Import.URL != "") {
+			composeArchs = pkg.Metadata.Architectures
 		}
 
-		// migrate any deprecated component configurations now
-		warning := chain.Migrate(pkg.Build)
-		warnings = append(warnings, warning...)
+		composedComponents := []v1alpha1.ZarfComponent{}
+		for _, composeArch := range composeArchs {
+			// build the import chain
+			chain, err := composer.NewImportChain(ctx, component, i, pkg.Metadata.Name, composeArch, flavor)
+			if err != nil {
+				if len(composeArchs) > 1 {
+					return v1alpha1.ZarfPackage{}, nil, fmt.Errorf("unable to compose component %s for %s: %w", component.Name, composeArch, err)
+				}
+				return v1alpha1.ZarfPackage{}, nil, err
+			}
 
-		// get the composed component
-		composed, err := chain.Compose(ctx)
-		if err != nil {
-			return v1alpha1.ZarfPackage{}, nil, err
-		}
-		components = append(components, *composed)
+			// migrate any deprecated component configurations now
+			for _, warning := range chain.Migrate(pkg.Build) {
+				if !slices.Contains(warnings, warning) {
+					warnings = append(warnings, warning)
+				}
+			}
 
-		// merge variables and constants
-		pkgVars = chain.MergeVariables(pkgVars)
-		pkgConsts = chain.MergeConstants(pkgConsts)
+			// get the composed component
+			composed, err := chain.Compose(ctx)
+			if err != nil {
+				return v1alpha1.ZarfPackage{}, nil, err
+			}
+			composedComponents = append(composedComponents, *composed)
+
+			// merge variables and constants
+			pkgVars = chain.MergeVariables(pkgVars)
+			pkgConsts = chain.MergeConstants(pkgConsts)
+		}
+		components = append(components, archComponents(composedComponents, composeArchs)...)
 	}
 
 	// set the filtered + composed components
@@ -61,3 +94,25 @@ func ComposeComponents(ctx context.Context, pkg v1alpha1.ZarfPackage, flavor str
 
 	return pkg, warnings, nil
 }
+
+// archComponents returns the components composed for each architecture limited to their architecture, or only the
+// first one when the imports composed the same component for every architecture.
+func archComponents(composed []v1alpha1.ZarfComponent, archs []string) []v1alpha1.ZarfComponent {
+	if len(composed) == 1 {
+		return composed
+	}
+	same := true
+	for _, component := range composed[1:] {
+		if !reflect.DeepEqual(component, composed[0]) {
+			same = false
+			break
+		}
+	}
+	if same {
+		return composed[:1]
+	}
+	for i := range composed {
+		composed[i].Only.Cluster.Architecture = archs[i]
+	}
+	return composed
+}
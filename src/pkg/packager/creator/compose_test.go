@@ -6,10 +6,15 @@ package creator
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/stretchr/testify/require"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
 )
 
 func TestComposeComponents(t *testing.T) {
@@ -138,6 +143,66 @@ func TestComposeComponents(t *testing.T) {
 			},
 			expectedErr: "",
 		},
+		{
+			name: "multi-architecture keeps the components of every architecture",
+			pkg: v1alpha1.ZarfPackage{
+				Metadata: v1alpha1.ZarfMetadata{
+					Architecture:  v1alpha1.ZarfPackageArchMulti,
+					Architectures: []string{"amd64", "arm64"},
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name: "component1",
+					},
+					{
+						Name: "component2",
+						Only: v1alpha1.ZarfComponentOnlyTarget{
+							Cluster: v1alpha1.ZarfComponentOnlyCluster{
+								Architecture: "amd64",
+							},
+						},
+					},
+					{
+						Name: "component2",
+						Only: v1alpha1.ZarfComponentOnlyTarget{
+							Cluster: v1alpha1.ZarfComponentOnlyCluster{
+								Architecture: "arm64",
+							},
+						},
+					},
+					{
+						Name: "component3",
+						Only: v1alpha1.ZarfComponentOnlyTarget{
+							Cluster: v1alpha1.ZarfComponentOnlyCluster{
+								Architecture: "s390x",
+							},
+						},
+					},
+				},
+			},
+			expectedPkg: v1alpha1.ZarfPackage{
+				Components: []v1alpha1.ZarfComponent{
+					{Name: "component1"},
+					{
+						Name: "component2",
+						Only: v1alpha1.ZarfComponentOnlyTarget{
+							Cluster: v1alpha1.ZarfComponentOnlyCluster{
+								Architecture: "amd64",
+							},
+						},
+					},
+					{
+						Name: "component2",
+						Only: v1alpha1.ZarfComponentOnlyTarget{
+							Cluster: v1alpha1.ZarfComponentOnlyCluster{
+								Architecture: "arm64",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "",
+		},
 		{
 			name: "no architecture set error",
 			pkg: v1alpha1.ZarfPackage{
@@ -174,3 +239,68 @@ func TestComposeComponents(t *testing.T) {
 		})
 	}
 }
+
+func TestComposeMultiArchImports(t *testing.T) {
+	t.Parallel()
+
+	importDir := t.TempDir()
+	imported := `kind: ZarfPackageConfig
+metadata:
+  name: imported
+components:
+  - name: helper
+    only:
+      cluster:
+        architecture: amd64
+    images:
+      - ghcr.io/example/helper-amd64:1.0.0
+  - name: helper
+    only:
+      cluster:
+        architecture: arm64
+    images:
+      - ghcr.io/example/helper-arm64:1.0.0
+  - name: podinfo
+    images:
+      - ghcr.io/stefanprodan/podinfo:6.4.0
+`
+	err := os.WriteFile(filepath.Join(importDir, layout.ZarfYAML), []byte(imported), helpers.ReadWriteUser)
+	require.NoError(t, err)
+	// Import paths are relative to the package
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	importDir, err = filepath.Rel(cwd, importDir)
+	require.NoError(t, err)
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{
+			Name:          "multi",
+			Architecture:  v1alpha1.ZarfPackageArchMulti,
+			Architectures: []string{"amd64", "arm64"},
+		},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "helper", Import: v1alpha1.ZarfComponentImport{Path: importDir}},
+			{Name: "podinfo", Import: v1alpha1.ZarfComponentImport{Path: importDir}},
+		},
+	}
+	composed, _, err := ComposeComponents(context.Background(), pkg, "")
+	require.NoError(t, err)
+
+	// The helper is composed once per architecture and podinfo once as it is the same for every architecture
+	require.Len(t, composed.Components, 3)
+	require.Equal(t, "helper", composed.Components[0].Name)
+	require.Equal(t, "amd64", composed.Components[0].Only.Cluster.Architecture)
+	require.Equal(t, []string{"ghcr.io/example/helper-amd64:1.0.0"}, composed.Components[0].Images)
+	require.Equal(t, "helper", composed.Components[1].Name)
+	require.Equal(t, "arm64", composed.Components[1].Only.Cluster.Architecture)
+	require.Equal(t, []string{"ghcr.io/example/helper-arm64:1.0.0"}, composed.Components[1].Images)
+	require.Equal(t, "podinfo", composed.Components[2].Name)
+	require.Empty(t, composed.Components[2].Only.Cluster.Architecture)
+	require.Equal(t, []string{"ghcr.io/stefanprodan/podinfo:6.4.0"}, composed.Components[2].Images)
+
+	// Every listed architecture needs a component to import
+	pkg.Metadata.Architectures = []string{"amd64", "s390x"}
+	pkg.Components = pkg.Components[:1]
+	_, _, err = ComposeComponents(context.Background(), pkg, "")
+	require.EqualError(t, err, fmt.Sprintf("unable to compose component helper for s390x: component \"helper\" not found in %q", importDir))
+}
@@ -14,6 +14,6 @@ import (
 // Creator is an interface for creating Zarf packages.
 type Creator interface {
 	LoadPackageDefinition(ctx context.Context, src *layout.PackagePaths) (pkg v1alpha1.ZarfPackage, warnings []string, err error)
-	Assemble(ctx context.Context, dst *layout.PackagePaths, components []v1alpha1.ZarfComponent, arch string) error
+	Assemble(ctx context.Context, dst *layout.PackagePaths, pkg v1alpha1.ZarfPackage) error
 	Output(ctx context.Context, dst *layout.PackagePaths, pkg *v1alpha1.ZarfPackage) error
 }
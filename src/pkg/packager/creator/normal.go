@@ -11,17 +11,19 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/defenseunicorns/pkg/oci"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/mholt/archiver/v3"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
-	"github.com/zarf-dev/zarf/src/extensions/bigbang"
+	"github.com/zarf-dev/zarf/src/extensions"
 	"github.com/zarf-dev/zarf/src/internal/git"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/images"
@@ -68,7 +70,11 @@ func (pc *PackageCreator) LoadPackageDefinition(ctx context.Context, src *layout
 		return v1alpha1.ZarfPackage{}, nil, err
 	}
 
-	pkg.Metadata.Architecture = config.GetArch(pkg.Metadata.Architecture)
+	pkg.Metadata.Architecture = packageArchitecture(pkg.Metadata)
+	// Building a multi-architecture package for a single architecture with --architecture drops the other ones
+	if !pkg.IsMultiArch() {
+		pkg.Metadata.Architectures = nil
+	}
 
 	// Compose components into a single zarf.yaml file
 	pkg, composeWarnings, err := ComposeComponents(ctx, pkg, pc.createOpts.Flavor)
@@ -86,7 +92,7 @@ func (pc *PackageCreator) LoadPackageDefinition(ctx context.Context, src *layout
 	warnings = append(warnings, templateWarnings...)
 
 	// After templates are filled process any create extensions
-	pkg.Components, err = pc.processExtensions(ctx, pkg.Components, src, pkg.Metadata.YOLO)
+	pkg.Components, err = pc.processExtensions(ctx, pkg, src)
 	if err != nil {
 		return v1alpha1.ZarfPackage{}, nil, err
 	}
@@ -127,13 +133,20 @@ func (pc *PackageCreator) LoadPackageDefinition(ctx context.Context, src *layout
 }
 
 // Assemble assembles all of the package assets into Zarf's tmp directory layout.
-func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths, components []v1alpha1.ZarfComponent, arch string) error {
+//
+// Images are pulled for the architectures of the package, multi-architecture packages keep the image index of every
+// image that is needed for more than one of them.
+func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths, pkg v1alpha1.ZarfPackage) error {
 	var imageList []transform.Image
+	archs := pkg.SupportedArchitectures()
+	multiArch := len(archs) > 1
+	imageArchs := map[transform.Image][]string{}
 
 	skipSBOMFlagUsed := pc.createOpts.SkipSBOM
 	componentSBOMs := map[string]*layout.ComponentSBOM{}
 
-	for _, component := range components {
+	for _, component := range pkg.Components {
+		key := pkg.ComponentKey(component)
 		onCreate := component.Actions.OnCreate
 
 		onFailure := func() {
@@ -142,7 +155,7 @@ func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths
 			}
 		}
 
-		if err := pc.addComponent(ctx, component, dst); err != nil {
+		if err := pc.addComponent(ctx, key, component, dst); err != nil {
 			onFailure()
 			return fmt.Errorf("unable to add component %q: %w", component.Name, err)
 		}
@@ -153,12 +166,12 @@ func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths
 		}
 
 		if !skipSBOMFlagUsed {
-			componentSBOM, err := pc.getFilesToSBOM(component, dst)
+			componentSBOM, err := pc.getFilesToSBOM(key, component, dst)
 			if err != nil {
 				return fmt.Errorf("unable to create component SBOM: %w", err)
 			}
 			if componentSBOM != nil && len(componentSBOM.Files) > 0 {
-				componentSBOMs[component.Name] = componentSBOM
+				componentSBOMs[key] = componentSBOM
 			}
 		}
 
@@ -169,6 +182,17 @@ func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths
 				return fmt.Errorf("failed to create ref for image %s: %w", src, err)
 			}
 			imageList = append(imageList, refInfo)
+			if multiArch {
+				componentArchs := archs
+				if component.Only.Cluster.Architecture != "" {
+					componentArchs = []string{component.Only.Cluster.Architecture}
+				}
+				for _, arch := range componentArchs {
+					if !slices.Contains(imageArchs[refInfo], arch) {
+						imageArchs[refInfo] = append(imageArchs[refInfo], arch)
+					}
+				}
+			}
 		}
 	}
 
@@ -184,12 +208,18 @@ func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths
 
 		dst.AddImages()
 
+		arch := v1alpha1.ZarfPackageArchMulti
+		if !multiArch {
+			arch = archs[0]
+		}
 		pullCfg := images.PullConfig{
 			DestinationDirectory: dst.Images.Base,
 			ImageList:            imageList,
 			Arch:                 arch,
+			Architectures:        imageArchs,
 			RegistryOverrides:    pc.createOpts.RegistryOverrides,
 			CacheDirectory:       filepath.Join(config.GetAbsCachePath(), layout.ImagesDir),
+			LocalSources:         pc.createOpts.ImageSources,
 		}
 
 		pulled, err := images.Pull(ctx, pullCfg)
@@ -197,15 +227,40 @@ func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths
 			return err
 		}
 
-		for info, img := range pulled {
-			if err := dst.Images.AddV1Image(img); err != nil {
-				return err
+		for info, pulledImg := range pulled {
+			var sbomImages []v1.Image
+			switch pulledImg := pulledImg.(type) {
+			case v1.ImageIndex:
+				if err := dst.Images.AddV1ImageIndex(pulledImg); err != nil {
+					return err
+				}
+				// An SBOM is generated for the image of every platform in an image index
+				descs, err := utils.IndexPlatformImages(pulledImg)
+				if err != nil {
+					return fmt.Errorf("failed to get the images of %s: %w", info, err)
+				}
+				for _, desc := range descs {
+					img, err := pulledImg.Image(desc.Digest)
+					if err != nil {
+						return fmt.Errorf("failed to get an image of %s: %w", info, err)
+					}
+					sbomImages = append(sbomImages, img)
+				}
+			case v1.Image:
+				if err := dst.Images.AddV1Image(pulledImg); err != nil {
+					return err
+				}
+				sbomImages = append(sbomImages, pulledImg)
 			}
-			ok, err := utils.OnlyHasImageLayers(img)
-			if err != nil {
-				return fmt.Errorf("failed to validate %s is an image and not an artifact: %w", info, err)
+			onlyImages := true
+			for _, img := range sbomImages {
+				ok, err := utils.OnlyHasImageLayers(img)
+				if err != nil {
+					return fmt.Errorf("failed to validate %s is an image and not an artifact: %w", info, err)
+				}
+				onlyImages = onlyImages && ok
 			}
-			if ok {
+			if onlyImages {
 				sbomImageList = append(sbomImageList, info)
 			}
 		}
@@ -241,7 +296,7 @@ func (pc *PackageCreator) Output(ctx context.Context, dst *layout.PackagePaths,
 	// NOTE: This is purposefully being done after the SBOM cataloging
 	for _, component := range pkg.Components {
 		// Make the component a tar archive
-		if err := dst.Components.Archive(component, true); err != nil {
+		if err := dst.Components.Archive(pkg.ComponentKey(component), true); err != nil {
 			return fmt.Errorf("unable to archive component: %s", err.Error())
 		}
 	}
@@ -272,7 +327,7 @@ func (pc *PackageCreator) Output(ctx context.Context, dst *layout.PackagePaths,
 		if err != nil {
 			return err
 		}
-		remote, err := zoci.NewRemote(ref, oci.PlatformForArch(config.GetArch()))
+		remote, err := zoci.NewRemote(ref, oci.PlatformForArch(pkg.Build.Architecture))
 		if err != nil {
 			return err
 		}
@@ -330,19 +385,16 @@ func (pc *PackageCreator) Output(ctx context.Context, dst *layout.PackagePaths,
 	return nil
 }
 
-func (pc *PackageCreator) processExtensions(ctx context.Context, components []v1alpha1.ZarfComponent, layout *layout.PackagePaths, isYOLO bool) (processedComponents []v1alpha1.ZarfComponent, err error) {
+func (pc *PackageCreator) processExtensions(ctx context.Context, pkg v1alpha1.ZarfPackage, layout *layout.PackagePaths) (processedComponents []v1alpha1.ZarfComponent, err error) {
 	// Create component paths and process extensions for each component.
-	for _, c := range components {
-		componentPaths, err := layout.Components.Create(c)
+	for _, c := range pkg.Components {
+		componentPaths, err := layout.Components.Create(pkg.ComponentKey(c), c)
 		if err != nil {
 			return nil, err
 		}
 
-		// Big Bang
-		if c.Extensions.BigBang != nil {
-			if c, err = bigbang.Run(ctx, isYOLO, componentPaths, c); err != nil {
-				return nil, fmt.Errorf("unable to process bigbang extension: %w", err)
-			}
+		if c, err = extensions.Run(ctx, pkg.Metadata.YOLO, componentPaths, c); err != nil {
+			return nil, err
 		}
 
 		processedComponents = append(processedComponents, c)
@@ -351,10 +403,10 @@ func (pc *PackageCreator) processExtensions(ctx context.Context, components []v1
 	return processedComponents, nil
 }
 
-func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.ZarfComponent, dst *layout.PackagePaths) error {
+func (pc *PackageCreator) addComponent(ctx context.Context, key string, component v1alpha1.ZarfComponent, dst *layout.PackagePaths) error {
 	message.HeaderInfof("📦 %s COMPONENT", strings.ToUpper(component.Name))
 
-	componentPaths, err := dst.Components.Create(component)
+	componentPaths, err := dst.Components.Create(key, component)
 	if err != nil {
 		return err
 	}
@@ -529,8 +581,8 @@ func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.Z
 	return nil
 }
 
-func (pc *PackageCreator) getFilesToSBOM(component v1alpha1.ZarfComponent, dst *layout.PackagePaths) (*layout.ComponentSBOM, error) {
-	componentPaths, err := dst.Components.Create(component)
+func (pc *PackageCreator) getFilesToSBOM(key string, component v1alpha1.ZarfComponent, dst *layout.PackagePaths) (*layout.ComponentSBOM, error) {
+	componentPaths, err := dst.Components.Create(key, component)
 	if err != nil {
 		return nil, err
 	}
@@ -17,11 +17,12 @@ import (
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
-	"github.com/zarf-dev/zarf/src/extensions/bigbang"
+	"github.com/zarf-dev/zarf/src/extensions"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/kustomize"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
@@ -62,7 +63,7 @@ func (sc *SkeletonCreator) LoadPackageDefinition(ctx context.Context, src *layou
 
 	warnings = append(warnings, composeWarnings...)
 
-	pkg.Components, err = sc.processExtensions(pkg.Components, src)
+	pkg.Components, err = sc.processExtensions(pkg, src)
 	if err != nil {
 		return v1alpha1.ZarfPackage{}, nil, err
 	}
@@ -81,9 +82,10 @@ func (sc *SkeletonCreator) LoadPackageDefinition(ctx context.Context, src *layou
 // Assemble updates all components of the loaded Zarf package with necessary modifications for package assembly.
 //
 // It processes each component to ensure correct structure and resource locations.
-func (sc *SkeletonCreator) Assemble(_ context.Context, dst *layout.PackagePaths, components []v1alpha1.ZarfComponent, _ string) error {
+func (sc *SkeletonCreator) Assemble(_ context.Context, dst *layout.PackagePaths, pkg v1alpha1.ZarfPackage) error {
+	components := pkg.Components
 	for _, component := range components {
-		c, err := sc.addComponent(component, dst)
+		c, err := sc.addComponent(pkg.ComponentKey(component), component, dst)
 		if err != nil {
 			return err
 		}
@@ -104,7 +106,7 @@ func (sc *SkeletonCreator) Assemble(_ context.Context, dst *layout.PackagePaths,
 // - signs the package
 func (sc *SkeletonCreator) Output(_ context.Context, dst *layout.PackagePaths, pkg *v1alpha1.ZarfPackage) (err error) {
 	for _, component := range pkg.Components {
-		if err := dst.Components.Archive(component, false); err != nil {
+		if err := dst.Components.Archive(pkg.ComponentKey(component), false); err != nil {
 			return err
 		}
 	}
@@ -126,19 +128,16 @@ func (sc *SkeletonCreator) Output(_ context.Context, dst *layout.PackagePaths, p
 	return dst.SignPackage(sc.publishOpts.SigningKeyPath, sc.publishOpts.SigningKeyPassword, !config.CommonOptions.Confirm)
 }
 
-func (sc *SkeletonCreator) processExtensions(components []v1alpha1.ZarfComponent, layout *layout.PackagePaths) (processedComponents []v1alpha1.ZarfComponent, err error) {
+func (sc *SkeletonCreator) processExtensions(pkg v1alpha1.ZarfPackage, layout *layout.PackagePaths) (processedComponents []v1alpha1.ZarfComponent, err error) {
 	// Create component paths and process extensions for each component.
-	for _, c := range components {
-		componentPaths, err := layout.Components.Create(c)
+	for _, c := range pkg.Components {
+		componentPaths, err := layout.Components.Create(pkg.ComponentKey(c), c)
 		if err != nil {
 			return nil, err
 		}
 
-		// Big Bang
-		if c.Extensions.BigBang != nil {
-			if c, err = bigbang.Skeletonize(componentPaths, c); err != nil {
-				return nil, fmt.Errorf("unable to process bigbang extension: %w", err)
-			}
+		if c, err = extensions.Skeletonize(componentPaths, c); err != nil {
+			return nil, err
 		}
 
 		processedComponents = append(processedComponents, c)
@@ -147,12 +146,12 @@ func (sc *SkeletonCreator) processExtensions(components []v1alpha1.ZarfComponent
 	return processedComponents, nil
 }
 
-func (sc *SkeletonCreator) addComponent(component v1alpha1.ZarfComponent, dst *layout.PackagePaths) (updatedComponent *v1alpha1.ZarfComponent, err error) {
+func (sc *SkeletonCreator) addComponent(key string, component v1alpha1.ZarfComponent, dst *layout.PackagePaths) (updatedComponent *v1alpha1.ZarfComponent, err error) {
 	message.HeaderInfof("📦 %s COMPONENT", strings.ToUpper(component.Name))
 
 	updatedComponent = &component
 
-	componentPaths, err := dst.Components.Create(component)
+	componentPaths, err := dst.Components.Create(key, component)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +208,30 @@ func (sc *SkeletonCreator) addComponent(component v1alpha1.ZarfComponent, dst *l
 		}
 	}
 
+	// Copy the image layouts of oci-layout:// images so that the images can be pulled when the skeleton is imported.
+	for imageIdx, image := range component.Images {
+		if !strings.HasPrefix(image, transform.OCILayoutPrefix) {
+			continue
+		}
+		refInfo, err := transform.ParseImageRef(image)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse image %s: %w", image, err)
+		}
+
+		// The image is named after the final element of the layout path so it is kept.
+		rel := filepath.Join(layout.ImagesDir, strconv.Itoa(imageIdx), filepath.Base(filepath.Clean(refInfo.LayoutPath)))
+		dst := filepath.Join(componentPaths.Base, rel)
+
+		if err := helpers.CreatePathAndCopy(refInfo.LayoutPath, dst); err != nil {
+			return nil, fmt.Errorf("unable to copy image layout %s: %w", refInfo.LayoutPath, err)
+		}
+
+		updatedComponent.Images[imageIdx], err = transform.SetOCILayoutPath(image, rel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for filesIdx, file := range component.Files {
 		if helpers.IsURL(file.Source) {
 			continue
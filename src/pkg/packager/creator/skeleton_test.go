@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package creator contains functions for creating Zarf packages.
+package creator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestSkeletonImageLayouts(t *testing.T) {
+	t.Parallel()
+
+	layoutPath := filepath.Join(t.TempDir(), "podinfo")
+	_, err := clayout.Write(layoutPath, empty.Index)
+	require.NoError(t, err)
+
+	component := v1alpha1.ZarfComponent{
+		Name: "podinfo",
+		Images: []string{
+			"ghcr.io/stefanprodan/podinfo:6.4.0",
+			"oci-layout://" + layoutPath + ":6.4.0",
+		},
+	}
+	dst := layout.New(t.TempDir())
+	sc := NewSkeletonCreator(types.ZarfCreateOptions{}, types.ZarfPublishOptions{})
+	updated, err := sc.addComponent(component.Name, component, dst)
+	require.NoError(t, err)
+
+	rel := filepath.Join(layout.ImagesDir, "1", "podinfo")
+	require.Equal(t, []string{"ghcr.io/stefanprodan/podinfo:6.4.0", "oci-layout://" + rel + ":6.4.0"}, updated.Images)
+	require.FileExists(t, filepath.Join(dst.Components.Dirs["podinfo"].Base, rel, "index.json"))
+}
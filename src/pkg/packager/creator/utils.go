@@ -37,6 +37,15 @@ func Validate(pkg v1alpha1.ZarfPackage, baseDir string) error {
 	return nil
 }
 
+// packageArchitecture returns the architecture of a package, packages that list their architectures are
+// multi-architecture unless an architecture is set.
+func packageArchitecture(metadata v1alpha1.ZarfMetadata) string {
+	if metadata.Architecture == "" && len(metadata.Architectures) > 0 {
+		return config.GetArch(v1alpha1.ZarfPackageArchMulti)
+	}
+	return config.GetArch(metadata.Architecture)
+}
+
 // recordPackageMetadata records various package metadata during package create.
 func recordPackageMetadata(pkg *v1alpha1.ZarfPackage, createOpts types.ZarfCreateOptions) error {
 	now := time.Now()
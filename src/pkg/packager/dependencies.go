@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// verifyPackageDependencies checks that the packages the package depends on are deployed to the cluster and that the
+// version being deployed still satisfies the deployed packages that depend on it.
+func (p *Packager) verifyPackageDependencies(ctx context.Context) error {
+	// Only packages deployed to a cluster can have dependents
+	requiresCluster := len(p.cfg.Pkg.Metadata.DependsOn) > 0
+	for _, component := range p.cfg.Pkg.Components {
+		if component.RequiresCluster() {
+			requiresCluster = true
+		}
+	}
+	if !requiresCluster {
+		return nil
+	}
+	connectCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	if err := p.connectToCluster(connectCtx); err != nil {
+		return err
+	}
+
+	spinner := message.NewProgressSpinner("Checking the dependencies of package %s", p.cfg.Pkg.Metadata.Name)
+	defer spinner.Stop()
+
+	deployedPackages, err := p.cluster.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get the deployed packages: %w", err)
+	}
+	if err := checkPackageDependencies(p.cfg.Pkg, deployedPackages); err != nil {
+		return err
+	}
+	if !p.cfg.DeployOpts.SkipDependentsCheck {
+		if err := checkDependentPackages(p.cfg.Pkg, deployedPackages); err != nil {
+			return err
+		}
+	}
+
+	spinner.Success()
+	return nil
+}
+
+// checkPackageDependencies returns an error for every dependency of the package that is not satisfied by the deployed packages.
+func checkPackageDependencies(pkg v1alpha1.ZarfPackage, deployedPackages []types.DeployedPackage) error {
+	versions := map[string]string{}
+	for _, deployedPackage := range deployedPackages {
+		versions[deployedPackage.Name] = deployedPackage.Data.Metadata.Version
+	}
+
+	var err error
+	for _, dependency := range pkg.Metadata.DependsOn {
+		version, ok := versions[dependency.Name]
+		if !ok {
+			err = errors.Join(err, fmt.Errorf("package %s depends on package %s which is not deployed", pkg.Metadata.Name, dependency.Name))
+			continue
+		}
+		if dependency.Version == "" {
+			continue
+		}
+		if dependencyErr := checkDependencyVersion(pkg.Metadata.Name, dependency, version); dependencyErr != nil {
+			err = errors.Join(err, dependencyErr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("the package dependencies are not satisfied: %w", err)
+	}
+	return nil
+}
+
+// checkDependencyVersion returns an error if the version of a package does not satisfy the constraint of a dependency on it.
+func checkDependencyVersion(packageName string, dependency v1alpha1.ZarfPackageDependency, version string) error {
+	constraint, err := semver.NewConstraint(dependency.Version)
+	if err != nil {
+		return fmt.Errorf("package %s has an invalid version constraint for package %s: %w", packageName, dependency.Name, err)
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("package %s depends on package %s %s but version %q is not a semantic version", packageName, dependency.Name, dependency.Version, version)
+	}
+	if !constraint.Check(v) {
+		return fmt.Errorf("package %s depends on package %s %s which version %s does not satisfy", packageName, dependency.Name, dependency.Version, version)
+	}
+	return nil
+}
+
+// checkDependentPackages returns an error for every deployed package that depends on the package with a version
+// constraint the version of the package does not satisfy.
+func checkDependentPackages(pkg v1alpha1.ZarfPackage, deployedPackages []types.DeployedPackage) error {
+	var err error
+	for _, deployedPackage := range deployedPackages {
+		if deployedPackage.Name == pkg.Metadata.Name {
+			continue
+		}
+		for _, dependency := range deployedPackage.Data.Metadata.DependsOn {
+			if dependency.Name != pkg.Metadata.Name || dependency.Version == "" {
+				continue
+			}
+			if dependencyErr := checkDependencyVersion(deployedPackage.Name, dependency, pkg.Metadata.Version); dependencyErr != nil {
+				err = errors.Join(err, dependencyErr)
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("version %s of package %s does not satisfy the deployed packages that depend on it: %w", pkg.Metadata.Version, pkg.Metadata.Name, err)
+	}
+	return nil
+}
+
+// verifyNoDependentPackages checks that no other deployed package depends on the package being removed.
+func (p *Packager) verifyNoDependentPackages(ctx context.Context, packageName string) error {
+	deployedPackages, err := p.cluster.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get the deployed packages: %w", err)
+	}
+	dependents := dependentPackages(packageName, deployedPackages)
+	if len(dependents) == 0 {
+		return nil
+	}
+	if p.cfg.RemoveOpts.Force {
+		message.Warnf("Removing package %s which packages %s depend on", packageName, strings.Join(dependents, ", "))
+		return nil
+	}
+	return fmt.Errorf("package %s cannot be removed because packages %s depend on it, remove them first or use --force", packageName, strings.Join(dependents, ", "))
+}
+
+// dependentPackages returns the names of the deployed packages that depend on the named package.
+func dependentPackages(packageName string, deployedPackages []types.DeployedPackage) []string {
+	dependents := []string{}
+	for _, deployedPackage := range deployedPackages {
+		if deployedPackage.Name == packageName {
+			continue
+		}
+		for _, dependency := range deployedPackage.Data.Metadata.DependsOn {
+			if dependency.Name == packageName {
+				dependents = append(dependents, deployedPackage.Name)
+				break
+			}
+		}
+	}
+	return dependents
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func testDeployedPackage(name, version string, dependsOn ...v1alpha1.ZarfPackageDependency) types.DeployedPackage {
+	return types.DeployedPackage{
+		Name: name,
+		Data: v1alpha1.ZarfPackage{
+			Metadata: v1alpha1.ZarfMetadata{
+				Name:      name,
+				Version:   version,
+				DependsOn: dependsOn,
+			},
+		},
+	}
+}
+
+func TestCheckPackageDependencies(t *testing.T) {
+	t.Parallel()
+
+	deployedPackages := []types.DeployedPackage{
+		testDeployedPackage("init", "v0.36.0"),
+		testDeployedPackage("core", "1.4.2"),
+		testDeployedPackage("dev", "main"),
+	}
+
+	tests := []struct {
+		name         string
+		dependsOn    []v1alpha1.ZarfPackageDependency
+		expectedErrs []string
+	}{
+		{
+			name: "no dependencies",
+		},
+		{
+			name: "satisfied dependencies",
+			dependsOn: []v1alpha1.ZarfPackageDependency{
+				{Name: "init", Version: ">= 0.35.0"},
+				{Name: "core", Version: "~1.4"},
+				{Name: "dev"},
+			},
+		},
+		{
+			name: "unsatisfied dependencies",
+			dependsOn: []v1alpha1.ZarfPackageDependency{
+				{Name: "gitea"},
+				{Name: "core", Version: ">= 2.0.0"},
+				{Name: "dev", Version: "1.x"},
+			},
+			expectedErrs: []string{
+				"package apps depends on package gitea which is not deployed",
+				"package apps depends on package core >= 2.0.0 which version 1.4.2 does not satisfy",
+				"package apps depends on package dev 1.x but version \"main\" is not a semantic version",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := testDeployedPackage("apps", "1.0.0", tt.dependsOn...).Data
+			err := checkPackageDependencies(pkg, deployedPackages)
+			if len(tt.expectedErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, expectedErr := range tt.expectedErrs {
+				require.ErrorContains(t, err, expectedErr)
+			}
+		})
+	}
+}
+
+func TestDependentPackages(t *testing.T) {
+	t.Parallel()
+
+	deployedPackages := []types.DeployedPackage{
+		testDeployedPackage("init", "v0.36.0"),
+		testDeployedPackage("core", "1.4.2", v1alpha1.ZarfPackageDependency{Name: "init"}),
+		testDeployedPackage("apps", "1.0.0", v1alpha1.ZarfPackageDependency{Name: "init"}, v1alpha1.ZarfPackageDependency{Name: "core", Version: "~1.4"}),
+	}
+
+	require.Equal(t, []string{"core", "apps"}, dependentPackages("init", deployedPackages))
+	require.Equal(t, []string{"apps"}, dependentPackages("core", deployedPackages))
+	require.Empty(t, dependentPackages("apps", deployedPackages))
+}
+
+func TestCheckDependentPackages(t *testing.T) {
+	t.Parallel()
+
+	deployedPackages := []types.DeployedPackage{
+		testDeployedPackage("core", "1.4.2"),
+		testDeployedPackage("apps", "1.0.0", v1alpha1.ZarfPackageDependency{Name: "core", Version: "~1.4"}),
+		testDeployedPackage("tools", "1.0.0", v1alpha1.ZarfPackageDependency{Name: "core"}),
+	}
+
+	err := checkDependentPackages(testDeployedPackage("core", "1.4.3").Data, deployedPackages)
+	require.NoError(t, err)
+	err = checkDependentPackages(testDeployedPackage("other", "0.1.0").Data, deployedPackages)
+	require.NoError(t, err)
+	err = checkDependentPackages(testDeployedPackage("core", "2.0.0").Data, deployedPackages)
+	require.EqualError(t, err, "version 2.0.0 of package core does not satisfy the deployed packages that depend on it: package apps depends on package core ~1.4 which version 2.0.0 does not satisfy")
+}
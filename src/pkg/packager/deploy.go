@@ -6,12 +6,14 @@ package packager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -55,10 +57,11 @@ func (p *Packager) resetRegistryHPA(ctx context.Context) {
 
 // Deploy attempts to deploy the given PackageConfig.
 func (p *Packager) Deploy(ctx context.Context) error {
-	isInteractive := !config.CommonOptions.Confirm
+	isInteractive := !p.confirm
 
 	deployFilter := filters.Combine(
 		filters.ByLocalOS(runtime.GOOS),
+		filters.ByArchitecture(func() ([]string, error) { return p.clusterArchitectures(ctx) }),
 		filters.ForDeploy(p.cfg.PkgOpts.OptionalComponents, isInteractive),
 	)
 
@@ -112,6 +115,14 @@ func (p *Packager) Deploy(ctx context.Context) error {
 		}
 	}
 
+	if err := p.verifyPackageDependencies(ctx); err != nil {
+		return err
+	}
+
+	if p.cfg.DeployOpts.DryRun {
+		return p.dryRunDeploy(ctx)
+	}
+
 	p.hpaModified = false
 	p.connectStrings = make(types.ConnectStrings)
 	// Reset registry HPA scale down whether an error occurs or not
@@ -139,6 +150,21 @@ func (p *Packager) Deploy(ctx context.Context) error {
 
 // deployComponents loops through a list of ZarfComponents and deploys them.
 func (p *Packager) deployComponents(ctx context.Context) (deployedComponents []types.DeployedComponent, err error) {
+	// The package as it was recorded before this deployment, captured when the cluster is first reached
+	var previousPackage *types.DeployedPackage
+	previousPackageLoaded := false
+	// Helm releases that this deployment may have installed or upgraded
+	touchedCharts := []types.InstalledChart{}
+
+	defer func() {
+		if err == nil || !p.cfg.DeployOpts.RollbackOnFailure || !p.isConnectedToCluster() {
+			return
+		}
+		if rollbackErr := p.rollbackDeployment(ctx, previousPackage, touchedCharts); rollbackErr != nil {
+			err = errors.Join(err, fmt.Errorf("unable to roll back the failed deployment: %w", rollbackErr))
+		}
+	}()
+
 	// Process all the components we are deploying
 	for _, component := range p.cfg.Pkg.Components {
 		// Connect to cluster if a component requires it.
@@ -155,9 +181,14 @@ func (p *Packager) deployComponents(ctx context.Context) (deployedComponents []t
 			}
 
 			// If this package has been deployed before, increment the package generation within the secret
-			if existingDeployedPackage, _ := p.cluster.GetDeployedPackage(ctx, p.cfg.Pkg.Metadata.Name); existingDeployedPackage != nil {
+			existingDeployedPackage, _ := p.cluster.GetDeployedPackage(ctx, p.cfg.Pkg.Metadata.Name)
+			if existingDeployedPackage != nil {
 				packageGeneration = existingDeployedPackage.Generation + 1
 			}
+			if !previousPackageLoaded {
+				previousPackage = existingDeployedPackage
+				previousPackageLoaded = true
+			}
 		}
 
 		deployedComponent := types.DeployedComponent{
@@ -177,22 +208,32 @@ func (p *Packager) deployComponents(ctx context.Context) (deployedComponents []t
 		deployedComponents = append(deployedComponents, deployedComponent)
 		idx := len(deployedComponents) - 1
 
+		touchedCharts = append(touchedCharts, deployedComponent.InstalledCharts...)
+		for _, chart := range component.Charts {
+			releaseName := chart.ReleaseName
+			if releaseName == "" {
+				releaseName = chart.Name
+			}
+			touchedCharts = append(touchedCharts, types.InstalledChart{Namespace: chart.Namespace, ChartName: releaseName})
+		}
+
 		// Update the package secret to indicate that we are attempting to deploy this component
 		if p.isConnectedToCluster() {
-			if _, err := p.cluster.RecordPackageDeploymentAndWait(ctx, p.cfg.Pkg, deployedComponents, p.connectStrings, packageGeneration, component, p.cfg.DeployOpts.SkipWebhooks); err != nil {
-				message.Debugf("Unable to record package deployment for component %s: this will affect features like `zarf package remove`: %s", component.Name, err.Error())
-			}
+			p.recordPackageDeployment(ctx, deployedComponents, packageGeneration, component)
 		}
 
 		// Deploy the component
 		var charts []types.InstalledChart
+		var dataInjections []types.DeployedDataInjection
 		var deployErr error
 		if p.cfg.Pkg.IsInitConfig() {
-			charts, deployErr = p.deployInitComponent(ctx, component)
+			charts, dataInjections, deployErr = p.deployInitComponent(ctx, component)
 		} else {
-			charts, deployErr = p.deployComponent(ctx, component, false /* keep img checksum */, false /* always push images */)
+			charts, dataInjections, deployErr = p.deployComponent(ctx, component, false /* keep img checksum */, false /* always push images */)
 		}
 
+		touchedCharts = append(touchedCharts, charts...)
+
 		onDeploy := component.Actions.OnDeploy
 
 		onFailure := func() {
@@ -207,9 +248,7 @@ func (p *Packager) deployComponents(ctx context.Context) (deployedComponents []t
 			// Update the package secret to indicate that we failed to deploy this component
 			deployedComponents[idx].Status = types.ComponentStatusFailed
 			if p.isConnectedToCluster() {
-				if _, err := p.cluster.RecordPackageDeploymentAndWait(ctx, p.cfg.Pkg, deployedComponents, p.connectStrings, packageGeneration, component, p.cfg.DeployOpts.SkipWebhooks); err != nil {
-					message.Debugf("Unable to record package deployment for component %q: this will affect features like `zarf package remove`: %s", component.Name, err.Error())
-				}
+				p.recordPackageDeployment(ctx, deployedComponents, packageGeneration, component)
 			}
 
 			return deployedComponents, fmt.Errorf("unable to deploy component %q: %w", component.Name, deployErr)
@@ -217,11 +256,10 @@ func (p *Packager) deployComponents(ctx context.Context) (deployedComponents []t
 
 		// Update the package secret to indicate that we successfully deployed this component
 		deployedComponents[idx].InstalledCharts = charts
+		deployedComponents[idx].DataInjections = dataInjections
 		deployedComponents[idx].Status = types.ComponentStatusSucceeded
 		if p.isConnectedToCluster() {
-			if _, err := p.cluster.RecordPackageDeploymentAndWait(ctx, p.cfg.Pkg, deployedComponents, p.connectStrings, packageGeneration, component, p.cfg.DeployOpts.SkipWebhooks); err != nil {
-				message.Debugf("Unable to record package deployment for component %q: this will affect features like `zarf package remove`: %s", component.Name, err.Error())
-			}
+			p.recordPackageDeployment(ctx, deployedComponents, packageGeneration, component)
 		}
 
 		if err := actions.Run(ctx, onDeploy.Defaults, onDeploy.OnSuccess, p.variableConfig); err != nil {
@@ -233,7 +271,36 @@ func (p *Packager) deployComponents(ctx context.Context) (deployedComponents []t
 	return deployedComponents, nil
 }
 
-func (p *Packager) deployInitComponent(ctx context.Context, component v1alpha1.ZarfComponent) (charts []types.InstalledChart, err error) {
+// recordPackageDeployment updates the package secret and the package history with the state of the current deployment.
+func (p *Packager) recordPackageDeployment(ctx context.Context, deployedComponents []types.DeployedComponent, generation int, component v1alpha1.ZarfComponent) {
+	deployedPackage, err := p.cluster.RecordPackageDeploymentAndWait(ctx, p.cfg.Pkg, deployedComponents, p.connectStrings, generation, component, p.cfg.DeployOpts.SkipWebhooks)
+	if err != nil {
+		message.Debugf("Unable to record package deployment for component %q: this will affect features like `zarf package remove`: %s", component.Name, err.Error())
+		return
+	}
+	if err := p.cluster.RecordPackageRevision(ctx, *deployedPackage, p.redactedVariables()); err != nil {
+		message.Warnf("Unable to record the history of package %q: this will affect features like `zarf package rollback`: %s", deployedPackage.Name, err.Error())
+	}
+}
+
+// redactedVariables returns the values of the package variables with sensitive values sanitized.
+func (p *Packager) redactedVariables() map[string]string {
+	variables := map[string]string{}
+	for _, variable := range p.cfg.Pkg.Variables {
+		setVariable, ok := p.variableConfig.GetSetVariable(variable.Name)
+		if !ok || setVariable == nil {
+			continue
+		}
+		if setVariable.Sensitive {
+			variables[variable.Name] = "**sanitized**"
+			continue
+		}
+		variables[variable.Name] = setVariable.Value
+	}
+	return variables
+}
+
+func (p *Packager) deployInitComponent(ctx context.Context, component v1alpha1.ZarfComponent) (charts []types.InstalledChart, dataInjections []types.DeployedDataInjection, err error) {
 	hasExternalRegistry := p.cfg.InitOpts.RegistryInfo.Address != ""
 	isSeedRegistry := component.Name == "zarf-seed-registry"
 	isRegistry := component.Name == "zarf-registry"
@@ -249,13 +316,13 @@ func (p *Packager) deployInitComponent(ctx context.Context, component v1alpha1.Z
 	if component.RequiresCluster() && p.state == nil {
 		err = p.cluster.InitZarfState(ctx, p.cfg.InitOpts)
 		if err != nil {
-			return nil, fmt.Errorf("unable to initialize Zarf state: %w", err)
+			return nil, nil, fmt.Errorf("unable to initialize Zarf state: %w", err)
 		}
 	}
 
 	if hasExternalRegistry && (isSeedRegistry || isInjector || isRegistry) {
 		message.Notef("Not deploying the component (%s) since external registry information was provided during `zarf init`", component.Name)
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	if isRegistry {
@@ -265,31 +332,31 @@ func (p *Packager) deployInitComponent(ctx context.Context, component v1alpha1.Z
 
 	// Before deploying the seed registry, start the injector
 	if isSeedRegistry {
-		err := p.cluster.StartInjection(ctx, p.layout.Base, p.layout.Images.Base, component.Images)
+		err := p.cluster.StartInjection(ctx, p.layout.Base, p.layout.Images.Base, component.Images, p.cfg.InitOpts.InjectionMode)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
-	charts, err = p.deployComponent(ctx, component, isAgent /* skip img checksum if isAgent */, isSeedRegistry /* skip image push if isSeedRegistry */)
+	charts, dataInjections, err = p.deployComponent(ctx, component, isAgent /* skip img checksum if isAgent */, isSeedRegistry /* skip image push if isSeedRegistry */)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Do cleanup for when we inject the seed registry during initialization
 	if isSeedRegistry {
 		if err := p.cluster.StopInjection(ctx); err != nil {
-			return nil, fmt.Errorf("unable to seed the Zarf Registry: %w", err)
+			return nil, nil, fmt.Errorf("unable to seed the Zarf Registry: %w", err)
 		}
 	}
 
-	return charts, nil
+	return charts, dataInjections, nil
 }
 
 // Deploy a Zarf Component.
-func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfComponent, noImgChecksum bool, noImgPush bool) (charts []types.InstalledChart, err error) {
+func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfComponent, noImgChecksum bool, noImgPush bool) (charts []types.InstalledChart, dataInjections []types.DeployedDataInjection, err error) {
 	// Toggles for general deploy operations
-	componentPath := p.layout.Components.Dirs[component.Name]
+	componentPath := p.layout.Components.Dirs[p.cfg.Pkg.ComponentKey(component)]
 
 	// All components now require a name
 	message.HeaderInfof("📦 %s COMPONENT", strings.ToUpper(component.Name))
@@ -307,7 +374,7 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 		if p.state == nil {
 			err = p.setupState(ctx)
 			if err != nil {
-				return charts, err
+				return charts, nil, err
 			}
 		}
 
@@ -323,33 +390,48 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 
 	err = p.populateComponentAndStateTemplates(component.Name)
 	if err != nil {
-		return charts, err
+		return charts, nil, err
 	}
 
 	if err = actions.Run(ctx, onDeploy.Defaults, onDeploy.Before, p.variableConfig); err != nil {
-		return charts, fmt.Errorf("unable to run component before action: %w", err)
+		return charts, nil, fmt.Errorf("unable to run component before action: %w", err)
 	}
 
 	if hasFiles {
 		if err := p.processComponentFiles(component, componentPath.Files); err != nil {
-			return charts, fmt.Errorf("unable to process the component files: %w", err)
+			return charts, nil, fmt.Errorf("unable to process the component files: %w", err)
 		}
 	}
 
 	if hasImages {
 		if err := p.pushImagesToRegistry(ctx, component.Images, noImgChecksum); err != nil {
-			return charts, fmt.Errorf("unable to push images to the registry: %w", err)
+			return charts, nil, fmt.Errorf("unable to push images to the registry: %w", err)
 		}
 	}
 
 	if hasRepos {
 		if err = p.pushReposToRepository(ctx, componentPath.Repos, component.Repos); err != nil {
-			return charts, fmt.Errorf("unable to push the repos to the repository: %w", err)
+			return charts, nil, fmt.Errorf("unable to push the repos to the repository: %w", err)
 		}
 	}
 
+	// Seed persistent volume claims before the charts and manifests that mount them are installed
+	for idx, data := range component.DataInjections {
+		if !data.Target.IsPersistentVolumeClaim() {
+			continue
+		}
+		injection, err := p.cluster.HandlePVCDataInjection(ctx, data, componentPath, idx, p.state)
+		if err != nil {
+			return charts, nil, err
+		}
+		dataInjections = append(dataInjections, injection)
+	}
+
 	g, gCtx := errgroup.WithContext(ctx)
 	for idx, data := range component.DataInjections {
+		if data.Target.IsPersistentVolumeClaim() {
+			continue
+		}
 		g.Go(func() error {
 			return p.cluster.HandleDataInjection(gCtx, data, componentPath, idx)
 		})
@@ -357,19 +439,19 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 
 	if hasCharts || hasManifests {
 		if charts, err = p.installChartAndManifests(ctx, componentPath, component); err != nil {
-			return charts, err
+			return charts, nil, err
 		}
 	}
 
 	if err = actions.Run(ctx, onDeploy.Defaults, onDeploy.After, p.variableConfig); err != nil {
-		return charts, fmt.Errorf("unable to run component after action: %w", err)
+		return charts, nil, fmt.Errorf("unable to run component after action: %w", err)
 	}
 
 	err = g.Wait()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return charts, nil
+	return charts, dataInjections, nil
 }
 
 // Move files onto the host of the machine performing the deployment.
@@ -471,6 +553,13 @@ func (p *Packager) setupState(ctx context.Context) (err error) {
 		// YOLO mode, so minimal state needed
 		state.Distro = "YOLO"
 
+		// Leave the cluster untouched during a dry run
+		if p.cfg.DeployOpts.DryRun {
+			p.state = state
+			spinner.Success()
+			return nil
+		}
+
 		// Try to create the zarf namespace
 		spinner.Updatef("Creating the Zarf namespace")
 		zarfNamespace := cluster.NewZarfManagedNamespace(cluster.ZarfNamespaceName)
@@ -539,6 +628,7 @@ func (p *Packager) pushImagesToRegistry(ctx context.Context, componentImages []s
 		NoChecksum:      noImgChecksum,
 		Arch:            p.cfg.Pkg.Build.Architecture,
 		Retries:         p.cfg.PkgOpts.Retries,
+		Concurrency:     p.cfg.PkgOpts.PushConcurrency,
 	}
 
 	return images.Push(ctx, pushCfg)
@@ -643,45 +733,16 @@ func (p *Packager) generateValuesOverrides(chart v1alpha1.ZarfChart, componentNa
 // Install all Helm charts and raw k8s manifests into the k8s cluster.
 func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths *layout.ComponentPaths, component v1alpha1.ZarfComponent) (installedCharts []types.InstalledChart, err error) {
 	for _, chart := range component.Charts {
-		// Do not wait for the chart to be ready if data injections are present.
-		if len(component.DataInjections) > 0 {
-			chart.NoWait = true
-		}
-
-		// zarf magic for the value file
-		for idx := range chart.ValuesFiles {
-			valueFilePath := helm.StandardValuesName(componentPaths.Values, chart, idx)
-			if err := p.variableConfig.ReplaceTextTemplate(valueFilePath); err != nil {
-				return installedCharts, err
-			}
-		}
-
-		// Create a Helm values overrides map from set Zarf `variables` and DeployOpts library inputs
-		// Values overrides are to be applied in order of Helm Chart Defaults -> Zarf `valuesFiles` -> Zarf `variables` -> DeployOpts overrides
-		valuesOverrides, err := p.generateValuesOverrides(chart, component.Name)
+		helmCfg, err := p.newChartHelm(componentPaths, component, chart)
 		if err != nil {
 			return installedCharts, err
 		}
 
-		helmCfg := helm.New(
-			chart,
-			componentPaths.Charts,
-			componentPaths.Values,
-			helm.WithDeployInfo(
-				p.cfg,
-				p.variableConfig,
-				p.state,
-				p.cluster,
-				valuesOverrides,
-				p.cfg.DeployOpts.Timeout,
-				p.cfg.PkgOpts.Retries),
-		)
-
-		addedConnectStrings, installedChartName, err := helmCfg.InstallOrUpgradeChart(ctx)
+		addedConnectStrings, installedChartName, revision, err := helmCfg.InstallOrUpgradeChart(ctx)
 		if err != nil {
 			return installedCharts, err
 		}
-		installedCharts = append(installedCharts, types.InstalledChart{Namespace: chart.Namespace, ChartName: installedChartName})
+		installedCharts = append(installedCharts, types.InstalledChart{Namespace: chart.Namespace, ChartName: installedChartName, Revision: revision})
 
 		// Iterate over any connectStrings and add to the main map
 		for name, description := range addedConnectStrings {
@@ -690,52 +751,22 @@ func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths
 	}
 
 	for _, manifest := range component.Manifests {
-		for idx := range manifest.Files {
-			if helpers.InvalidPath(filepath.Join(componentPaths.Manifests, manifest.Files[idx])) {
-				// The path is likely invalid because of how we compose OCI components, add an index suffix to the filename
-				manifest.Files[idx] = fmt.Sprintf("%s-%d.yaml", manifest.Name, idx)
-				if helpers.InvalidPath(filepath.Join(componentPaths.Manifests, manifest.Files[idx])) {
-					return installedCharts, fmt.Errorf("unable to find manifest file %s", manifest.Files[idx])
-				}
-			}
-		}
-		// Move kustomizations to files now
-		for idx := range manifest.Kustomizations {
-			kustomization := fmt.Sprintf("kustomization-%s-%d.yaml", manifest.Name, idx)
-			manifest.Files = append(manifest.Files, kustomization)
-		}
-
-		if manifest.Namespace == "" {
-			// Helm gets sad when you don't provide a namespace even though we aren't using helm templating
-			manifest.Namespace = corev1.NamespaceDefault
-		}
-
-		// Create a chart and helm cfg from a given Zarf Manifest.
-		helmCfg, err := helm.NewFromZarfManifest(
-			manifest,
-			componentPaths.Manifests,
-			p.cfg.Pkg.Metadata.Name,
-			component.Name,
-			helm.WithDeployInfo(
-				p.cfg,
-				p.variableConfig,
-				p.state,
-				p.cluster,
-				nil,
-				p.cfg.DeployOpts.Timeout,
-				p.cfg.PkgOpts.Retries),
-		)
+		helmCfg, err := p.newManifestHelm(componentPaths, component, manifest)
 		if err != nil {
 			return installedCharts, err
 		}
 
 		// Install the chart.
-		addedConnectStrings, installedChartName, err := helmCfg.InstallOrUpgradeChart(ctx)
+		addedConnectStrings, installedChartName, revision, err := helmCfg.InstallOrUpgradeChart(ctx)
 		if err != nil {
 			return installedCharts, err
 		}
 
-		installedCharts = append(installedCharts, types.InstalledChart{Namespace: manifest.Namespace, ChartName: installedChartName})
+		namespace := manifest.Namespace
+		if namespace == "" {
+			namespace = corev1.NamespaceDefault
+		}
+		installedCharts = append(installedCharts, types.InstalledChart{Namespace: namespace, ChartName: installedChartName, Revision: revision})
 
 		// Iterate over any connectStrings and add to the main map
 		for name, description := range addedConnectStrings {
@@ -746,6 +777,86 @@ func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths
 	return installedCharts, nil
 }
 
+// newChartHelm templates the values files of a Zarf chart and creates the helm config used to deploy it.
+func (p *Packager) newChartHelm(componentPaths *layout.ComponentPaths, component v1alpha1.ZarfComponent, chart v1alpha1.ZarfChart) (*helm.Helm, error) {
+	// Do not wait for the chart to be ready if data is injected into its pods, the volumes it mounts are seeded beforehand.
+	if slices.ContainsFunc(component.DataInjections, func(data v1alpha1.ZarfDataInjection) bool {
+		return !data.Target.IsPersistentVolumeClaim()
+	}) {
+		chart.NoWait = true
+	}
+
+	// zarf magic for the value file
+	for idx := range chart.ValuesFiles {
+		valueFilePath := helm.StandardValuesName(componentPaths.Values, chart, idx)
+		if err := p.variableConfig.ReplaceTextTemplate(valueFilePath); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create a Helm values overrides map from set Zarf `variables` and DeployOpts library inputs
+	// Values overrides are to be applied in order of Helm Chart Defaults -> Zarf `valuesFiles` -> Zarf `variables` -> DeployOpts overrides
+	valuesOverrides, err := p.generateValuesOverrides(chart, component.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return helm.New(
+		chart,
+		componentPaths.Charts,
+		componentPaths.Values,
+		helm.WithDeployInfo(
+			p.cfg,
+			p.variableConfig,
+			p.state,
+			p.cluster,
+			valuesOverrides,
+			p.cfg.DeployOpts.Timeout,
+			p.cfg.PkgOpts.Retries),
+	), nil
+}
+
+// newManifestHelm creates the helm config used to deploy a Zarf manifest as a chart.
+func (p *Packager) newManifestHelm(componentPaths *layout.ComponentPaths, component v1alpha1.ZarfComponent, manifest v1alpha1.ZarfManifest) (*helm.Helm, error) {
+	// Copy the files so that fixing up paths does not modify the component
+	manifest.Files = slices.Clone(manifest.Files)
+	for idx := range manifest.Files {
+		if helpers.InvalidPath(filepath.Join(componentPaths.Manifests, manifest.Files[idx])) {
+			// The path is likely invalid because of how we compose OCI components, add an index suffix to the filename
+			manifest.Files[idx] = fmt.Sprintf("%s-%d.yaml", manifest.Name, idx)
+			if helpers.InvalidPath(filepath.Join(componentPaths.Manifests, manifest.Files[idx])) {
+				return nil, fmt.Errorf("unable to find manifest file %s", manifest.Files[idx])
+			}
+		}
+	}
+	// Move kustomizations to files now
+	for idx := range manifest.Kustomizations {
+		kustomization := fmt.Sprintf("kustomization-%s-%d.yaml", manifest.Name, idx)
+		manifest.Files = append(manifest.Files, kustomization)
+	}
+
+	if manifest.Namespace == "" {
+		// Helm gets sad when you don't provide a namespace even though we aren't using helm templating
+		manifest.Namespace = corev1.NamespaceDefault
+	}
+
+	// Create a chart and helm cfg from a given Zarf Manifest.
+	return helm.NewFromZarfManifest(
+		manifest,
+		componentPaths.Manifests,
+		p.cfg.Pkg.Metadata.Name,
+		component.Name,
+		helm.WithDeployInfo(
+			p.cfg,
+			p.variableConfig,
+			p.state,
+			p.cluster,
+			nil,
+			p.cfg.DeployOpts.Timeout,
+			p.cfg.PkgOpts.Retries),
+	)
+}
+
 func (p *Packager) printTablesForDeployment(ctx context.Context, componentsToDeploy []types.DeployedComponent) error {
 	// If not init config, print the application connection table
 	if !p.cfg.Pkg.IsInitConfig() {
@@ -0,0 +1,335 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/pterm/pterm"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// Deployment states reported in the summary of a multi-package deployment.
+const (
+	packageDeployed = "Deployed"
+	packageFailed   = "Failed"
+	packageSkipped  = "Skipped"
+)
+
+// plannedPackage is a package that is deployed as part of a multi-package deployment.
+type plannedPackage struct {
+	// source is the local tarball a split or URL source resolves to once its metadata is loaded
+	source string
+	// downloadDir holds the tarball downloaded for a URL source until the deployment is done
+	downloadDir string
+	pkg         v1alpha1.ZarfPackage
+	status      string
+	components  []string
+}
+
+// DeployPackages deploys several packages in the order of the dependencies they declare on each other, after checking
+// that every dependency is satisfied by a package in the set or by a package that is already deployed.
+func DeployPackages(ctx context.Context, cfg *types.PackagerConfig, packageSources []string) error {
+	planned, err := loadPlannedPackages(ctx, cfg, packageSources)
+	if err != nil {
+		return err
+	}
+	defer removeDownloads(planned)
+	planned, err = orderPackages(planned)
+	if err != nil {
+		return err
+	}
+
+	deployedPackages := []types.DeployedPackage{}
+	if hasExternalDependencies(planned) || requiresCluster(planned) {
+		connectCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+		defer cancel()
+		c, err := cluster.NewClusterWithWait(connectCtx)
+		if err != nil {
+			return err
+		}
+		deployedPackages, err = c.GetDeployedZarfPackages(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to get the deployed packages: %w", err)
+		}
+	}
+	if err := checkPlannedDependencies(planned, deployedPackages); err != nil {
+		return err
+	}
+	if err := checkPlannedDependents(planned, deployedPackages); err != nil {
+		return err
+	}
+
+	if !confirmPackages(planned) {
+		return errors.New("deployment cancelled")
+	}
+
+	var deployErr error
+	for i := range planned {
+		if deployErr != nil {
+			planned[i].status = packageSkipped
+			continue
+		}
+		components, err := deployPlannedPackage(ctx, *cfg, planned[i].source)
+		if err != nil {
+			planned[i].status = packageFailed
+			deployErr = fmt.Errorf("failed to deploy package %s: %w", planned[i].pkg.Metadata.Name, err)
+			continue
+		}
+		planned[i].status = packageDeployed
+		planned[i].components = components
+	}
+
+	printPackagesSummary(planned)
+	if deployErr != nil {
+		return deployErr
+	}
+	message.Successf("Deployed %d Zarf packages", len(planned))
+	return nil
+}
+
+// loadPlannedPackages loads the metadata of every package source.
+func loadPlannedPackages(ctx context.Context, cfg *types.PackagerConfig, packageSources []string) (planned []plannedPackage, err error) {
+	defer func() {
+		if err != nil {
+			removeDownloads(planned)
+		}
+	}()
+	for _, packageSource := range packageSources {
+		pkgOpts := cfg.PkgOpts
+		pkgOpts.PackageSource = packageSource
+		src, err := sources.New(&pkgOpts)
+		if err != nil {
+			return planned, err
+		}
+		tmpdir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+		if err != nil {
+			return planned, err
+		}
+		pkg, _, err := src.LoadPackageMetadata(ctx, layout.New(tmpdir), false, true)
+		os.RemoveAll(tmpdir)
+		p := plannedPackage{source: pkgOpts.PackageSource, pkg: pkg}
+		// Split and URL sources point the options at the tarball they reassembled or downloaded, which is deployed
+		// instead of collecting the source again
+		if helpers.IsURL(packageSource) && pkgOpts.PackageSource != packageSource {
+			p.downloadDir = filepath.Dir(pkgOpts.PackageSource)
+		}
+		if err != nil {
+			return append(planned, p), fmt.Errorf("unable to load the package %s: %w", packageSource, err)
+		}
+		planned = append(planned, p)
+	}
+	return planned, nil
+}
+
+// removeDownloads removes the tarballs downloaded for the URL sources of the packages.
+func removeDownloads(planned []plannedPackage) {
+	for _, p := range planned {
+		if p.downloadDir != "" {
+			os.RemoveAll(p.downloadDir)
+		}
+	}
+}
+
+// orderPackages sorts the packages so that every package comes after the packages in the set that it depends on.
+// Packages without dependencies between each other keep the order they were given in.
+func orderPackages(planned []plannedPackage) ([]plannedPackage, error) {
+	byName := map[string]int{}
+	for i, p := range planned {
+		name := p.pkg.Metadata.Name
+		if _, ok := byName[name]; ok {
+			return nil, fmt.Errorf("package %s was provided more than once", name)
+		}
+		byName[name] = i
+	}
+
+	ordered := []plannedPackage{}
+	done := map[string]bool{}
+	for len(ordered) < len(planned) {
+		progressed := false
+		for _, p := range planned {
+			if done[p.pkg.Metadata.Name] {
+				continue
+			}
+			ready := true
+			for _, dependency := range p.pkg.Metadata.DependsOn {
+				if _, inSet := byName[dependency.Name]; inSet && !done[dependency.Name] {
+					ready = false
+					break
+				}
+			}
+			if !ready {
+				continue
+			}
+			ordered = append(ordered, p)
+			done[p.pkg.Metadata.Name] = true
+			progressed = true
+		}
+		if !progressed {
+			remaining := []string{}
+			for _, p := range planned {
+				if !done[p.pkg.Metadata.Name] {
+					remaining = append(remaining, p.pkg.Metadata.Name)
+				}
+			}
+			return nil, fmt.Errorf("packages %s have circular dependencies", strings.Join(remaining, ", "))
+		}
+	}
+	return ordered, nil
+}
+
+// hasExternalDependencies returns whether any package depends on a package outside of the set.
+func hasExternalDependencies(planned []plannedPackage) bool {
+	names := []string{}
+	for _, p := range planned {
+		names = append(names, p.pkg.Metadata.Name)
+	}
+	for _, p := range planned {
+		for _, dependency := range p.pkg.Metadata.DependsOn {
+			if !slices.Contains(names, dependency.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requiresCluster returns whether any package of the set is deployed to a cluster, where it can have dependents.
+func requiresCluster(planned []plannedPackage) bool {
+	for _, p := range planned {
+		for _, component := range p.pkg.Components {
+			if component.RequiresCluster() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkPlannedDependents checks that the packages of the set satisfy the deployed packages that depend on them.
+// Deployed packages that are part of the set are replaced, so only their new dependencies are checked.
+func checkPlannedDependents(planned []plannedPackage, deployedPackages []types.DeployedPackage) error {
+	names := []string{}
+	for _, p := range planned {
+		names = append(names, p.pkg.Metadata.Name)
+	}
+	remaining := []types.DeployedPackage{}
+	for _, deployedPackage := range deployedPackages {
+		if !slices.Contains(names, deployedPackage.Name) {
+			remaining = append(remaining, deployedPackage)
+		}
+	}
+	var err error
+	for _, p := range planned {
+		if depErr := checkDependentPackages(p.pkg, remaining); depErr != nil {
+			err = errors.Join(err, depErr)
+		}
+	}
+	return err
+}
+
+// checkPlannedDependencies checks the dependencies of the ordered packages against the deployed packages and the
+// packages that are deployed before them, which replace deployed packages of the same name.
+func checkPlannedDependencies(planned []plannedPackage, deployedPackages []types.DeployedPackage) error {
+	available := map[string]types.DeployedPackage{}
+	for _, deployedPackage := range deployedPackages {
+		available[deployedPackage.Name] = deployedPackage
+	}
+
+	var err error
+	for _, p := range planned {
+		availablePackages := []types.DeployedPackage{}
+		for _, deployedPackage := range available {
+			availablePackages = append(availablePackages, deployedPackage)
+		}
+		if depErr := checkPackageDependencies(p.pkg, availablePackages); depErr != nil {
+			err = errors.Join(err, depErr)
+		}
+		available[p.pkg.Metadata.Name] = types.DeployedPackage{Name: p.pkg.Metadata.Name, Data: p.pkg}
+	}
+	return err
+}
+
+// confirmPackages prints the order the packages are deployed in and prompts once for the whole set.
+func confirmPackages(planned []plannedPackage) bool {
+	message.HeaderInfof("📦 PACKAGE DEPLOYMENT ORDER")
+	header := []string{"#", "Package", "Version", "Depends On", "Source"}
+	rows := [][]string{}
+	for i, p := range planned {
+		dependsOn := []string{}
+		for _, dependency := range p.pkg.Metadata.DependsOn {
+			dependsOn = append(dependsOn, strings.TrimSpace(dependency.Name+" "+dependency.Version))
+		}
+		rows = append(rows, []string{fmt.Sprint(i + 1), p.pkg.Metadata.Name, p.pkg.Metadata.Version, strings.Join(dependsOn, ", "), p.source})
+	}
+	message.Table(header, rows)
+	message.HorizontalRule()
+
+	if config.CommonOptions.Confirm {
+		pterm.Println()
+		message.Successf("Deployment of %d Zarf packages confirmed", len(planned))
+		return true
+	}
+
+	confirm := false
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("Deploy these %d Zarf packages?", len(planned)),
+	}
+	pterm.Println()
+	if err := survey.AskOne(prompt, &confirm); err != nil {
+		return false
+	}
+	return confirm
+}
+
+// deployPlannedPackage deploys a single package of the set with its own copy of the packager config.
+func deployPlannedPackage(ctx context.Context, cfg types.PackagerConfig, packageSource string) ([]string, error) {
+	cfg.PkgOpts.PackageSource = packageSource
+	// The dependents of the package were checked against the whole set
+	cfg.DeployOpts.SkipDependentsCheck = true
+	// The set was confirmed as a whole so each package deploys without prompting again
+	pkgClient, err := New(&cfg, WithConfirm())
+	if err != nil {
+		return nil, err
+	}
+	defer pkgClient.ClearTempPaths()
+
+	if err := pkgClient.Deploy(ctx); err != nil {
+		return nil, err
+	}
+	// Deploy narrows the package components down to the ones that were selected for deployment
+	components := []string{}
+	for _, component := range cfg.Pkg.Components {
+		components = append(components, component.Name)
+	}
+	return components, nil
+}
+
+// printPackagesSummary prints the outcome of every package of a multi-package deployment.
+func printPackagesSummary(planned []plannedPackage) {
+	message.HeaderInfof("📦 DEPLOYMENT SUMMARY")
+	header := []string{"Package", "Version", "Status", "Components"}
+	rows := [][]string{}
+	for _, p := range planned {
+		rows = append(rows, []string{p.pkg.Metadata.Name, p.pkg.Metadata.Version, p.status, strings.Join(p.components, ", ")})
+	}
+	message.Table(header, rows)
+}
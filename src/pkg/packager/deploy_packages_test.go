@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func testPlannedPackage(name, version string, dependsOn ...v1alpha1.ZarfPackageDependency) plannedPackage {
+	return plannedPackage{
+		source: "zarf-package-" + name + ".tar.zst",
+		pkg:    testDeployedPackage(name, version, dependsOn...).Data,
+	}
+}
+
+func plannedNames(planned []plannedPackage) []string {
+	names := []string{}
+	for _, p := range planned {
+		names = append(names, p.pkg.Metadata.Name)
+	}
+	return names
+}
+
+func TestOrderPackages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		planned       []plannedPackage
+		expectedOrder []string
+		expectedErr   string
+	}{
+		{
+			name: "independent packages keep their order",
+			planned: []plannedPackage{
+				testPlannedPackage("b", "1.0.0"),
+				testPlannedPackage("a", "1.0.0"),
+			},
+			expectedOrder: []string{"b", "a"},
+		},
+		{
+			name: "dependencies are deployed first",
+			planned: []plannedPackage{
+				testPlannedPackage("apps", "1.0.0", v1alpha1.ZarfPackageDependency{Name: "core"}, v1alpha1.ZarfPackageDependency{Name: "init"}),
+				testPlannedPackage("core", "1.4.2", v1alpha1.ZarfPackageDependency{Name: "init"}),
+				testPlannedPackage("monitoring", "2.0.0"),
+			},
+			expectedOrder: []string{"core", "monitoring", "apps"},
+		},
+		{
+			name: "duplicate packages",
+			planned: []plannedPackage{
+				testPlannedPackage("core", "1.4.2"),
+				testPlannedPackage("core", "1.5.0"),
+			},
+			expectedErr: "package core was provided more than once",
+		},
+		{
+			name: "circular dependencies",
+			planned: []plannedPackage{
+				testPlannedPackage("init", "v0.36.0"),
+				testPlannedPackage("core", "1.4.2", v1alpha1.ZarfPackageDependency{Name: "apps"}),
+				testPlannedPackage("apps", "1.0.0", v1alpha1.ZarfPackageDependency{Name: "core"}),
+			},
+			expectedErr: "packages core, apps have circular dependencies",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ordered, err := orderPackages(tt.planned)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedOrder, plannedNames(ordered))
+		})
+	}
+}
+
+func TestCheckPlannedDependencies(t *testing.T) {
+	t.Parallel()
+
+	deployedPackages := []types.DeployedPackage{
+		testDeployedPackage("init", "v0.36.0"),
+		testDeployedPackage("core", "1.3.0"),
+	}
+
+	planned := []plannedPackage{
+		testPlannedPackage("core", "1.4.2", v1alpha1.ZarfPackageDependency{Name: "init", Version: ">= 0.36.0"}),
+		testPlannedPackage("apps", "1.0.0", v1alpha1.ZarfPackageDependency{Name: "core", Version: "~1.4"}),
+	}
+	require.True(t, hasExternalDependencies(planned))
+	require.False(t, hasExternalDependencies(append([]plannedPackage{testPlannedPackage("init", "v0.36.0")}, planned...)))
+	err := checkPlannedDependencies(planned, deployedPackages)
+	require.NoError(t, err)
+
+	planned = []plannedPackage{
+		testPlannedPackage("apps", "1.0.0", v1alpha1.ZarfPackageDependency{Name: "core", Version: "~1.4"}),
+		testPlannedPackage("monitoring", "1.0.0", v1alpha1.ZarfPackageDependency{Name: "gitea"}),
+	}
+	err = checkPlannedDependencies(planned, deployedPackages)
+	require.ErrorContains(t, err, "package apps depends on package core ~1.4 which version 1.3.0 does not satisfy")
+	require.ErrorContains(t, err, "package monitoring depends on package gitea which is not deployed")
+}
+
+func TestCheckPlannedDependents(t *testing.T) {
+	t.Parallel()
+
+	deployedPackages := []types.DeployedPackage{
+		testDeployedPackage("core", "1.4.2"),
+		testDeployedPackage("apps", "1.0.0", v1alpha1.ZarfPackageDependency{Name: "core", Version: "~1.4"}),
+		testDeployedPackage("tools", "1.0.0", v1alpha1.ZarfPackageDependency{Name: "core", Version: ">= 1.0.0"}),
+	}
+
+	// Upgrading a dependent in the same set replaces its constraint
+	planned := []plannedPackage{
+		testPlannedPackage("core", "2.0.0"),
+		testPlannedPackage("apps", "2.0.0", v1alpha1.ZarfPackageDependency{Name: "core", Version: "~2.0"}),
+	}
+	err := checkPlannedDependents(planned, deployedPackages)
+	require.NoError(t, err)
+
+	planned = []plannedPackage{testPlannedPackage("core", "2.0.0")}
+	err = checkPlannedDependents(planned, deployedPackages)
+	require.EqualError(t, err, "version 2.0.0 of package core does not satisfy the deployed packages that depend on it: package apps depends on package core ~1.4 which version 2.0.0 does not satisfy")
+}
+
+func TestLoadPlannedPackagesSplitTarball(t *testing.T) {
+	t.Parallel()
+
+	// Split the test package into parts the way zarf package create does
+	b, err := os.ReadFile(filepath.Join("sources", "testdata", "zarf-package-wordpress-amd64-16.0.4.tar.zst"))
+	require.NoError(t, err)
+	dir := t.TempDir()
+	tarPath := filepath.Join(dir, "zarf-package-wordpress-amd64-16.0.4.tar.zst")
+	err = os.WriteFile(tarPath, b, helpers.ReadWriteUser)
+	require.NoError(t, err)
+	shasum, err := helpers.GetSHA256OfFile(tarPath)
+	require.NoError(t, err)
+	err = os.Remove(tarPath)
+	require.NoError(t, err)
+	chunkSize := len(b)/2 + 1
+	for i := 0; i*chunkSize < len(b); i++ {
+		chunk := b[i*chunkSize : min((i+1)*chunkSize, len(b))]
+		err = os.WriteFile(fmt.Sprintf("%s.part%03d", tarPath, i+1), chunk, helpers.ReadWriteUser)
+		require.NoError(t, err)
+	}
+	splitData, err := json.Marshal(types.ZarfSplitPackageData{Sha256Sum: shasum, Bytes: int64(len(b)), Count: 2})
+	require.NoError(t, err)
+	err = os.WriteFile(tarPath+".part000", splitData, helpers.ReadWriteUser)
+	require.NoError(t, err)
+
+	// The plan deploys the reassembled tarball as loading the metadata removes the parts
+	cfg := &types.PackagerConfig{}
+	planned, err := loadPlannedPackages(context.Background(), cfg, []string{tarPath + ".part000"})
+	require.NoError(t, err)
+	require.Len(t, planned, 1)
+	require.Equal(t, tarPath, planned[0].source)
+	require.Empty(t, planned[0].downloadDir)
+	require.Equal(t, "wordpress", planned[0].pkg.Metadata.Name)
+	require.NoFileExists(t, tarPath+".part000")
+	reassembledSHA, err := helpers.GetSHA256OfFile(planned[0].source)
+	require.NoError(t, err)
+	require.Equal(t, shasum, reassembledSHA)
+}
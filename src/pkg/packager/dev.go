@@ -12,6 +12,7 @@ import (
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/template"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/creator"
@@ -22,6 +23,8 @@ import (
 // DevDeploy creates + deploys a package in one shot
 func (p *Packager) DevDeploy(ctx context.Context) error {
 	config.CommonOptions.Confirm = true
+	p.confirm = true
+	p.variableConfig = template.NewZarfVariableConfig(true)
 	p.cfg.CreateOpts.SkipSBOM = !p.cfg.CreateOpts.NoYOLO
 
 	cwd, err := os.Getwd()
@@ -46,6 +49,7 @@ func (p *Packager) DevDeploy(ctx context.Context) error {
 
 	filter := filters.Combine(
 		filters.ByLocalOS(runtime.GOOS),
+		filters.ByArchitecture(func() ([]string, error) { return p.clusterArchitectures(ctx) }),
 		filters.ForDeploy(p.cfg.PkgOpts.OptionalComponents, false),
 	)
 	p.cfg.Pkg.Components, err = filter.Apply(p.cfg.Pkg)
@@ -69,7 +73,7 @@ func (p *Packager) DevDeploy(ctx context.Context) error {
 		}
 	}
 
-	if err := pc.Assemble(ctx, p.layout, p.cfg.Pkg.Components, p.cfg.Pkg.Metadata.Architecture); err != nil {
+	if err := pc.Assemble(ctx, p.layout, p.cfg.Pkg); err != nil {
 		return err
 	}
 
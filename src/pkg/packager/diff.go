@@ -0,0 +1,533 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/mholt/archiver/v3"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// Supported output formats for a package diff report.
+const (
+	DiffOutputText = "text"
+	DiffOutputJSON = "json"
+)
+
+// PackageDiff is a structured report of the differences between two Zarf packages.
+type PackageDiff struct {
+	Old        DiffPackageInfo `json:"old"`
+	New        DiffPackageInfo `json:"new"`
+	Components DiffSet         `json:"components"`
+	Images     DiffSet         `json:"images"`
+	Repos      DiffSet         `json:"repos"`
+	Charts     DiffSet         `json:"charts"`
+	Files      DiffSet         `json:"files"`
+	Variables  DiffSet         `json:"variables"`
+	Constants  DiffSet         `json:"constants"`
+}
+
+// DiffPackageInfo identifies one side of a package diff.
+type DiffPackageInfo struct {
+	Name         string `json:"name"`
+	Version      string `json:"version,omitempty"`
+	Architecture string `json:"architecture,omitempty"`
+}
+
+// DiffSet contains the entries that were added, removed or changed for a single kind of package content.
+type DiffSet struct {
+	Added   []DiffEntry `json:"added,omitempty"`
+	Removed []DiffEntry `json:"removed,omitempty"`
+	Changed []DiffEntry `json:"changed,omitempty"`
+}
+
+// DiffEntry describes a single difference between two packages.
+type DiffEntry struct {
+	Name string `json:"name"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// IsEmpty returns true if the set contains no differences.
+func (ds DiffSet) IsEmpty() bool {
+	return len(ds.Added) == 0 && len(ds.Removed) == 0 && len(ds.Changed) == 0
+}
+
+// HasChanges returns true if any content differs between the two packages.
+func (pd PackageDiff) HasChanges() bool {
+	for _, set := range pd.sets() {
+		if !set.value.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+type namedDiffSet struct {
+	title string
+	value DiffSet
+}
+
+func (pd PackageDiff) sets() []namedDiffSet {
+	return []namedDiffSet{
+		{"Components", pd.Components},
+		{"Images", pd.Images},
+		{"Repos", pd.Repos},
+		{"Charts", pd.Charts},
+		{"Files", pd.Files},
+		{"Variables", pd.Variables},
+		{"Constants", pd.Constants},
+	}
+}
+
+// Diff compares the package source against the base package source and prints a report of the differences.
+func (p *Packager) Diff(ctx context.Context) (PackageDiff, error) {
+	switch p.cfg.DiffOpts.OutputFormat {
+	case "", DiffOutputText, DiffOutputJSON:
+	default:
+		return PackageDiff{}, fmt.Errorf("unsupported output format %q, must be one of %s or %s", p.cfg.DiffOpts.OutputFormat, DiffOutputText, DiffOutputJSON)
+	}
+
+	newPkg, _, err := p.source.LoadPackageMetadata(ctx, p.layout, false, true)
+	if err != nil {
+		return PackageDiff{}, fmt.Errorf("unable to load the package %s: %w", p.cfg.PkgOpts.PackageSource, err)
+	}
+	newTarget, err := loadDiffTarget(ctx, p.source, p.layout, newPkg)
+	if err != nil {
+		return PackageDiff{}, fmt.Errorf("unable to load the content of the package %s: %w", p.cfg.PkgOpts.PackageSource, err)
+	}
+
+	oldTarget, err := p.loadBasePackage(ctx)
+	if err != nil {
+		return PackageDiff{}, fmt.Errorf("unable to load the package %s: %w", p.cfg.DiffOpts.BasePackageSource, err)
+	}
+
+	diff, err := diffPackages(oldTarget, newTarget)
+	if err != nil {
+		return PackageDiff{}, err
+	}
+
+	if p.cfg.DiffOpts.OutputFormat == DiffOutputJSON {
+		b, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return PackageDiff{}, err
+		}
+		fmt.Fprintln(os.Stdout, string(b))
+	} else {
+		printPackageDiff(os.Stdout, diff)
+	}
+
+	return diff, nil
+}
+
+// loadBasePackage loads the metadata and content digests of the base package from its own temporary layout.
+func (p *Packager) loadBasePackage(ctx context.Context) (diffTarget, error) {
+	tmpdir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return diffTarget{}, err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	src, err := sources.New(&types.ZarfPackageOptions{
+		PackageSource: p.cfg.DiffOpts.BasePackageSource,
+		PublicKeyPath: p.cfg.PkgOpts.PublicKeyPath,
+	})
+	if err != nil {
+		return diffTarget{}, err
+	}
+
+	dst := layout.New(tmpdir)
+	pkg, _, err := src.LoadPackageMetadata(ctx, dst, false, true)
+	if err != nil {
+		return diffTarget{}, err
+	}
+	return loadDiffTarget(ctx, src, dst, pkg)
+}
+
+// diffTarget is one side of a package diff, the package definition and the digests of the content it contains.
+type diffTarget struct {
+	pkg v1alpha1.ZarfPackage
+	// images maps each image reference to the digest of its manifest in the package
+	images map[string]string
+	// values maps each component chart to the digests of the contents of its values files
+	values map[string][]string
+}
+
+// loadDiffTarget loads the image index and the chart values files of a package so that changed content is reported
+// even when image tags and values file paths stay the same. Sources that cannot load individual paths, like packages
+// in the cluster, are only compared by their definition.
+func loadDiffTarget(ctx context.Context, src sources.PackageSource, dst *layout.PackagePaths, pkg v1alpha1.ZarfPackage) (diffTarget, error) {
+	target := diffTarget{pkg: pkg, images: map[string]string{}, values: map[string][]string{}}
+	loader, ok := src.(sources.PathLoader)
+	if !ok || dst.IsLegacyLayout() {
+		return target, nil
+	}
+
+	paths := []string{}
+	for _, component := range pkg.Components {
+		if len(component.Images) > 0 {
+			paths = append(paths, filepath.ToSlash(layout.IndexPath))
+		}
+		for _, chart := range component.Charts {
+			if len(chart.ValuesFiles) > 0 {
+				paths = append(paths, filepath.ToSlash(filepath.Join(layout.ComponentsDir, pkg.ComponentKey(component)+".tar")))
+				break
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return target, nil
+	}
+	if err := loader.LoadPackagePaths(ctx, dst, helpers.Unique(paths)); err != nil {
+		return diffTarget{}, err
+	}
+	if err := sources.ValidatePackageIntegrity(dst, pkg.Metadata.AggregateChecksum, true); err != nil {
+		return diffTarget{}, err
+	}
+
+	if dst.Images.Index != "" {
+		b, err := os.ReadFile(dst.Images.Index)
+		if err != nil {
+			return diffTarget{}, err
+		}
+		var index ocispec.Index
+		if err := json.Unmarshal(b, &index); err != nil {
+			return diffTarget{}, fmt.Errorf("unable to parse the image index: %w", err)
+		}
+		for _, manifest := range index.Manifests {
+			if name := manifest.Annotations[ocispec.AnnotationBaseImageName]; name != "" {
+				target.images[name] = manifest.Digest.String()
+			}
+		}
+	}
+
+	for _, component := range pkg.Components {
+		componentKey := pkg.ComponentKey(component)
+		tb, ok := dst.Components.Tarballs[componentKey]
+		if !ok {
+			continue
+		}
+		for _, chart := range component.Charts {
+			key := fmt.Sprintf("%s/%s", componentKey, chart.Name)
+			for idx := range chart.ValuesFiles {
+				rel := filepath.ToSlash(helm.StandardValuesName(filepath.Join(componentKey, layout.ValuesDir), chart, idx))
+				if err := archiver.Extract(tb, rel, dst.Components.Base); err != nil {
+					return diffTarget{}, fmt.Errorf("unable to extract the values file %s: %w", rel, err)
+				}
+				sha, err := helpers.GetSHA256OfFile(filepath.Join(dst.Components.Base, rel))
+				if err != nil {
+					return diffTarget{}, err
+				}
+				target.values[key] = append(target.values[key], "sha256:"+sha)
+			}
+		}
+	}
+	return target, nil
+}
+
+// diffPackages builds a report of the differences between the old and new package.
+func diffPackages(oldTarget, newTarget diffTarget) (PackageDiff, error) {
+	oldPkg, newPkg := oldTarget.pkg, newTarget.pkg
+	diff := PackageDiff{
+		Old: DiffPackageInfo{Name: oldPkg.Metadata.Name, Version: oldPkg.Metadata.Version, Architecture: oldPkg.Build.Architecture},
+		New: DiffPackageInfo{Name: newPkg.Metadata.Name, Version: newPkg.Metadata.Version, Architecture: newPkg.Build.Architecture},
+	}
+
+	oldComponents := map[string]v1alpha1.ZarfComponent{}
+	for _, component := range oldPkg.Components {
+		oldComponents[component.Name] = component
+	}
+	newComponents := map[string]v1alpha1.ZarfComponent{}
+	for _, component := range newPkg.Components {
+		newComponents[component.Name] = component
+	}
+	for name, component := range newComponents {
+		old, ok := oldComponents[name]
+		if !ok {
+			diff.Components.Added = append(diff.Components.Added, DiffEntry{Name: name})
+			continue
+		}
+		if !reflect.DeepEqual(old, component) {
+			diff.Components.Changed = append(diff.Components.Changed, DiffEntry{Name: name})
+		}
+	}
+	for name := range oldComponents {
+		if _, ok := newComponents[name]; !ok {
+			diff.Components.Removed = append(diff.Components.Removed, DiffEntry{Name: name})
+		}
+	}
+	sortDiffSet(&diff.Components)
+
+	oldImages, err := imageVersions(oldTarget)
+	if err != nil {
+		return PackageDiff{}, err
+	}
+	newImages, err := imageVersions(newTarget)
+	if err != nil {
+		return PackageDiff{}, err
+	}
+	diff.Images = diffMaps(oldImages, newImages)
+
+	oldRepos, err := repoVersions(oldPkg)
+	if err != nil {
+		return PackageDiff{}, err
+	}
+	newRepos, err := repoVersions(newPkg)
+	if err != nil {
+		return PackageDiff{}, err
+	}
+	diff.Repos = diffMaps(oldRepos, newRepos)
+
+	diff.Charts = diffMaps(chartVersions(oldTarget), chartVersions(newTarget))
+	diff.Files = diffMaps(fileVersions(oldPkg), fileVersions(newPkg))
+	diff.Variables = diffVariables(oldPkg, newPkg)
+	diff.Constants = diffMaps(constantValues(oldPkg), constantValues(newPkg))
+
+	return diff, nil
+}
+
+// imageVersions maps each image name in a package to the tags and digests it is referenced by, tags are followed by
+// the digest of the manifest stored in the package so that changed images with the same tag are reported.
+func imageVersions(target diffTarget) (map[string]string, error) {
+	versions := map[string][]string{}
+	for _, component := range target.pkg.Components {
+		for _, image := range component.Images {
+			ref, err := transform.ParseImageRef(image)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse image ref %s: %w", image, err)
+			}
+			version := ref.Tag
+			if ref.Digest != "" {
+				version = ref.Digest
+				if ref.Tag != "" {
+					version = fmt.Sprintf("%s@%s", ref.Tag, ref.Digest)
+				}
+			} else if digest, ok := imageDigest(target.images, ref); ok {
+				version = fmt.Sprintf("%s@%s", ref.Tag, digest)
+			}
+			versions[ref.Name] = append(versions[ref.Name], version)
+		}
+	}
+	return joinVersions(versions), nil
+}
+
+// imageDigest returns the digest of the manifest stored in the package for an image ref.
+func imageDigest(images map[string]string, ref transform.Image) (string, bool) {
+	if digest, ok := images[ref.Reference]; ok {
+		return digest, true
+	}
+	// A backwards compatibility shim for older Zarf versions that would leave docker.io off of image annotations
+	if ref.Host == "docker.io" {
+		digest, ok := images[ref.Path+ref.TagOrDigest]
+		return digest, ok
+	}
+	return "", false
+}
+
+// repoVersions maps each git repository in a package to the refs it is referenced by.
+func repoVersions(pkg v1alpha1.ZarfPackage) (map[string]string, error) {
+	versions := map[string][]string{}
+	for _, component := range pkg.Components {
+		for _, repo := range component.Repos {
+			url, ref, err := transform.GitURLSplitRef(repo)
+			if err != nil {
+				return nil, err
+			}
+			versions[url] = append(versions[url], ref)
+		}
+	}
+	return joinVersions(versions), nil
+}
+
+// chartVersions maps each component chart to its version, source and values, values files are compared by the
+// digests of their contents where the package content was loaded and by their paths otherwise.
+func chartVersions(target diffTarget) map[string]string {
+	charts := map[string]string{}
+	for _, component := range target.pkg.Components {
+		for _, chart := range component.Charts {
+			source := chart.URL
+			if source == "" {
+				source = chart.LocalPath
+			}
+			key := fmt.Sprintf("%s/%s", component.Name, chart.Name)
+			desc := fmt.Sprintf("%s (%s)", chart.Version, source)
+			if digests, ok := target.values[key]; ok {
+				desc = fmt.Sprintf("%s values=%s", desc, strings.Join(digests, ","))
+			} else if len(chart.ValuesFiles) > 0 {
+				desc = fmt.Sprintf("%s values=%s", desc, strings.Join(chart.ValuesFiles, ","))
+			}
+			if len(chart.Variables) > 0 {
+				paths := []string{}
+				for _, variable := range chart.Variables {
+					paths = append(paths, fmt.Sprintf("%s=%s", variable.Name, variable.Path))
+				}
+				desc = fmt.Sprintf("%s variables=%s", desc, strings.Join(paths, ","))
+			}
+			charts[key] = desc
+		}
+	}
+	return charts
+}
+
+// fileVersions maps each component file target to its shasum (or source if no shasum was provided).
+func fileVersions(pkg v1alpha1.ZarfPackage) map[string]string {
+	files := map[string]string{}
+	for _, component := range pkg.Components {
+		for _, file := range component.Files {
+			version := file.Shasum
+			if version == "" {
+				version = file.Source
+			}
+			files[fmt.Sprintf("%s/%s", pkg.ComponentKey(component), file.Target)] = version
+		}
+	}
+	return files
+}
+
+// diffVariables compares the package variables. Sensitive defaults are compared by their digests and only reported as
+// changed, never printed.
+func diffVariables(oldPkg, newPkg v1alpha1.ZarfPackage) DiffSet {
+	set := diffMaps(variableValues(oldPkg, sensitiveDigest), variableValues(newPkg, sensitiveDigest))
+	oldValues := variableValues(oldPkg, sanitize)
+	newValues := variableValues(newPkg, sanitize)
+	for i, entry := range set.Added {
+		set.Added[i].New = newValues[entry.Name]
+	}
+	for i, entry := range set.Removed {
+		set.Removed[i].Old = oldValues[entry.Name]
+	}
+	for i, entry := range set.Changed {
+		set.Changed[i].Old = oldValues[entry.Name]
+		set.Changed[i].New = newValues[entry.Name]
+		if set.Changed[i].Old == set.Changed[i].New {
+			set.Changed[i].New = variableValues(newPkg, func(string) string { return "**sanitized** (changed)" })[entry.Name]
+		}
+	}
+	return set
+}
+
+// variableValues maps each package variable to its default value, replacing the defaults of sensitive variables.
+func variableValues(pkg v1alpha1.ZarfPackage, redact func(string) string) map[string]string {
+	variables := map[string]string{}
+	for _, variable := range pkg.Variables {
+		value := variable.Default
+		if variable.Sensitive && value != "" {
+			value = redact(value)
+		}
+		variables[variable.Name] = fmt.Sprintf("default=%q prompt=%t", value, variable.Prompt)
+	}
+	return variables
+}
+
+func sensitiveDigest(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func sanitize(string) string {
+	return "**sanitized**"
+}
+
+// constantValues maps each package constant to its value.
+func constantValues(pkg v1alpha1.ZarfPackage) map[string]string {
+	constants := map[string]string{}
+	for _, constant := range pkg.Constants {
+		constants[constant.Name] = constant.Value
+	}
+	return constants
+}
+
+func joinVersions(versions map[string][]string) map[string]string {
+	joined := map[string]string{}
+	for name, list := range versions {
+		list = helpers.Unique(list)
+		slices.Sort(list)
+		joined[name] = strings.Join(list, ", ")
+	}
+	return joined
+}
+
+// diffMaps compares two name to value maps and returns the added, removed and changed entries.
+func diffMaps(oldMap, newMap map[string]string) DiffSet {
+	set := DiffSet{}
+	for name, newValue := range newMap {
+		oldValue, ok := oldMap[name]
+		if !ok {
+			set.Added = append(set.Added, DiffEntry{Name: name, New: newValue})
+			continue
+		}
+		if oldValue != newValue {
+			set.Changed = append(set.Changed, DiffEntry{Name: name, Old: oldValue, New: newValue})
+		}
+	}
+	for name, oldValue := range oldMap {
+		if _, ok := newMap[name]; !ok {
+			set.Removed = append(set.Removed, DiffEntry{Name: name, Old: oldValue})
+		}
+	}
+	sortDiffSet(&set)
+	return set
+}
+
+func sortDiffSet(set *DiffSet) {
+	byName := func(a, b DiffEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	}
+	slices.SortFunc(set.Added, byName)
+	slices.SortFunc(set.Removed, byName)
+	slices.SortFunc(set.Changed, byName)
+}
+
+// printPackageDiff writes a human readable version of the diff report.
+func printPackageDiff(w io.Writer, diff PackageDiff) {
+	fmt.Fprintf(w, "--- %s %s\n", diff.Old.Name, diff.Old.Version)
+	fmt.Fprintf(w, "+++ %s %s\n", diff.New.Name, diff.New.Version)
+
+	if !diff.HasChanges() {
+		fmt.Fprintln(w, "No differences found")
+		return
+	}
+
+	for _, set := range diff.sets() {
+		if set.value.IsEmpty() {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n", set.title)
+		for _, entry := range set.value.Added {
+			fmt.Fprintln(w, strings.TrimRight(fmt.Sprintf("  + %s %s", entry.Name, entry.New), " "))
+		}
+		for _, entry := range set.value.Removed {
+			fmt.Fprintln(w, strings.TrimRight(fmt.Sprintf("  - %s %s", entry.Name, entry.Old), " "))
+		}
+		for _, entry := range set.value.Changed {
+			if entry.Old == "" && entry.New == "" {
+				fmt.Fprintf(w, "  ~ %s\n", entry.Name)
+				continue
+			}
+			fmt.Fprintf(w, "  ~ %s %s -> %s\n", entry.Name, entry.Old, entry.New)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/mholt/archiver/v3"
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestDiffPackages(t *testing.T) {
+	t.Parallel()
+
+	oldPkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "1.0.0"},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name: "web",
+				Images: []string{
+					"ghcr.io/example/web:1.0.0",
+					"ghcr.io/example/removed:1.0.0",
+				},
+				Repos: []string{"https://github.com/example/web.git@v1.0.0"},
+				Charts: []v1alpha1.ZarfChart{
+					{Name: "web", Version: "1.0.0", URL: "oci://ghcr.io/example/charts/web"},
+				},
+				Files: []v1alpha1.ZarfFile{
+					{Source: "https://example.com/file", Target: "file", Shasum: "abc"},
+				},
+			},
+			{
+				Name: "old",
+			},
+		},
+		Variables: []v1alpha1.InteractiveVariable{
+			{Variable: v1alpha1.Variable{Name: "DOMAIN"}, Default: "old.dev"},
+			{Variable: v1alpha1.Variable{Name: "PASSWORD", Sensitive: true}, Default: "hunter2"},
+		},
+		Constants: []v1alpha1.Constant{{Name: "REPLICAS", Value: "1"}},
+	}
+	newPkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "1.1.0"},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name: "web",
+				Images: []string{
+					"ghcr.io/example/web@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+					"ghcr.io/example/added:1.0.0",
+				},
+				Repos: []string{"https://github.com/example/web.git@v1.1.0"},
+				Charts: []v1alpha1.ZarfChart{
+					{Name: "web", Version: "1.0.0", URL: "oci://ghcr.io/example/charts/web", ValuesFiles: []string{"values.yaml"}},
+				},
+				Files: []v1alpha1.ZarfFile{
+					{Source: "https://example.com/file", Target: "file", Shasum: "abc"},
+				},
+			},
+			{
+				Name: "new",
+			},
+		},
+		Variables: []v1alpha1.InteractiveVariable{
+			{Variable: v1alpha1.Variable{Name: "DOMAIN"}, Default: "new.dev"},
+			{Variable: v1alpha1.Variable{Name: "PASSWORD", Sensitive: true}, Default: "hunter3"},
+		},
+		Constants: []v1alpha1.Constant{{Name: "REPLICAS", Value: "1"}},
+	}
+
+	diff, err := diffPackages(diffTarget{pkg: oldPkg}, diffTarget{pkg: newPkg})
+	require.NoError(t, err)
+	require.True(t, diff.HasChanges())
+
+	require.Equal(t, DiffPackageInfo{Name: "test", Version: "1.0.0"}, diff.Old)
+	require.Equal(t, DiffPackageInfo{Name: "test", Version: "1.1.0"}, diff.New)
+
+	require.Equal(t, []DiffEntry{{Name: "new"}}, diff.Components.Added)
+	require.Equal(t, []DiffEntry{{Name: "old"}}, diff.Components.Removed)
+	require.Equal(t, []DiffEntry{{Name: "web"}}, diff.Components.Changed)
+
+	require.Equal(t, []DiffEntry{{Name: "ghcr.io/example/added", New: "1.0.0"}}, diff.Images.Added)
+	require.Equal(t, []DiffEntry{{Name: "ghcr.io/example/removed", Old: "1.0.0"}}, diff.Images.Removed)
+	require.Equal(t, []DiffEntry{{
+		Name: "ghcr.io/example/web",
+		Old:  "1.0.0",
+		New:  "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	}}, diff.Images.Changed)
+
+	require.Equal(t, []DiffEntry{{Name: "https://github.com/example/web.git", Old: "v1.0.0", New: "v1.1.0"}}, diff.Repos.Changed)
+
+	require.Len(t, diff.Charts.Changed, 1)
+	require.Equal(t, "web/web", diff.Charts.Changed[0].Name)
+
+	require.True(t, diff.Files.IsEmpty())
+	require.True(t, diff.Constants.IsEmpty())
+
+	// Sensitive variable defaults are reported as changed but never included in the report
+	require.Equal(t, []DiffEntry{
+		{Name: "DOMAIN", Old: `default="old.dev" prompt=false`, New: `default="new.dev" prompt=false`},
+		{Name: "PASSWORD", Old: `default="**sanitized**" prompt=false`, New: `default="**sanitized** (changed)" prompt=false`},
+	}, diff.Variables.Changed)
+
+	var buf bytes.Buffer
+	printPackageDiff(&buf, diff)
+	require.Contains(t, buf.String(), "  + new\n")
+	require.Contains(t, buf.String(), "  ~ https://github.com/example/web.git v1.0.0 -> v1.1.0\n")
+	require.NotContains(t, buf.String(), "hunter")
+}
+
+func TestDiffPackagesNoChanges(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "1.0.0"},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "web", Images: []string{"ghcr.io/example/web:1.0.0"}},
+		},
+	}
+
+	diff, err := diffPackages(diffTarget{pkg: pkg}, diffTarget{pkg: pkg})
+	require.NoError(t, err)
+	require.False(t, diff.HasChanges())
+
+	var buf bytes.Buffer
+	printPackageDiff(&buf, diff)
+	require.Contains(t, buf.String(), "No differences found")
+}
+
+func TestDiffPackagesContent(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "1.0.0"},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name:   "web",
+				Images: []string{"ghcr.io/example/web:1.0.0"},
+				Charts: []v1alpha1.ZarfChart{
+					{Name: "web", Version: "1.0.0", URL: "oci://ghcr.io/example/charts/web", ValuesFiles: []string{"values.yaml"}},
+				},
+			},
+		},
+	}
+	oldTarget := diffTarget{
+		pkg:    pkg,
+		images: map[string]string{"ghcr.io/example/web:1.0.0": "sha256:1111"},
+		values: map[string][]string{"web/web": {"sha256:aaaa"}},
+	}
+	newTarget := diffTarget{
+		pkg:    pkg,
+		images: map[string]string{"ghcr.io/example/web:1.0.0": "sha256:2222"},
+		values: map[string][]string{"web/web": {"sha256:bbbb"}},
+	}
+
+	// The same tag and values file path with different content are reported as changed
+	diff, err := diffPackages(oldTarget, newTarget)
+	require.NoError(t, err)
+	require.Equal(t, []DiffEntry{{
+		Name: "ghcr.io/example/web",
+		Old:  "1.0.0@sha256:1111",
+		New:  "1.0.0@sha256:2222",
+	}}, diff.Images.Changed)
+	require.Equal(t, []DiffEntry{{
+		Name: "web/web",
+		Old:  "1.0.0 (oci://ghcr.io/example/charts/web) values=sha256:aaaa",
+		New:  "1.0.0 (oci://ghcr.io/example/charts/web) values=sha256:bbbb",
+	}}, diff.Charts.Changed)
+
+	diff, err = diffPackages(oldTarget, oldTarget)
+	require.NoError(t, err)
+	require.False(t, diff.HasChanges())
+}
+
+func TestDiffPackagesLegacyDockerAnnotation(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "1.0.0"},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name:   "web",
+				Images: []string{"nginx:1.25"},
+			},
+		},
+	}
+	// Older Zarf versions left docker.io off of image annotations
+	legacyTarget := diffTarget{
+		pkg:    pkg,
+		images: map[string]string{"library/nginx:1.25": "sha256:1111"},
+	}
+	sameTarget := diffTarget{
+		pkg:    pkg,
+		images: map[string]string{"docker.io/library/nginx:1.25": "sha256:1111"},
+	}
+	changedTarget := diffTarget{
+		pkg:    pkg,
+		images: map[string]string{"docker.io/library/nginx:1.25": "sha256:2222"},
+	}
+
+	diff, err := diffPackages(legacyTarget, sameTarget)
+	require.NoError(t, err)
+	require.False(t, diff.HasChanges())
+
+	diff, err = diffPackages(legacyTarget, changedTarget)
+	require.NoError(t, err)
+	require.Equal(t, []DiffEntry{{
+		Name: "docker.io/library/nginx",
+		Old:  "1.25@sha256:1111",
+		New:  "1.25@sha256:2222",
+	}}, diff.Images.Changed)
+}
+
+func TestLoadDiffTarget(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Kind:     v1alpha1.ZarfPackageConfig,
+		Metadata: v1alpha1.ZarfMetadata{Name: "test", Version: "1.0.0"},
+		Build:    v1alpha1.ZarfBuildData{Architecture: "amd64"},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name:   "web",
+				Images: []string{"ghcr.io/example/web:1.0.0"},
+				Charts: []v1alpha1.ZarfChart{
+					{Name: "web", Version: "1.0.0", URL: "oci://ghcr.io/example/charts/web", ValuesFiles: []string{"values.yaml"}},
+				},
+			},
+		},
+	}
+
+	// Build a package tarball with an image index and a component with a chart values file
+	pkgDir := t.TempDir()
+	valuesPath := filepath.Join(pkgDir, layout.ComponentsDir, "web", layout.ValuesDir, "web-1.0.0-0")
+	err := helpers.CreatePathAndCopy(filepath.Join("testdata", "diff", "values.yaml"), valuesPath)
+	require.NoError(t, err)
+	componentTarball := filepath.Join(pkgDir, layout.ComponentsDir, "web.tar")
+	err = archiver.Archive([]string{filepath.Join(pkgDir, layout.ComponentsDir, "web")}, componentTarball)
+	require.NoError(t, err)
+	err = os.RemoveAll(filepath.Join(pkgDir, layout.ComponentsDir, "web"))
+	require.NoError(t, err)
+	err = helpers.CreatePathAndCopy(filepath.Join("testdata", "diff", "index.json"), filepath.Join(pkgDir, layout.IndexPath))
+	require.NoError(t, err)
+
+	pp := layout.New(pkgDir)
+	pp.SetFromPaths([]string{layout.IndexPath, filepath.Join(layout.ComponentsDir, "web.tar")})
+	pkg.Metadata.AggregateChecksum, err = pp.GenerateChecksums()
+	require.NoError(t, err)
+	err = utils.WriteYaml(pp.ZarfYAML, pkg, helpers.ReadWriteUser)
+	require.NoError(t, err)
+	tarball := filepath.Join(t.TempDir(), "zarf-package-test-amd64-1.0.0.tar")
+	err = pp.ArchivePackage(tarball, 0)
+	require.NoError(t, err)
+
+	src := &sources.TarballSource{ZarfPackageOptions: &types.ZarfPackageOptions{PackageSource: tarball}}
+	dst := layout.New(t.TempDir())
+	loaded, _, err := src.LoadPackageMetadata(context.Background(), dst, false, true)
+	require.NoError(t, err)
+	target, err := loadDiffTarget(context.Background(), src, dst, loaded)
+	require.NoError(t, err)
+
+	valuesSHA, err := helpers.GetSHA256OfFile(filepath.Join("testdata", "diff", "values.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"ghcr.io/example/web:1.0.0": "sha256:0a2e3d8b6bce0e0e7a4ca4ffd9c0bfbd5e0c1b1bca47ab8ef6b0a9bbcdbdd1c4",
+	}, target.images)
+	require.Equal(t, map[string][]string{"web/web": {"sha256:" + valuesSHA}}, target.values)
+}
@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/images"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+// DeployPlan describes the changes a package deployment would make.
+type DeployPlan struct {
+	Components []ComponentPlan
+}
+
+// ComponentPlan describes the changes deploying a single component would make.
+type ComponentPlan struct {
+	Name           string
+	Actions        []string
+	Files          []string
+	Images         []PlannedPush
+	Repos          []PlannedPush
+	DataInjections []string
+	Charts         []helm.ChartPlan
+}
+
+// PlannedPush is an artifact that would be pushed from the package to a Zarf managed service.
+type PlannedPush struct {
+	Source  string
+	Targets []string
+}
+
+// dryRunDeploy walks the components of the package the same way deployComponents does and reports what would change.
+func (p *Packager) dryRunDeploy(ctx context.Context) error {
+	if p.cfg.Pkg.IsInitConfig() {
+		return errors.New("dry runs are not supported for init packages")
+	}
+
+	plan, err := p.planComponents(ctx)
+	if err != nil {
+		return err
+	}
+	if len(plan.Components) == 0 {
+		message.Warn("No components were selected for deployment.  Inspect the package to view the available components and select components interactively or by name with \"--components\"")
+	}
+
+	printDeployPlan(os.Stdout, plan)
+	message.Successf("Zarf dry run complete, no changes were made")
+
+	return nil
+}
+
+// planComponents loops through a list of ZarfComponents and plans their deployment without modifying the cluster.
+func (p *Packager) planComponents(ctx context.Context) (DeployPlan, error) {
+	plan := DeployPlan{}
+	for _, component := range p.cfg.Pkg.Components {
+		if component.RequiresCluster() {
+			connectCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+			defer cancel()
+			if err := p.connectToCluster(connectCtx); err != nil {
+				return plan, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+			}
+		}
+
+		componentPlan, err := p.planComponent(ctx, component)
+		if err != nil {
+			return plan, fmt.Errorf("unable to plan component %q: %w", component.Name, err)
+		}
+		plan.Components = append(plan.Components, componentPlan)
+	}
+	return plan, nil
+}
+
+// planComponent reports the steps deployComponent would take for a single component.
+func (p *Packager) planComponent(ctx context.Context, component v1alpha1.ZarfComponent) (ComponentPlan, error) {
+	componentPath := p.layout.Components.Dirs[p.cfg.Pkg.ComponentKey(component)]
+	onDeploy := component.Actions.OnDeploy
+
+	message.HeaderInfof("📦 %s COMPONENT (DRY RUN)", strings.ToUpper(component.Name))
+
+	plan := ComponentPlan{Name: component.Name}
+
+	if component.RequiresCluster() && p.state == nil {
+		if err := p.setupState(ctx); err != nil {
+			return plan, err
+		}
+	}
+
+	if err := p.populateComponentAndStateTemplates(component.Name); err != nil {
+		return plan, err
+	}
+
+	plan.Actions = append(plan.Actions, describeActions("before", onDeploy.Before)...)
+
+	for _, file := range component.Files {
+		target := strings.Replace(file.Target, "###ZARF_TEMP###", p.layout.Base, 1)
+		plan.Files = append(plan.Files, config.GetAbsHomePath(target))
+		for _, link := range file.Symlinks {
+			plan.Files = append(plan.Files, fmt.Sprintf("%s -> %s", link, config.GetAbsHomePath(target)))
+		}
+	}
+
+	// YOLO packages are deployed without a Zarf registry or git server so nothing is pushed
+	if !p.cfg.Pkg.Metadata.YOLO {
+		for _, src := range helpers.Unique(component.Images) {
+			refInfo, err := transform.ParseImageRef(src)
+			if err != nil {
+				return plan, fmt.Errorf("failed to create ref for image %s: %w", src, err)
+			}
+			targets, err := images.PushNames(p.state.RegistryInfo.Address, refInfo, false)
+			if err != nil {
+				return plan, fmt.Errorf("unable to transform image %s: %w", src, err)
+			}
+			plan.Images = append(plan.Images, PlannedPush{Source: src, Targets: targets})
+		}
+
+		for _, repoURL := range component.Repos {
+			target, err := transform.GitURL(p.state.GitServer.Address, repoURL, p.state.GitServer.PushUsername)
+			if err != nil {
+				return plan, fmt.Errorf("unable to transform repo %s: %w", repoURL, err)
+			}
+			plan.Repos = append(plan.Repos, PlannedPush{Source: repoURL, Targets: []string{target.String()}})
+		}
+	}
+
+	for _, data := range component.DataInjections {
+		injection := fmt.Sprintf("%s -> %s/%s (%s):%s", data.Source, data.Target.Namespace, data.Target.Selector, data.Target.Container, data.Target.Path)
+		if data.Target.IsPersistentVolumeClaim() {
+			injection = fmt.Sprintf("%s -> %s/pvc/%s:%s", data.Source, data.Target.Namespace, data.Target.PersistentVolumeClaim, data.Target.Path)
+		}
+		plan.DataInjections = append(plan.DataInjections, injection)
+	}
+
+	for _, chart := range component.Charts {
+		helmCfg, err := p.newChartHelm(componentPath, component, chart)
+		if err != nil {
+			return plan, err
+		}
+		chartPlan, err := helmCfg.PlanChart(ctx)
+		if err != nil {
+			return plan, err
+		}
+		plan.Charts = append(plan.Charts, chartPlan)
+	}
+
+	for _, manifest := range component.Manifests {
+		helmCfg, err := p.newManifestHelm(componentPath, component, manifest)
+		if err != nil {
+			return plan, err
+		}
+		chartPlan, err := helmCfg.PlanChart(ctx)
+		if err != nil {
+			return plan, err
+		}
+		plan.Charts = append(plan.Charts, chartPlan)
+	}
+
+	plan.Actions = append(plan.Actions, describeActions("after", onDeploy.After)...)
+	plan.Actions = append(plan.Actions, describeActions("onSuccess", onDeploy.OnSuccess)...)
+	plan.Actions = append(plan.Actions, describeActions("onFailure", onDeploy.OnFailure)...)
+
+	return plan, nil
+}
+
+// describeActions returns a human readable description of each action within an action stage.
+func describeActions(stage string, actions []v1alpha1.ZarfComponentAction) []string {
+	descriptions := []string{}
+	for _, action := range actions {
+		description := action.Description
+		switch {
+		case description != "":
+		case action.Wait != nil && action.Wait.Cluster != nil:
+			wait := action.Wait.Cluster
+			description = fmt.Sprintf("wait for %s %s %s", wait.Kind, wait.Name, wait.Condition)
+		case action.Wait != nil && action.Wait.Network != nil:
+			wait := action.Wait.Network
+			description = fmt.Sprintf("wait for %s://%s", wait.Protocol, wait.Address)
+		default:
+			description = action.Cmd
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s", stage, strings.TrimSpace(description)))
+	}
+	return descriptions
+}
+
+// printDeployPlan writes a summary of the plan followed by the rendered manifests of every chart.
+func printDeployPlan(w io.Writer, plan DeployPlan) {
+	for _, component := range plan.Components {
+		fmt.Fprintf(w, "# Component: %s\n", component.Name)
+		printPlanSection(w, "Actions", component.Actions)
+		printPlanSection(w, "Files", component.Files)
+		printPlanSection(w, "Images", plannedPushes(component.Images))
+		printPlanSection(w, "Repos", plannedPushes(component.Repos))
+		printPlanSection(w, "Data injections", component.DataInjections)
+
+		namespaces := []string{}
+		charts := []string{}
+		for _, chart := range component.Charts {
+			charts = append(charts, fmt.Sprintf("%s/%s", chart.Namespace, chart.ReleaseName))
+			for _, namespace := range chart.CreatedNamespaces {
+				namespaces = append(namespaces, fmt.Sprintf("create %s", namespace))
+			}
+			for _, namespace := range chart.AdoptedNamespaces {
+				namespaces = append(namespaces, fmt.Sprintf("adopt %s", namespace))
+			}
+		}
+		printPlanSection(w, "Namespaces", helpers.Unique(namespaces))
+		printPlanSection(w, "Charts", charts)
+	}
+
+	for _, component := range plan.Components {
+		for _, chart := range component.Charts {
+			fmt.Fprintf(w, "---\n# Chart: %s/%s (component %s)\n", chart.Namespace, chart.ReleaseName, component.Name)
+			fmt.Fprintln(w, strings.TrimSpace(chart.Manifest))
+		}
+	}
+}
+
+func printPlanSection(w io.Writer, title string, entries []string) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "#   %s:\n", title)
+	for _, entry := range entries {
+		fmt.Fprintf(w, "#     - %s\n", entry)
+	}
+}
+
+func plannedPushes(pushes []PlannedPush) []string {
+	entries := []string{}
+	for _, push := range pushes {
+		entries = append(entries, fmt.Sprintf("%s -> %s", push.Source, strings.Join(push.Targets, ", ")))
+	}
+	return entries
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/template"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestDescribeActions(t *testing.T) {
+	t.Parallel()
+
+	actions := []v1alpha1.ZarfComponentAction{
+		{Cmd: "echo hello\n"},
+		{Cmd: "./secret-script.sh", Description: "Configure the database"},
+		{Wait: &v1alpha1.ZarfComponentActionWait{Cluster: &v1alpha1.ZarfComponentActionWaitCluster{Kind: "Pod", Name: "app=podinfo", Condition: "Ready"}}},
+		{Wait: &v1alpha1.ZarfComponentActionWait{Network: &v1alpha1.ZarfComponentActionWaitNetwork{Protocol: "https", Address: "example.com"}}},
+	}
+	expected := []string{
+		"before: echo hello",
+		"before: Configure the database",
+		"before: wait for Pod app=podinfo Ready",
+		"before: wait for https://example.com",
+	}
+	require.Equal(t, expected, describeActions("before", actions))
+	require.Empty(t, describeActions("after", nil))
+}
+
+func TestPrintDeployPlan(t *testing.T) {
+	t.Parallel()
+
+	plan := DeployPlan{
+		Components: []ComponentPlan{
+			{
+				Name:    "podinfo",
+				Actions: []string{"after: echo done"},
+				Images: []PlannedPush{{
+					Source:  "ghcr.io/stefanprodan/podinfo:6.4.0",
+					Targets: []string{"127.0.0.1:31999/stefanprodan/podinfo:6.4.0-zarf-2985051089", "127.0.0.1:31999/stefanprodan/podinfo:6.4.0"},
+				}},
+				Charts: []helm.ChartPlan{
+					{
+						ReleaseName:       "podinfo",
+						Namespace:         "podinfo",
+						Manifest:          "apiVersion: v1\nkind: Service\n",
+						CreatedNamespaces: []string{"podinfo"},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	printDeployPlan(&buf, plan)
+	out := buf.String()
+	require.Contains(t, out, "# Component: podinfo\n")
+	require.Contains(t, out, "#     - after: echo done\n")
+	require.Contains(t, out, "#     - ghcr.io/stefanprodan/podinfo:6.4.0 -> 127.0.0.1:31999/stefanprodan/podinfo:6.4.0-zarf-2985051089, 127.0.0.1:31999/stefanprodan/podinfo:6.4.0\n")
+	require.Contains(t, out, "#     - create podinfo\n")
+	require.Contains(t, out, "---\n# Chart: podinfo/podinfo (component podinfo)\napiVersion: v1\nkind: Service\n")
+	require.NotContains(t, out, "Repos:")
+}
+
+func TestPlanComponentPushes(t *testing.T) {
+	t.Parallel()
+
+	component := v1alpha1.ZarfComponent{
+		Name:   "podinfo",
+		Images: []string{"ghcr.io/stefanprodan/podinfo:6.4.0"},
+		Repos:  []string{"https://github.com/stefanprodan/podinfo.git"},
+	}
+	state := &types.ZarfState{
+		RegistryInfo: types.RegistryInfo{Address: "127.0.0.1:31999"},
+		GitServer:    types.GitServerInfo{Address: "http://zarf-gitea-http.zarf.svc.cluster.local:3000", PushUsername: "zarf-git-user"},
+	}
+
+	tests := []struct {
+		name           string
+		yolo           bool
+		expectedImages []PlannedPush
+		expectedRepos  []PlannedPush
+	}{
+		{
+			name: "both image tags and the repo are pushed",
+			expectedImages: []PlannedPush{{
+				Source:  "ghcr.io/stefanprodan/podinfo:6.4.0",
+				Targets: []string{"127.0.0.1:31999/stefanprodan/podinfo:6.4.0-zarf-2985051089", "127.0.0.1:31999/stefanprodan/podinfo:6.4.0"},
+			}},
+			expectedRepos: []PlannedPush{{
+				Source:  "https://github.com/stefanprodan/podinfo.git",
+				Targets: []string{"http://zarf-gitea-http.zarf.svc.cluster.local:3000/zarf-git-user/podinfo-1646971829.git"},
+			}},
+		},
+		{
+			name: "nothing is pushed in YOLO mode",
+			yolo: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Packager{
+				cfg: &types.PackagerConfig{
+					Pkg: v1alpha1.ZarfPackage{
+						Metadata:   v1alpha1.ZarfMetadata{Name: "podinfo", YOLO: tt.yolo},
+						Components: []v1alpha1.ZarfComponent{component},
+					},
+				},
+				state:          state,
+				layout:         layout.New(t.TempDir()),
+				variableConfig: template.GetZarfVariableConfig(),
+			}
+			plan, err := p.planComponent(context.Background(), component)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedImages, plan.Images)
+			require.Equal(t, tt.expectedRepos, plan.Repos)
+		})
+	}
+}
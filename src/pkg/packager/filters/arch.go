@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package filters contains core implementations of the ComponentFilterStrategy interface.
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+// ErrMultipleArchitectures is returned when several architecture variants of a component match the architectures.
+var ErrMultipleArchitectures = errors.New("multiple architecture variants of a component match the cluster")
+
+// ByArchitecture creates a new filter that filters the components of multi-architecture packages based on the
+// architectures returned by lookup, which is only called when a multi-architecture package is filtered.
+func ByArchitecture(lookup func() ([]string, error)) ComponentFilterStrategy {
+	return &archFilter{lookup}
+}
+
+// archFilter filters the components of multi-architecture packages based on architecture.
+type archFilter struct {
+	lookup func() ([]string, error)
+}
+
+// Apply applies the filter.
+func (f *archFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfComponent, error) {
+	if !pkg.IsMultiArch() {
+		return pkg.Components, nil
+	}
+
+	archs, err := f.lookup()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the architectures to deploy the multi-architecture package for: %w", err)
+	}
+	if !slices.ContainsFunc(pkg.Metadata.Architectures, func(arch string) bool { return slices.Contains(archs, arch) }) {
+		return nil, fmt.Errorf("the package contains images for %s, but the target cluster only has the %s architecture(s)",
+			strings.Join(pkg.Metadata.Architectures, ", "), strings.Join(archs, ", "))
+	}
+
+	filtered := []v1alpha1.ZarfComponent{}
+	componentArchs := map[string]string{}
+	for _, component := range pkg.Components {
+		arch := component.Only.Cluster.Architecture
+		if arch != "" && !slices.Contains(archs, arch) {
+			continue
+		}
+		// Clusters with nodes of several architectures would otherwise deploy every variant of a component
+		if other, ok := componentArchs[component.Name]; ok {
+			return nil, fmt.Errorf("%w: component %q has variants for %s and %s, set --architecture to choose one",
+				ErrMultipleArchitectures, component.Name, other, arch)
+		}
+		componentArchs[component.Name] = arch
+		filtered = append(filtered, component)
+	}
+	return filtered, nil
+}
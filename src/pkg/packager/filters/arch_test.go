@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package filters contains core implementations of the ComponentFilterStrategy interface.
+package filters
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestArchFilter(t *testing.T) {
+	t.Parallel()
+
+	componentForArch := func(name, arch string) v1alpha1.ZarfComponent {
+		return v1alpha1.ZarfComponent{
+			Name: name,
+			Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Architecture: arch}},
+		}
+	}
+	multiArchPkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{
+			Architecture:  v1alpha1.ZarfPackageArchMulti,
+			Architectures: []string{"amd64", "arm64"},
+		},
+		Components: []v1alpha1.ZarfComponent{
+			componentForArch("shared", ""),
+			componentForArch("agent", "amd64"),
+			componentForArch("agent", "arm64"),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		pkg           v1alpha1.ZarfPackage
+		archs         []string
+		lookupErr     error
+		expected      []v1alpha1.ZarfComponent
+		expectedErr   error
+		expectedError string
+	}{
+		{
+			name:     "single architecture package is not filtered",
+			pkg:      v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Architecture: "amd64"}, Components: []v1alpha1.ZarfComponent{componentForArch("a", "")}},
+			expected: []v1alpha1.ZarfComponent{componentForArch("a", "")},
+		},
+		{
+			name:     "components for the cluster architecture",
+			pkg:      multiArchPkg,
+			archs:    []string{"arm64"},
+			expected: []v1alpha1.ZarfComponent{componentForArch("shared", ""), componentForArch("agent", "arm64")},
+		},
+		{
+			name:          "cluster architecture not in the package",
+			pkg:           multiArchPkg,
+			archs:         []string{"s390x"},
+			expectedError: "the package contains images for amd64, arm64, but the target cluster only has the s390x architecture(s)",
+		},
+		{
+			name:        "several variants for a mixed architecture cluster",
+			pkg:         multiArchPkg,
+			archs:       []string{"amd64", "arm64"},
+			expectedErr: ErrMultipleArchitectures,
+		},
+		{
+			name:          "lookup failure",
+			pkg:           multiArchPkg,
+			lookupErr:     errors.New("no cluster"),
+			expectedError: "unable to get the architectures to deploy the multi-architecture package for: no cluster",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := ByArchitecture(func() ([]string, error) {
+				return tt.archs, tt.lookupErr
+			})
+			result, err := filter.Apply(tt.pkg)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
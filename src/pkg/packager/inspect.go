@@ -11,6 +11,7 @@ import (
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/internal/packager/sbom"
+	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 )
 
@@ -23,14 +24,27 @@ func (p *Packager) Inspect(ctx context.Context) (err error) {
 		return err
 	}
 
+	outputFormat := message.OutputFormat(p.cfg.InspectOpts.OutputFormat)
+	structured := outputFormat != "" && outputFormat != message.OutputTable
+
 	if p.cfg.InspectOpts.ListImages {
 		imageList := []string{}
 		for _, component := range p.cfg.Pkg.Components {
 			imageList = append(imageList, component.Images...)
 		}
 		imageList = helpers.Unique(imageList)
-		for _, image := range imageList {
-			fmt.Fprintln(os.Stdout, "-", image)
+		if structured {
+			if err := message.PrintStructured(os.Stdout, outputFormat, imageList); err != nil {
+				return err
+			}
+		} else {
+			for _, image := range imageList {
+				fmt.Fprintln(os.Stdout, "-", image)
+			}
+		}
+	} else if structured {
+		if err := message.PrintStructured(os.Stdout, outputFormat, p.cfg.Pkg); err != nil {
+			return err
 		}
 	} else {
 		utils.ColorPrintYAML(p.cfg.Pkg, nil, false)
@@ -64,10 +64,10 @@ func (p *Packager) confirmAction(stage string, warnings []string, sbomViewFiles
 	message.HorizontalRule()
 
 	// Display prompt if not auto-confirmed
-	if config.CommonOptions.Confirm {
+	if p.confirm {
 		pterm.Println()
 		message.Successf("%s Zarf package confirmed", stage)
-		return config.CommonOptions.Confirm
+		return true
 	}
 
 	prompt := &survey.Confirm{
@@ -56,7 +56,7 @@ func (p *Packager) Mirror(ctx context.Context) error {
 
 // mirrorComponent mirrors a Zarf Component.
 func (p *Packager) mirrorComponent(ctx context.Context, component v1alpha1.ZarfComponent) error {
-	componentPaths := p.layout.Components.Dirs[component.Name]
+	componentPaths := p.layout.Components.Dirs[p.cfg.Pkg.ComponentKey(component)]
 
 	// All components now require a name
 	message.HeaderInfof("📦 %s COMPONENT", strings.ToUpper(component.Name))
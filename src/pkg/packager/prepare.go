@@ -152,7 +152,7 @@ func (p *Packager) findImages(ctx context.Context) (imgMap map[string][]string,
 		// resources are a slice of generic structs that represent parsed K8s resources
 		var resources []*unstructured.Unstructured
 
-		componentPaths, err := p.layout.Components.Create(component)
+		componentPaths, err := p.layout.Components.Create(p.cfg.Pkg.ComponentKey(component), component)
 		if err != nil {
 			return nil, err
 		}
@@ -351,7 +351,14 @@ func (p *Packager) findImages(ctx context.Context) (imgMap map[string][]string,
 		return nil, nil
 	}
 
-	fmt.Println(componentDefinition)
+	outputFormat := message.OutputFormat(p.cfg.FindImagesOpts.OutputFormat)
+	if outputFormat != "" && outputFormat != message.OutputTable {
+		if err := message.PrintStructured(os.Stdout, outputFormat, imagesMap); err != nil {
+			return imagesMap, err
+		}
+	} else {
+		fmt.Println(componentDefinition)
+	}
 
 	if len(erroredCharts) > 0 || len(erroredCosignLookups) > 0 {
 		errMsg := ""
@@ -63,7 +63,7 @@ func (p *Packager) Publish(ctx context.Context) (err error) {
 			return err
 		}
 
-		if err := sc.Assemble(ctx, p.layout, p.cfg.Pkg.Components, ""); err != nil {
+		if err := sc.Assemble(ctx, p.layout, p.cfg.Pkg); err != nil {
 			return err
 		}
 
@@ -82,6 +82,18 @@ func (p *Packager) Remove(ctx context.Context) (err error) {
 		if err != nil {
 			return fmt.Errorf("unable to load the secret for the package we are attempting to remove: %s", err.Error())
 		}
+		// Dependent packages only block removing the whole package, which happens when no deployed component remains
+		remaining := false
+		for _, dc := range deployedPackage.DeployedComponents {
+			if !slices.Contains(componentsToRemove, dc.Name) {
+				remaining = true
+			}
+		}
+		if !remaining {
+			if err := p.verifyNoDependentPackages(ctx, packageName); err != nil {
+				return err
+			}
+		}
 	} else {
 		// If we do not need the cluster, create a deployed components object based on the info we have
 		deployedPackage.Name = packageName
@@ -236,6 +248,10 @@ func (p *Packager) removeComponent(ctx context.Context, deployedPackage *types.D
 			if err != nil {
 				message.Warnf("Unable to delete the '%s' package secret: '%s' (this may be normal if the cluster was removed)", secretName, err.Error())
 			}
+			err = p.cluster.DeletePackageHistory(ctx, deployedPackage.Name)
+			if err != nil {
+				message.Warnf("Unable to delete the history of package '%s': '%s'", deployedPackage.Name, err.Error())
+			}
 		}
 	} else {
 		err := p.updatePackageSecret(ctx, *deployedPackage)
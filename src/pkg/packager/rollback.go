@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// Rollback returns a package deployed to the cluster to a generation recorded in its history.
+func (p *Packager) Rollback(ctx context.Context) error {
+	clusterSource, ok := p.source.(*sources.ClusterSource)
+	if !ok {
+		return errors.New("a rollback requires the name of a package deployed to the cluster")
+	}
+	p.cluster = clusterSource.Cluster
+	packageName := p.cfg.PkgOpts.PackageSource
+
+	deployedPackage, err := p.cluster.GetDeployedPackage(ctx, packageName)
+	if err != nil {
+		return fmt.Errorf("unable to load the secret for package %s: %w", packageName, err)
+	}
+	history, err := p.cluster.GetPackageHistory(ctx, packageName)
+	if err != nil {
+		return fmt.Errorf("unable to load the history of package %s: %w", packageName, err)
+	}
+	target, err := findRollbackRevision(history, deployedPackage.Generation, p.cfg.RollbackOpts.Generation)
+	if err != nil {
+		return err
+	}
+
+	spinner := message.NewProgressSpinner("Rolling back package %s to generation %d", packageName, target.Generation)
+	defer spinner.Stop()
+
+	// Charts only present in the current generation are uninstalled by the rollback
+	charts := []types.InstalledChart{}
+	for _, component := range deployedPackage.DeployedComponents {
+		charts = append(charts, component.InstalledCharts...)
+	}
+	for _, component := range target.DeployedComponents {
+		charts = append(charts, component.InstalledCharts...)
+	}
+	for _, chart := range chartRollbacks(charts, target.DeployedComponents) {
+		if err := p.rollbackChart(chart, spinner); err != nil {
+			return err
+		}
+	}
+
+	// Like a Helm rollback, the restored package is recorded as a new generation
+	spinner.Updatef("Recording the rollback of package %s", packageName)
+	rolledBackPackage, err := p.cluster.RecordPackageDeployment(ctx, target.Data, target.DeployedComponents, target.ConnectStrings, deployedPackage.Generation+1)
+	if err != nil {
+		return err
+	}
+	if err := p.cluster.RecordPackageRevision(ctx, *rolledBackPackage, target.Variables); err != nil {
+		return err
+	}
+
+	spinner.Successf("Rolled back package %s to generation %d", packageName, target.Generation)
+	return nil
+}
+
+// findRollbackRevision returns the revision with the requested generation, or the newest successfully deployed revision
+// older than the current generation when no generation is requested.
+func findRollbackRevision(history []types.DeployedPackageRevision, currentGeneration int, generation int) (types.DeployedPackageRevision, error) {
+	if generation == currentGeneration {
+		return types.DeployedPackageRevision{}, fmt.Errorf("the package is already at generation %d", generation)
+	}
+	var target *types.DeployedPackageRevision
+	for _, revision := range history {
+		if generation == 0 && revision.Generation < currentGeneration && !revisionFailed(revision) {
+			target = &revision
+		}
+		if generation != 0 && revision.Generation == generation {
+			if revisionFailed(revision) {
+				return types.DeployedPackageRevision{}, fmt.Errorf("generation %d failed to deploy and cannot be rolled back to", generation)
+			}
+			target = &revision
+		}
+	}
+	if target != nil {
+		return *target, nil
+	}
+	if generation == 0 {
+		return types.DeployedPackageRevision{}, errors.New("no previous generation of the package was found in its history")
+	}
+	return types.DeployedPackageRevision{}, fmt.Errorf("generation %d was not found in the history of the package", generation)
+}
+
+// revisionFailed returns true if a component of the revision did not finish deploying.
+func revisionFailed(revision types.DeployedPackageRevision) bool {
+	for _, component := range revision.DeployedComponents {
+		if component.Status != types.ComponentStatusSucceeded {
+			return true
+		}
+	}
+	return false
+}
+
+// chartRollback is a Helm release to return to the state it had in a restored generation.
+type chartRollback struct {
+	namespace string
+	chartName string
+	// revision is the Helm revision to roll back to, a revision of 0 uninstalls the release
+	revision int
+	// unrecorded is set when the restored generation installed the release without recording its Helm revision
+	unrecorded bool
+}
+
+// chartRollbacks returns the charts to roll back in the reverse order they were deployed in, with the Helm revision
+// recorded for them by the components of the restored generation. Charts that were not installed by that generation
+// have a revision of 0 so they are uninstalled. Generations deployed before Helm revisions were recorded return their
+// charts to the revision before the newest one that is known, or to the revision before a failed one when none is.
+func chartRollbacks(charts []types.InstalledChart, components []types.DeployedComponent) []chartRollback {
+	recorded := map[string]types.InstalledChart{}
+	for _, component := range components {
+		for _, chart := range component.InstalledCharts {
+			recorded[chart.Namespace+"/"+chart.ChartName] = chart
+		}
+	}
+	latest := map[string]int{}
+	for _, chart := range charts {
+		key := chart.Namespace + "/" + chart.ChartName
+		latest[key] = max(latest[key], chart.Revision)
+	}
+
+	rollbacks := []chartRollback{}
+	seen := map[string]bool{}
+	for _, chart := range charts {
+		key := chart.Namespace + "/" + chart.ChartName
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rollback := chartRollback{namespace: chart.Namespace, chartName: chart.ChartName}
+		if previous, ok := recorded[key]; ok {
+			rollback.revision = previous.Revision
+			if previous.Revision == 0 {
+				rollback.unrecorded = true
+				rollback.revision = max(latest[key]-1, 0)
+			}
+		}
+		rollbacks = append(rollbacks, rollback)
+	}
+	return helpers.Reverse(rollbacks)
+}
+
+// rollbackChart returns a Helm release to the revision of a chart rollback.
+func (p *Packager) rollbackChart(chart chartRollback, spinner *message.Spinner) error {
+	helmCfg := helm.NewClusterOnly(p.cfg, p.variableConfig, p.state, p.cluster)
+	var err error
+	switch {
+	case chart.unrecorded && chart.revision == 0:
+		message.Warnf("No Helm revision was recorded for the chart %s in the namespace %s, it is only rolled back if its latest revision failed", chart.chartName, chart.namespace)
+		err = helmCfg.RollbackFailedChart(chart.namespace, chart.chartName, spinner)
+	case chart.unrecorded:
+		message.Warnf("No Helm revision was recorded for the chart %s in the namespace %s, rolling it back to revision %d", chart.chartName, chart.namespace, chart.revision)
+		err = helmCfg.RollbackChart(chart.namespace, chart.chartName, chart.revision, spinner)
+	default:
+		err = helmCfg.RollbackChart(chart.namespace, chart.chartName, chart.revision, spinner)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to roll back the helm chart %s in the namespace %s: %w", chart.chartName, chart.namespace, err)
+	}
+	return nil
+}
+
+// rollbackDeployment returns the Helm releases touched by a failed deployment and the package secret to the
+// generation that was deployed before the deployment started.
+func (p *Packager) rollbackDeployment(ctx context.Context, previousPackage *types.DeployedPackage, touchedCharts []types.InstalledChart) error {
+	packageName := p.cfg.Pkg.Metadata.Name
+	if previousPackage != nil {
+		message.Warnf("Rolling back package %q to generation %d", packageName, previousPackage.Generation)
+	} else {
+		message.Warnf("Rolling back the installation of package %q", packageName)
+	}
+
+	spinner := message.NewProgressSpinner("Rolling back package %s", packageName)
+	defer spinner.Stop()
+
+	// Roll back in the reverse order of deployment so dependent releases are reverted first
+	var previousComponents []types.DeployedComponent
+	if previousPackage != nil {
+		previousComponents = previousPackage.DeployedComponents
+	}
+	var errs []error
+	for _, chart := range chartRollbacks(touchedCharts, previousComponents) {
+		if err := p.rollbackChart(chart, spinner); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	spinner.Updatef("Restoring the package secret")
+	if previousPackage != nil {
+		if err := p.updatePackageSecret(ctx, *previousPackage); err != nil {
+			errs = append(errs, err)
+		}
+		// The failed generation is no longer deployed so it is dropped from the history
+		if err := p.cluster.DeletePackageRevision(ctx, packageName, previousPackage.Generation+1); err != nil {
+			errs = append(errs, fmt.Errorf("unable to remove the failed generation from the history of package %s: %w", packageName, err))
+		}
+	} else {
+		secretName := config.ZarfPackagePrefix + packageName
+		err := p.cluster.Clientset.CoreV1().Secrets(cluster.ZarfNamespaceName).Delete(ctx, secretName, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("unable to delete the '%s' package secret: %w", secretName, err))
+		}
+		if err := p.cluster.DeletePackageHistory(ctx, packageName); err != nil {
+			errs = append(errs, fmt.Errorf("unable to delete the history of package %s: %w", packageName, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	spinner.Successf("Rolled back package %s", packageName)
+	return nil
+}
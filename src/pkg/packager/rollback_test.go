@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestRollbackDeploymentPackageSecret(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "test"},
+	}
+	failedComponents := []types.DeployedComponent{{Name: "web", Status: types.ComponentStatusFailed, ObservedGeneration: 2}}
+
+	t.Run("restore previous generation", func(t *testing.T) {
+		t.Parallel()
+
+		c := &cluster.Cluster{Clientset: fake.NewSimpleClientset()}
+		p := &Packager{cluster: c, cfg: &types.PackagerConfig{Pkg: pkg}}
+
+		previous, err := c.RecordPackageDeployment(ctx, pkg, []types.DeployedComponent{{Name: "web", Status: types.ComponentStatusSucceeded, ObservedGeneration: 1}}, nil, 1)
+		require.NoError(t, err)
+		err = c.RecordPackageRevision(ctx, *previous, nil)
+		require.NoError(t, err)
+		failed, err := c.RecordPackageDeployment(ctx, pkg, failedComponents, nil, 2)
+		require.NoError(t, err)
+		err = c.RecordPackageRevision(ctx, *failed, nil)
+		require.NoError(t, err)
+
+		err = p.rollbackDeployment(ctx, previous, nil)
+		require.NoError(t, err)
+
+		restored, err := c.GetDeployedPackage(ctx, "test")
+		require.NoError(t, err)
+		require.Equal(t, 1, restored.Generation)
+		require.Equal(t, types.ComponentStatusSucceeded, restored.DeployedComponents[0].Status)
+
+		// The failed generation is dropped from the history
+		history, err := c.GetPackageHistory(ctx, "test")
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, 1, history[0].Generation)
+	})
+
+	t.Run("remove first installation", func(t *testing.T) {
+		t.Parallel()
+
+		c := &cluster.Cluster{Clientset: fake.NewSimpleClientset()}
+		p := &Packager{cluster: c, cfg: &types.PackagerConfig{Pkg: pkg}}
+
+		_, err := c.RecordPackageDeployment(ctx, pkg, failedComponents, nil, 1)
+		require.NoError(t, err)
+
+		err = p.rollbackDeployment(ctx, nil, nil)
+		require.NoError(t, err)
+
+		_, err = c.GetDeployedPackage(ctx, "test")
+		require.True(t, kerrors.IsNotFound(err))
+	})
+}
+
+func TestFindRollbackRevision(t *testing.T) {
+	t.Parallel()
+
+	succeeded := []types.DeployedComponent{{Name: "web", Status: types.ComponentStatusSucceeded}}
+	history := []types.DeployedPackageRevision{
+		{Generation: 2, DeployedComponents: succeeded},
+		{Generation: 3, DeployedComponents: succeeded},
+		{Generation: 4, DeployedComponents: []types.DeployedComponent{{Name: "web", Status: types.ComponentStatusFailed}}},
+		{Generation: 5, DeployedComponents: succeeded},
+	}
+
+	tests := []struct {
+		name              string
+		currentGeneration int
+		generation        int
+		expected          int
+		expectedErr       string
+	}{
+		{
+			name:              "previous generation",
+			currentGeneration: 3,
+			expected:          2,
+		},
+		{
+			name:              "previous generation skips failed generations",
+			currentGeneration: 5,
+			expected:          3,
+		},
+		{
+			name:              "failed generation",
+			currentGeneration: 5,
+			generation:        4,
+			expectedErr:       "generation 4 failed to deploy and cannot be rolled back to",
+		},
+		{
+			name:              "specific generation",
+			currentGeneration: 5,
+			generation:        2,
+			expected:          2,
+		},
+		{
+			name:              "current generation",
+			currentGeneration: 5,
+			generation:        5,
+			expectedErr:       "the package is already at generation 5",
+		},
+		{
+			name:              "missing generation",
+			currentGeneration: 5,
+			generation:        1,
+			expectedErr:       "generation 1 was not found in the history of the package",
+		},
+		{
+			name:              "no previous generation",
+			currentGeneration: 2,
+			expectedErr:       "no previous generation of the package was found in its history",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			revision, err := findRollbackRevision(history, tt.currentGeneration, tt.generation)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, revision.Generation)
+		})
+	}
+}
+
+func TestChartRollbacks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		charts     []types.InstalledChart
+		components []types.DeployedComponent
+		expected   []chartRollback
+	}{
+		{
+			name: "revisions of the restored generation",
+			charts: []types.InstalledChart{
+				{Namespace: "podinfo", ChartName: "podinfo", Revision: 4},
+				{Namespace: "podinfo", ChartName: "redis", Revision: 2},
+				{Namespace: "podinfo", ChartName: "podinfo", Revision: 2},
+			},
+			components: []types.DeployedComponent{
+				{Name: "podinfo", InstalledCharts: []types.InstalledChart{{Namespace: "podinfo", ChartName: "podinfo", Revision: 2}}},
+			},
+			// Charts are rolled back in reverse order and redis was not part of the generation so it is uninstalled
+			expected: []chartRollback{
+				{namespace: "podinfo", chartName: "redis"},
+				{namespace: "podinfo", chartName: "podinfo", revision: 2},
+			},
+		},
+		{
+			name: "first installation",
+			charts: []types.InstalledChart{
+				{Namespace: "podinfo", ChartName: "podinfo", Revision: 1},
+			},
+			expected: []chartRollback{
+				{namespace: "podinfo", chartName: "podinfo"},
+			},
+		},
+		{
+			name: "revision not recorded",
+			charts: []types.InstalledChart{
+				{Namespace: "podinfo", ChartName: "podinfo", Revision: 3},
+			},
+			components: []types.DeployedComponent{
+				{Name: "podinfo", InstalledCharts: []types.InstalledChart{{Namespace: "podinfo", ChartName: "podinfo"}}},
+			},
+			// The release is returned to the revision before the one installed by the newer generation
+			expected: []chartRollback{
+				{namespace: "podinfo", chartName: "podinfo", revision: 2, unrecorded: true},
+			},
+		},
+		{
+			name: "revision not recorded and the new revision unknown",
+			charts: []types.InstalledChart{
+				{Namespace: "podinfo", ChartName: "podinfo"},
+			},
+			components: []types.DeployedComponent{
+				{Name: "podinfo", InstalledCharts: []types.InstalledChart{{Namespace: "podinfo", ChartName: "podinfo"}}},
+			},
+			expected: []chartRollback{
+				{namespace: "podinfo", chartName: "podinfo", unrecorded: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, chartRollbacks(tt.charts, tt.components))
+		})
+	}
+}
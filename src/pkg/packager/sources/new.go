@@ -40,6 +40,13 @@ type PackageSource interface {
 	Collect(ctx context.Context, destinationDirectory string) (tarball string, err error)
 }
 
+// PathLoader is implemented by package sources that can load individual files of a package without loading all of it.
+//
+// LoadPackagePaths must be called after LoadPackageMetadata, paths that are not in the package are skipped.
+type PathLoader interface {
+	LoadPackagePaths(ctx context.Context, dst *layout.PackagePaths, paths []string) error
+}
+
 // Identify returns the type of package source based on the provided package source string.
 func Identify(pkgSrc string) string {
 	if helpers.IsURL(pkgSrc) {
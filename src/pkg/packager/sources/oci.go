@@ -26,6 +26,7 @@ import (
 var (
 	// verify that OCISource implements PackageSource
 	_ PackageSource = (*OCISource)(nil)
+	_ PathLoader    = (*OCISource)(nil)
 )
 
 // OCISource is a package source for OCI registries.
@@ -86,9 +87,9 @@ func (s *OCISource) LoadPackage(ctx context.Context, dst *layout.PackagePaths, f
 
 	if unarchiveAll {
 		for _, component := range pkg.Components {
-			if err := dst.Components.Unarchive(component); err != nil {
+			if err := dst.Components.Unarchive(pkg.ComponentKey(component), component); err != nil {
 				if errors.Is(err, layout.ErrNotLoaded) {
-					_, err := dst.Components.Create(component)
+					_, err := dst.Components.Create(pkg.ComponentKey(component), component)
 					if err != nil {
 						return pkg, nil, err
 					}
@@ -160,6 +161,16 @@ func (s *OCISource) LoadPackageMetadata(ctx context.Context, dst *layout.Package
 	return pkg, warnings, nil
 }
 
+// LoadPackagePaths pulls the layers of the paths from an OCI registry.
+func (s *OCISource) LoadPackagePaths(ctx context.Context, dst *layout.PackagePaths, paths []string) error {
+	layers, err := s.PullPaths(ctx, dst.Base, paths)
+	if err != nil {
+		return err
+	}
+	dst.SetFromLayers(layers)
+	return nil
+}
+
 // Collect pulls a package from an OCI registry and writes it to a tarball.
 func (s *OCISource) Collect(ctx context.Context, dir string) (string, error) {
 	tmp, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
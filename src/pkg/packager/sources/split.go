@@ -25,6 +25,7 @@ import (
 var (
 	// verify that SplitTarballSource implements PackageSource
 	_ PackageSource = (*SplitTarballSource)(nil)
+	_ PathLoader    = (*SplitTarballSource)(nil)
 )
 
 // SplitTarballSource is a package source for split tarballs.
@@ -143,3 +144,14 @@ func (s *SplitTarballSource) LoadPackageMetadata(ctx context.Context, dst *layou
 	}
 	return ts.LoadPackageMetadata(ctx, dst, wantSBOM, skipValidation)
 }
+
+// LoadPackagePaths extracts the paths from the tarball reassembled by LoadPackageMetadata.
+func (s *SplitTarballSource) LoadPackagePaths(ctx context.Context, dst *layout.PackagePaths, paths []string) error {
+	if strings.Contains(s.PackageSource, ".part000") {
+		return fmt.Errorf("unable to load paths from %s before its metadata is loaded", s.PackageSource)
+	}
+	ts := &TarballSource{
+		s.ZarfPackageOptions,
+	}
+	return ts.LoadPackagePaths(ctx, dst, paths)
+}
@@ -26,6 +26,7 @@ import (
 var (
 	// verify that TarballSource implements PackageSource
 	_ PackageSource = (*TarballSource)(nil)
+	_ PathLoader    = (*TarballSource)(nil)
 )
 
 // TarballSource is a package source for tarballs.
@@ -114,9 +115,9 @@ func (s *TarballSource) LoadPackage(ctx context.Context, dst *layout.PackagePath
 
 	if unarchiveAll {
 		for _, component := range pkg.Components {
-			if err := dst.Components.Unarchive(component); err != nil {
+			if err := dst.Components.Unarchive(pkg.ComponentKey(component), component); err != nil {
 				if errors.Is(err, layout.ErrNotLoaded) {
-					_, err := dst.Components.Create(component)
+					_, err := dst.Components.Create(pkg.ComponentKey(component), component)
 					if err != nil {
 						return pkg, nil, err
 					}
@@ -203,6 +204,22 @@ func (s *TarballSource) LoadPackageMetadata(ctx context.Context, dst *layout.Pac
 	return pkg, warnings, nil
 }
 
+// LoadPackagePaths extracts the paths from a tarball.
+func (s *TarballSource) LoadPackagePaths(_ context.Context, dst *layout.PackagePaths, paths []string) error {
+	pathsExtracted := []string{}
+	for _, rel := range paths {
+		if err := archiver.Extract(s.PackageSource, rel, dst.Base); err != nil {
+			return err
+		}
+		// archiver.Extract will not return an error if the file does not exist, so we must manually check
+		if !helpers.InvalidPath(filepath.Join(dst.Base, rel)) {
+			pathsExtracted = append(pathsExtracted, rel)
+		}
+	}
+	dst.SetFromPaths(pathsExtracted)
+	return nil
+}
+
 // Collect for the TarballSource is essentially an `mv`
 func (s *TarballSource) Collect(_ context.Context, dir string) (string, error) {
 	dst := filepath.Join(dir, filepath.Base(s.PackageSource))
@@ -23,6 +23,7 @@ import (
 var (
 	// verify that URLSource implements PackageSource
 	_ PackageSource = (*URLSource)(nil)
+	_ PathLoader    = (*URLSource)(nil)
 )
 
 // URLSource is a package source for http, https and sget URLs.
@@ -96,3 +97,14 @@ func (s *URLSource) LoadPackageMetadata(ctx context.Context, dst *layout.Package
 
 	return ts.LoadPackageMetadata(ctx, dst, wantSBOM, skipValidation)
 }
+
+// LoadPackagePaths extracts the paths from the tarball downloaded by LoadPackageMetadata.
+func (s *URLSource) LoadPackagePaths(ctx context.Context, dst *layout.PackagePaths, paths []string) error {
+	if helpers.IsURL(s.PackageSource) {
+		return fmt.Errorf("unable to load paths from %s before its metadata is loaded", s.PackageSource)
+	}
+	ts := &TarballSource{
+		s.ZarfPackageOptions,
+	}
+	return ts.LoadPackagePaths(ctx, dst, paths)
+}
@@ -0,0 +1,367 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/images"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// Status values reported in addition to the Helm release and kstatus statuses.
+const (
+	StatusReady   = "Ready"
+	StatusMissing = "Missing"
+	StatusDrifted = "Drifted"
+)
+
+// Kinds of the objects checked by a status check.
+const (
+	StatusKindHelmRelease = "HelmRelease"
+	StatusKindImage       = "Image"
+)
+
+// workloadKinds are the namespaced kinds rendered by a package whose readiness is checked.
+var workloadKinds = map[schema.GroupKind]bool{
+	{Group: "apps", Kind: "Deployment"}:        true,
+	{Group: "apps", Kind: "StatefulSet"}:       true,
+	{Group: "apps", Kind: "DaemonSet"}:         true,
+	{Group: "apps", Kind: "ReplicaSet"}:        true,
+	{Group: "batch", Kind: "Job"}:              true,
+	{Group: "", Kind: "Pod"}:                   true,
+	{Group: "", Kind: "Service"}:               true,
+	{Group: "", Kind: "PersistentVolumeClaim"}: true,
+}
+
+// StatusResult is the health of a single Helm release, workload or image of a deployed package.
+type StatusResult struct {
+	Component string `json:"component"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Healthy   bool   `json:"healthy"`
+	Message   string `json:"message,omitempty"`
+}
+
+// PackageStatus is the health of a deployed package compared to its recorded deployment.
+type PackageStatus struct {
+	Name       string         `json:"name"`
+	Generation int            `json:"generation"`
+	Healthy    bool           `json:"healthy"`
+	Results    []StatusResult `json:"results"`
+}
+
+// Status compares a package deployed to the cluster with its live Helm releases, workloads and images.
+func (p *Packager) Status(ctx context.Context) (PackageStatus, error) {
+	clusterSource, ok := p.source.(*sources.ClusterSource)
+	if !ok {
+		return PackageStatus{}, errors.New("a status check requires the name of a package deployed to the cluster")
+	}
+	p.cluster = clusterSource.Cluster
+	packageName := p.cfg.PkgOpts.PackageSource
+
+	deployedPackage, err := p.cluster.GetDeployedPackage(ctx, packageName)
+	if err != nil {
+		return PackageStatus{}, fmt.Errorf("unable to load the secret for package %s: %w", packageName, err)
+	}
+	state, err := p.cluster.LoadZarfState(ctx)
+	if err != nil {
+		return PackageStatus{}, err
+	}
+
+	spinner := message.NewProgressSpinner("Checking the status of package %s", packageName)
+	defer spinner.Stop()
+
+	results := []StatusResult{}
+	for _, component := range deployedPackage.DeployedComponents {
+		chartResults, err := p.chartStatuses(ctx, component, state, spinner)
+		if err != nil {
+			return PackageStatus{}, err
+		}
+		results = append(results, chartResults...)
+	}
+	spinner.Updatef("Checking the images of package %s", packageName)
+	imageResults, err := p.imageStatuses(ctx, deployedPackage, state)
+	if err != nil {
+		return PackageStatus{}, err
+	}
+	results = append(results, imageResults...)
+
+	packageStatus := PackageStatus{
+		Name:       packageName,
+		Generation: deployedPackage.Generation,
+		Healthy:    true,
+		Results:    results,
+	}
+	for _, result := range results {
+		if !result.Healthy {
+			packageStatus.Healthy = false
+		}
+	}
+	spinner.Success()
+	return packageStatus, nil
+}
+
+// chartStatuses checks the Helm releases of a component and the readiness of the workloads they rendered.
+func (p *Packager) chartStatuses(ctx context.Context, component types.DeployedComponent, state *types.ZarfState, spinner *message.Spinner) ([]StatusResult, error) {
+	results := []StatusResult{}
+	for _, chart := range component.InstalledCharts {
+		spinner.Updatef("Checking the chart %s in the %s namespace", chart.ChartName, chart.Namespace)
+		name := fmt.Sprintf("%s/%s", chart.Namespace, chart.ChartName)
+
+		helmCfg := helm.NewClusterOnly(p.cfg, p.variableConfig, state, p.cluster)
+		rel, err := helmCfg.GetRelease(chart.Namespace, chart.ChartName, spinner)
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			results = append(results, StatusResult{
+				Component: component.Name,
+				Kind:      StatusKindHelmRelease,
+				Name:      name,
+				Status:    StatusMissing,
+				Message:   "the Helm release was removed from the cluster",
+			})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the Helm release %s: %w", name, err)
+		}
+		results = append(results, StatusResult{
+			Component: component.Name,
+			Kind:      StatusKindHelmRelease,
+			Name:      name,
+			Status:    rel.Info.Status.String(),
+			Healthy:   rel.Info.Status == release.StatusDeployed,
+			Message:   fmt.Sprintf("revision %d", rel.Version),
+		})
+
+		objs, err := releaseWorkloads(rel)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the manifest of the Helm release %s: %w", name, err)
+		}
+		if len(objs) == 0 {
+			continue
+		}
+		statuses, err := p.cluster.GetResourceStatuses(ctx, objs)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the status of the resources of the Helm release %s: %w", name, err)
+		}
+		for _, obj := range objs {
+			results = append(results, workloadStatusResult(component.Name, obj, statuses[obj]))
+		}
+	}
+	return results, nil
+}
+
+// releaseWorkloads returns the workloads rendered in the manifest of a Helm release.
+func releaseWorkloads(rel *release.Release) ([]object.ObjMetadata, error) {
+	objs := []object.ObjMetadata{}
+	for _, manifest := range releaseutil.SplitManifests(rel.Manifest) {
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(manifest), &u.Object); err != nil {
+			return nil, err
+		}
+		gk := u.GroupVersionKind().GroupKind()
+		if !workloadKinds[gk] {
+			continue
+		}
+		namespace := u.GetNamespace()
+		if namespace == "" {
+			namespace = rel.Namespace
+		}
+		objs = append(objs, object.ObjMetadata{
+			GroupKind: gk,
+			Namespace: namespace,
+			Name:      u.GetName(),
+		})
+	}
+	return objs, nil
+}
+
+func workloadStatusResult(component string, obj object.ObjMetadata, st status.Status) StatusResult {
+	result := StatusResult{
+		Component: component,
+		Kind:      obj.GroupKind.Kind,
+		Name:      fmt.Sprintf("%s/%s", obj.Namespace, obj.Name),
+		Status:    st.String(),
+		Healthy:   st == status.CurrentStatus,
+	}
+	switch st {
+	case status.CurrentStatus:
+		result.Status = StatusReady
+	case status.NotFoundStatus:
+		result.Status = StatusMissing
+		result.Message = "the resource was removed from the cluster"
+	}
+	return result
+}
+
+// imageStatuses checks that the images of the deployed components still resolve in the Zarf registry and that the
+// pods running them use the same digests.
+func (p *Packager) imageStatuses(ctx context.Context, deployedPackage *types.DeployedPackage, state *types.ZarfState) ([]StatusResult, error) {
+	deployed := map[string]bool{}
+	for _, component := range deployedPackage.DeployedComponents {
+		deployed[component.Name] = true
+	}
+	hasImages := false
+	for _, component := range deployedPackage.Data.Components {
+		if deployed[component.Name] && len(component.Images) > 0 {
+			hasImages = true
+		}
+	}
+	if !hasImages {
+		return nil, nil
+	}
+
+	registryEndpoint, tunnel, err := p.cluster.ConnectToZarfRegistryEndpoint(ctx, state.RegistryInfo)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the Zarf registry: %w", err)
+	}
+	if tunnel != nil {
+		defer tunnel.Close()
+	}
+	podList, err := p.cluster.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodeList, err := p.cluster.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodeArchs := map[string]string{}
+	for _, node := range nodeList.Items {
+		nodeArchs[node.Name] = node.Status.NodeInfo.Architecture
+	}
+	opts := append(images.CommonOpts(deployedPackage.Data.Build.Architecture), images.WithPullAuth(state.RegistryInfo))
+
+	results := []StatusResult{}
+	for _, component := range deployedPackage.Data.Components {
+		if !deployed[component.Name] {
+			continue
+		}
+		for _, image := range component.Images {
+			ref, err := transform.ImageTransformHost(state.RegistryInfo.Address, image)
+			if err != nil {
+				return nil, err
+			}
+			endpointRef, err := transform.ImageTransformHost(registryEndpoint, image)
+			if err != nil {
+				return nil, err
+			}
+			digests, err := resolveRegistryImage(endpointRef, opts...)
+			results = append(results, imageStatusResult(component.Name, ref, digests, err, podList.Items, nodeArchs))
+		}
+	}
+	return results, nil
+}
+
+// registryImageDigests are the digest an image resolves to in the Zarf registry and, when it is an image index, the
+// digests of the image of each architecture, which some container runtimes report instead of the index digest.
+type registryImageDigests struct {
+	digest    string
+	platforms map[string]string
+}
+
+// matches returns true if a container on a node of the given architecture running the digest runs the registry image.
+// The image of any architecture matches when the architecture of the node is unknown.
+func (d registryImageDigests) matches(digest, arch string) bool {
+	if digest == d.digest {
+		return true
+	}
+	if arch != "" {
+		return d.platforms[arch] == digest
+	}
+	for _, platformDigest := range d.platforms {
+		if platformDigest == digest {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveRegistryImage(ref string, opts ...crane.Option) (registryImageDigests, error) {
+	desc, err := crane.Head(ref, opts...)
+	if err != nil {
+		return registryImageDigests{}, err
+	}
+	digests := registryImageDigests{
+		digest:    desc.Digest.String(),
+		platforms: map[string]string{},
+	}
+	if !desc.MediaType.IsIndex() {
+		return digests, nil
+	}
+	// Clear the platform so that the index itself is returned rather than the image of the platform
+	b, err := crane.Manifest(ref, append(opts, crane.WithPlatform(nil))...)
+	if err != nil {
+		return registryImageDigests{}, err
+	}
+	index, err := v1.ParseIndexManifest(bytes.NewReader(b))
+	if err != nil {
+		return registryImageDigests{}, err
+	}
+	for _, manifest := range index.Manifests {
+		if manifest.Platform == nil {
+			continue
+		}
+		digests.platforms[manifest.Platform.Architecture] = manifest.Digest.String()
+	}
+	return digests, nil
+}
+
+func imageStatusResult(component, ref string, digests registryImageDigests, resolveErr error, pods []corev1.Pod, nodeArchs map[string]string) StatusResult {
+	result := StatusResult{
+		Component: component,
+		Kind:      StatusKindImage,
+		Name:      ref,
+	}
+	if resolveErr != nil {
+		result.Status = StatusMissing
+		result.Message = fmt.Sprintf("unable to resolve the image in the Zarf registry: %s", resolveErr.Error())
+		return result
+	}
+
+	drifted := []string{}
+	for _, pod := range pods {
+		for _, containerStatus := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			if containerStatus.Image != ref {
+				continue
+			}
+			_, runningDigest, ok := strings.Cut(containerStatus.ImageID, "@")
+			if ok && !digests.matches(runningDigest, nodeArchs[pod.Spec.NodeName]) {
+				drifted = append(drifted, fmt.Sprintf("%s/%s runs %s", pod.Namespace, pod.Name, runningDigest))
+			}
+		}
+	}
+	if len(drifted) > 0 {
+		result.Status = StatusDrifted
+		result.Message = fmt.Sprintf("the Zarf registry has %s but %s", digests.digest, strings.Join(drifted, ", "))
+		return result
+	}
+	result.Status = StatusReady
+	result.Healthy = true
+	result.Message = digests.digest
+	return result
+}
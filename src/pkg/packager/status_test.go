@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/release"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestReleaseWorkloads(t *testing.T) {
+	t.Parallel()
+
+	rel := &release.Release{
+		Namespace: "podinfo",
+		Manifest: `---
+# Source: podinfo/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: podinfo
+---
+# Source: podinfo/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: podinfo
+  namespace: other
+---
+# Source: podinfo/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: podinfo
+---
+# Source: podinfo/templates/empty.yaml
+`,
+	}
+	objs, err := releaseWorkloads(rel)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []object.ObjMetadata{
+		{GroupKind: schema.GroupKind{Kind: "Service"}, Namespace: "podinfo", Name: "podinfo"},
+		{GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"}, Namespace: "other", Name: "podinfo"},
+	}, objs)
+}
+
+func TestWorkloadStatusResult(t *testing.T) {
+	t.Parallel()
+
+	obj := object.ObjMetadata{GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"}, Namespace: "podinfo", Name: "podinfo"}
+	tests := []struct {
+		status          status.Status
+		expectedStatus  string
+		expectedHealthy bool
+	}{
+		{status: status.CurrentStatus, expectedStatus: StatusReady, expectedHealthy: true},
+		{status: status.NotFoundStatus, expectedStatus: StatusMissing},
+		{status: status.InProgressStatus, expectedStatus: "InProgress"},
+		{status: status.FailedStatus, expectedStatus: "Failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			t.Parallel()
+
+			result := workloadStatusResult("web", obj, tt.status)
+			require.Equal(t, "Deployment", result.Kind)
+			require.Equal(t, "podinfo/podinfo", result.Name)
+			require.Equal(t, tt.expectedStatus, result.Status)
+			require.Equal(t, tt.expectedHealthy, result.Healthy)
+		})
+	}
+}
+
+func TestImageStatusResult(t *testing.T) {
+	t.Parallel()
+
+	ref := "127.0.0.1:31999/stefanprodan/podinfo:6.4.0-zarf-2985051089"
+	digest := "sha256:57a654ace69ec02ba8973093b6a786faa15640575fbf0dbb603db55aca2ccec8"
+	amd64Digest := "sha256:a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1"
+	arm64Digest := "sha256:b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2"
+	digests := registryImageDigests{
+		digest:    digest,
+		platforms: map[string]string{"amd64": amd64Digest, "arm64": arm64Digest},
+	}
+	nodeArchs := map[string]string{"amd64-node": "amd64", "arm64-node": "arm64"}
+	pod := func(name, node, image, imageID string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "podinfo"},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Image: image, ImageID: imageID}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		resolveErr      error
+		pods            []corev1.Pod
+		expectedStatus  string
+		expectedHealthy bool
+		expectedMessage string
+	}{
+		{
+			name:            "running the registry digest",
+			pods:            []corev1.Pod{pod("a", "amd64-node", ref, "127.0.0.1:31999/stefanprodan/podinfo@"+digest), pod("b", "amd64-node", "nginx", "nginx@sha256:other")},
+			expectedStatus:  StatusReady,
+			expectedHealthy: true,
+			expectedMessage: digest,
+		},
+		{
+			name: "running the image of the node architecture",
+			pods: []corev1.Pod{
+				pod("a", "amd64-node", ref, "127.0.0.1:31999/stefanprodan/podinfo@"+amd64Digest),
+				pod("b", "arm64-node", ref, "127.0.0.1:31999/stefanprodan/podinfo@"+arm64Digest),
+				pod("c", "", ref, "127.0.0.1:31999/stefanprodan/podinfo@"+arm64Digest),
+			},
+			expectedStatus:  StatusReady,
+			expectedHealthy: true,
+			expectedMessage: digest,
+		},
+		{
+			name:            "running the image of another architecture",
+			pods:            []corev1.Pod{pod("a", "arm64-node", ref, "127.0.0.1:31999/stefanprodan/podinfo@"+amd64Digest)},
+			expectedStatus:  StatusDrifted,
+			expectedMessage: "the Zarf registry has " + digest + " but podinfo/a runs " + amd64Digest,
+		},
+		{
+			name:            "running a different digest",
+			pods:            []corev1.Pod{pod("a", "amd64-node", ref, "127.0.0.1:31999/stefanprodan/podinfo@sha256:other")},
+			expectedStatus:  StatusDrifted,
+			expectedMessage: "the Zarf registry has " + digest + " but podinfo/a runs sha256:other",
+		},
+		{
+			name:            "missing from the registry",
+			resolveErr:      errors.New("MANIFEST_UNKNOWN"),
+			expectedStatus:  StatusMissing,
+			expectedMessage: "unable to resolve the image in the Zarf registry: MANIFEST_UNKNOWN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := imageStatusResult("web", ref, digests, tt.resolveErr, tt.pods, nodeArchs)
+			require.Equal(t, StatusKindImage, result.Kind)
+			require.Equal(t, tt.expectedStatus, result.Status)
+			require.Equal(t, tt.expectedHealthy, result.Healthy)
+			require.Equal(t, tt.expectedMessage, result.Message)
+		})
+	}
+}
+
+func TestResolveRegistryImage(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	registryAddress := strings.TrimPrefix(srv.URL, "http://")
+
+	addenda := []mutate.IndexAddendum{}
+	platformDigests := map[string]string{}
+	for _, arch := range []string{"amd64", "arm64"} {
+		img, err := random.Image(256, 1)
+		require.NoError(t, err)
+		imgDigest, err := img.Digest()
+		require.NoError(t, err)
+		platformDigests[arch] = imgDigest.String()
+		addenda = append(addenda, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: arch}},
+		})
+	}
+	idx := mutate.AppendManifests(empty.Index, addenda...)
+	idxDigest, err := idx.Digest()
+	require.NoError(t, err)
+	ref, err := name.ParseReference(registryAddress + "/podinfo:6.4.0")
+	require.NoError(t, err)
+	err = remote.WriteIndex(ref, idx)
+	require.NoError(t, err)
+	single, err := random.Image(256, 1)
+	require.NoError(t, err)
+	singleDigest, err := single.Digest()
+	require.NoError(t, err)
+	err = crane.Push(single, registryAddress+"/single:1.0.0")
+	require.NoError(t, err)
+
+	digests, err := resolveRegistryImage(registryAddress+"/podinfo:6.4.0", crane.WithPlatform(&v1.Platform{OS: "linux", Architecture: "amd64"}))
+	require.NoError(t, err)
+	require.Equal(t, registryImageDigests{digest: idxDigest.String(), platforms: platformDigests}, digests)
+
+	digests, err = resolveRegistryImage(registryAddress + "/single:1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, registryImageDigests{digest: singleDigest.String(), platforms: map[string]string{}}, digests)
+
+	_, err = resolveRegistryImage(registryAddress + "/missing:1.0.0")
+	require.Error(t, err)
+}
@@ -6,12 +6,16 @@ package transform
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/distribution/reference"
 )
 
+// OCILayoutPrefix is the prefix of image references that load the image from an OCI image layout directory.
+const OCILayoutPrefix = "oci-layout://"
+
 // Image represents a config for an OCI image.
 type Image struct {
 	Host        string
@@ -21,6 +25,8 @@ type Image struct {
 	Digest      string
 	Reference   string
 	TagOrDigest string
+	// LayoutPath is the OCI image layout directory of an oci-layout:// reference.
+	LayoutPath string
 }
 
 // ImageTransformHost replaces the base url for an image and adds a crc32 of the original url to the end of the src (note image refs are not full URLs).
@@ -63,6 +69,10 @@ func ImageTransformHostWithoutChecksum(targetHost, srcReference string) (string,
 
 // ParseImageRef parses a source reference into an Image struct
 func ParseImageRef(srcReference string) (out Image, err error) {
+	if layoutReference, ok := strings.CutPrefix(srcReference, OCILayoutPrefix); ok {
+		return parseOCILayoutRef(layoutReference)
+	}
+
 	srcReference = strings.TrimPrefix(srcReference, helpers.OCIURLPrefix)
 
 	ref, err := reference.ParseAnyReference(srcReference)
@@ -101,3 +111,38 @@ func ParseImageRef(srcReference string) (out Image, err error) {
 
 	return out, nil
 }
+
+// parseOCILayoutRef parses a reference of the form path:tag or path@digest to an image in an OCI image layout
+// directory. The image is named after the final element of the path.
+func parseOCILayoutRef(layoutReference string) (Image, error) {
+	layoutPath, tagOrDigest := layoutReference, ""
+	if i := strings.LastIndex(layoutReference, "@"); i != -1 {
+		layoutPath, tagOrDigest = layoutReference[:i], layoutReference[i:]
+	} else if i := strings.LastIndex(layoutReference, ":"); i > strings.LastIndexAny(layoutReference, `/\`) {
+		layoutPath, tagOrDigest = layoutReference[:i], layoutReference[i:]
+	}
+	if layoutPath == "" {
+		return Image{}, fmt.Errorf("missing the image layout path in %s%s", OCILayoutPrefix, layoutReference)
+	}
+
+	out, err := ParseImageRef(filepath.Base(filepath.Clean(layoutPath)) + tagOrDigest)
+	if err != nil {
+		return Image{}, fmt.Errorf("unable to name the image in %s%s: %w", OCILayoutPrefix, layoutReference, err)
+	}
+	out.LayoutPath = layoutPath
+	return out, nil
+}
+
+// SetOCILayoutPath returns the oci-layout:// reference with its image layout directory replaced by the path, keeping
+// the tag or digest of the reference as written.
+func SetOCILayoutPath(srcReference, layoutPath string) (string, error) {
+	layoutReference, ok := strings.CutPrefix(srcReference, OCILayoutPrefix)
+	if !ok {
+		return "", fmt.Errorf("%s is not an %s reference", srcReference, OCILayoutPrefix)
+	}
+	out, err := parseOCILayoutRef(layoutReference)
+	if err != nil {
+		return "", err
+	}
+	return OCILayoutPrefix + layoutPath + strings.TrimPrefix(layoutReference, out.LayoutPath), nil
+}
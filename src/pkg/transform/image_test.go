@@ -124,3 +124,108 @@ func TestParseImageRef(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestParseOCILayoutImageRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		ref                string
+		expectedReference  string
+		expectedLayoutPath string
+		expectedErr        string
+	}{
+		{
+			name:               "tag",
+			ref:                "oci-layout://build/podinfo:6.4.0",
+			expectedReference:  "docker.io/library/podinfo:6.4.0",
+			expectedLayoutPath: "build/podinfo",
+		},
+		{
+			name:               "default tag",
+			ref:                "oci-layout://./podinfo",
+			expectedReference:  "docker.io/library/podinfo:latest",
+			expectedLayoutPath: "./podinfo",
+		},
+		{
+			name:               "digest",
+			ref:                "oci-layout:///tmp/images/podinfo@sha256:84605f731c6a18194794c51e70021c671ab064654b751aa57e905bce55be13de",
+			expectedReference:  "docker.io/library/podinfo@sha256:84605f731c6a18194794c51e70021c671ab064654b751aa57e905bce55be13de",
+			expectedLayoutPath: "/tmp/images/podinfo",
+		},
+		{
+			name:        "missing path",
+			ref:         "oci-layout://:6.4.0",
+			expectedErr: "missing the image layout path in oci-layout://:6.4.0",
+		},
+		{
+			name:        "invalid name",
+			ref:         "oci-layout://build/PodInfo:6.4.0",
+			expectedErr: "unable to name the image in oci-layout://build/PodInfo:6.4.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			img, err := ParseImageRef(tt.ref)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedReference, img.Reference)
+			require.Equal(t, tt.expectedLayoutPath, img.LayoutPath)
+		})
+	}
+}
+
+func TestSetOCILayoutPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		ref         string
+		layoutPath  string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:       "tag",
+			ref:        "oci-layout://build/podinfo:6.4.0",
+			layoutPath: "imports/build/podinfo",
+			expected:   "oci-layout://imports/build/podinfo:6.4.0",
+		},
+		{
+			name:       "default tag",
+			ref:        "oci-layout://./podinfo",
+			layoutPath: "images/0/podinfo",
+			expected:   "oci-layout://images/0/podinfo",
+		},
+		{
+			name:       "digest",
+			ref:        "oci-layout://podinfo@sha256:84605f731c6a18194794c51e70021c671ab064654b751aa57e905bce55be13de",
+			layoutPath: "/tmp/podinfo",
+			expected:   "oci-layout:///tmp/podinfo@sha256:84605f731c6a18194794c51e70021c671ab064654b751aa57e905bce55be13de",
+		},
+		{
+			name:        "not a layout reference",
+			ref:         "ghcr.io/stefanprodan/podinfo:6.4.0",
+			layoutPath:  "podinfo",
+			expectedErr: "ghcr.io/stefanprodan/podinfo:6.4.0 is not an oci-layout:// reference",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ref, err := SetOCILayoutPath(tt.ref, tt.layoutPath)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, ref)
+		})
+	}
+}
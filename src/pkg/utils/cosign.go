@@ -6,6 +6,7 @@ package utils
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"io"
 	"os"
@@ -196,6 +197,36 @@ func CosignVerifyBlob(ctx context.Context, blobRef string, sigRef string, keyPat
 	return err
 }
 
+// CosignVerifyImage verifies that an image has a cosign signature that validates against the given PEM encoded public
+// key. Transparency log and certificate checks are skipped as they are not available in air gapped environments.
+func CosignVerifyImage(ctx context.Context, image string, publicKey []byte, insecure bool, auth authn.Authenticator) error {
+	var nameOpts []name.Option
+	if insecure {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+	ref, err := name.ParseReference(image, nameOpts...)
+	if err != nil {
+		return err
+	}
+
+	verifier, err := sigs.LoadPublicKeyRaw(publicKey, crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("unable to load the cosign public key: %w", err)
+	}
+	checkOpts := &cosign.CheckOpts{
+		SigVerifier:   verifier,
+		ClaimVerifier: cosign.SimpleClaimVerifier,
+		RegistryClientOpts: []ociremote.Option{
+			ociremote.WithRemoteOptions(remote.WithAuth(auth), remote.WithContext(ctx)),
+		},
+		IgnoreSCT:  true,
+		IgnoreTlog: true,
+		Offline:    true,
+	}
+	_, _, err = cosign.VerifyImageSignatures(ctx, ref, checkOpts)
+	return err
+}
+
 // CosignSignBlob signs the provide binary and returns the signature
 func CosignSignBlob(blobPath string, outputSigPath string, keyPath string, passwordFunc func(bool) ([]byte, error)) ([]byte, error) {
 	rootOptions := &options.RootOptions{Verbose: false, Timeout: options.DefaultTimeout}
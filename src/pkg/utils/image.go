@@ -6,6 +6,7 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,12 +14,27 @@ import (
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 )
 
 // LoadOCIImage returns a v1.Image with the image ref specified from a location provided, or an error if the image cannot be found.
+//
+// Image indexes of multi-architecture packages resolve to the image of their first platform.
 func LoadOCIImage(imgPath string, refInfo transform.Image) (v1.Image, error) {
+	m, err := LoadOCIManifest(imgPath, refInfo)
+	if err != nil {
+		return nil, err
+	}
+	if idx, ok := m.(v1.ImageIndex); ok {
+		return FirstIndexImage(idx)
+	}
+	return m.(v1.Image), nil
+}
+
+// LoadOCIManifest returns the v1.Image or v1.ImageIndex with the image ref specified from a location provided, or an error if it cannot be found.
+func LoadOCIManifest(imgPath string, refInfo transform.Image) (partial.Describable, error) {
 	// Use the manifest within the index.json to load the specific image we want
 	layoutPath := layout.Path(imgPath)
 	imgIdx, err := layoutPath.ImageIndex()
@@ -36,6 +52,9 @@ func LoadOCIImage(imgPath string, refInfo transform.Image) (v1.Image, error) {
 			// A backwards compatibility shim for older Zarf versions that would leave docker.io off of image annotations
 			(manifest.Annotations[ocispec.AnnotationBaseImageName] == refInfo.Path+refInfo.TagOrDigest && refInfo.Host == "docker.io") {
 			// This is the image we are looking for, load it and then return
+			if manifest.MediaType.IsIndex() {
+				return imgIdx.ImageIndex(manifest.Digest)
+			}
 			return layoutPath.Image(manifest.Digest)
 		}
 	}
@@ -43,6 +62,34 @@ func LoadOCIImage(imgPath string, refInfo transform.Image) (v1.Image, error) {
 	return nil, fmt.Errorf("unable to find image (%s) at the path (%s)", refInfo.Reference, imgPath)
 }
 
+// FirstIndexImage returns the image of the first platform in an image index.
+func FirstIndexImage(idx v1.ImageIndex) (v1.Image, error) {
+	descs, err := IndexPlatformImages(idx)
+	if err != nil {
+		return nil, err
+	}
+	return idx.Image(descs[0].Digest)
+}
+
+// IndexPlatformImages returns the descriptors of the images for a platform in an image index, skipping the manifests of
+// attestations that have an unknown platform.
+func IndexPlatformImages(idx v1.ImageIndex) ([]v1.Descriptor, error) {
+	idxManifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	descs := []v1.Descriptor{}
+	for _, manifest := range idxManifest.Manifests {
+		if manifest.Platform != nil && manifest.Platform.OS != "unknown" && manifest.MediaType.IsImage() {
+			descs = append(descs, manifest)
+		}
+	}
+	if len(descs) == 0 {
+		return nil, errors.New("the image index does not contain an image for a platform")
+	}
+	return descs, nil
+}
+
 // AddImageNameAnnotation adds an annotation to the index.json file so that the deploying code can figure out what the image reference <-> digest shasum will be.
 func AddImageNameAnnotation(ociPath string, referenceToDigest map[string]string) error {
 	indexPath := filepath.Join(ociPath, "index.json")
@@ -11,6 +11,7 @@ import (
 
 	"github.com/defenseunicorns/pkg/oci"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"oras.land/oras-go/v2/content"
 )
@@ -52,6 +53,16 @@ func CopyPackage(ctx context.Context, src *Remote, dst *Remote, concurrency int)
 	if err := dst.UpdateIndex(ctx, tag, expected); err != nil {
 		return err
 	}
+	pkg, err := src.FetchZarfYAML(ctx)
+	if err != nil {
+		return err
+	}
+	if pkg.IsMultiArch() {
+		archs := append([]string{v1alpha1.ZarfPackageArchMulti}, pkg.Metadata.Architectures...)
+		if err := dst.updateIndexForArchitectures(ctx, tag, expected, archs); err != nil {
+			return err
+		}
+	}
 
 	src.Log().Info(fmt.Sprintf("Published %s to %s", src.Repo().Reference, dst.Repo().Reference))
 	return nil
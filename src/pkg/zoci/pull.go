@@ -6,6 +6,7 @@ package zoci
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 
@@ -19,6 +20,9 @@ import (
 	"oras.land/oras-go/v2/content/file"
 )
 
+// dockerManifestListMediaType is the media type of docker image indexes.
+const dockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+
 var (
 	// PackageAlwaysPull is a list of paths that will always be pulled from the remote repository.
 	PackageAlwaysPull = []string{layout.ZarfYAML, layout.Checksums, layout.Signature}
@@ -90,7 +94,7 @@ func (r *Remote) LayersFromRequestedComponents(ctx context.Context, requestedCom
 	images := map[string]bool{}
 	for _, rc := range requestedComponents {
 		component := helpers.Find(pkg.Components, func(component v1alpha1.ZarfComponent) bool {
-			return component.Name == rc.Name
+			return pkg.ComponentKey(component) == pkg.ComponentKey(rc)
 		})
 		if component.Name == "" {
 			return nil, fmt.Errorf("component %s does not exist in this package", rc.Name)
@@ -98,7 +102,7 @@ func (r *Remote) LayersFromRequestedComponents(ctx context.Context, requestedCom
 		for _, image := range component.Images {
 			images[image] = true
 		}
-		layers = append(layers, root.Locate(filepath.Join(layout.ComponentsDir, fmt.Sprintf(tarballFormat, component.Name))))
+		layers = append(layers, root.Locate(filepath.Join(layout.ComponentsDir, fmt.Sprintf(tarballFormat, pkg.ComponentKey(component)))))
 	}
 	// Append the sboms.tar layer if it exists
 	//
@@ -128,23 +132,52 @@ func (r *Remote) LayersFromRequestedComponents(ctx context.Context, requestedCom
 					(layer.Annotations[ocispec.AnnotationBaseImageName] == refInfo.Path+refInfo.TagOrDigest && refInfo.Host == "docker.io")
 			})
 
-			// even though these are technically image manifests, we store them as Zarf blobs
-			manifestDescriptor.MediaType = ZarfLayerMediaTypeBlob
-
-			manifest, err := r.FetchManifest(ctx, manifestDescriptor)
+			imageLayers, err := r.imageLayers(ctx, root, manifestDescriptor)
 			if err != nil {
 				return nil, err
 			}
-			// Add the manifest and the manifest config layers
-			layers = append(layers, root.Locate(filepath.Join(layout.ImagesBlobsDir, manifestDescriptor.Digest.Encoded())))
-			layers = append(layers, root.Locate(filepath.Join(layout.ImagesBlobsDir, manifest.Config.Digest.Encoded())))
-
-			// Add all the layers from the manifest
-			for _, layer := range manifest.Layers {
-				layerPath := filepath.Join(layout.ImagesBlobsDir, layer.Digest.Encoded())
-				layers = append(layers, root.Locate(layerPath))
+			layers = append(layers, imageLayers...)
+		}
+	}
+	return layers, nil
+}
+
+// imageLayers returns the descriptors of the manifest, config and layers of an image in the package, or of every
+// image in an image index of a multi-architecture package.
+func (r *Remote) imageLayers(ctx context.Context, root *oci.Manifest, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	// even though these are technically image manifests, we store them as Zarf blobs
+	isIndex := desc.MediaType == ocispec.MediaTypeImageIndex || desc.MediaType == dockerManifestListMediaType
+	desc.MediaType = ZarfLayerMediaTypeBlob
+
+	// Add the manifest layer
+	layers := []ocispec.Descriptor{root.Locate(filepath.Join(layout.ImagesBlobsDir, desc.Digest.Encoded()))}
+
+	if isIndex {
+		index, err := oci.FetchUnmarshal[ocispec.Index](ctx, r.FetchLayer, json.Unmarshal, desc)
+		if err != nil {
+			return nil, err
+		}
+		for _, manifest := range index.Manifests {
+			manifestLayers, err := r.imageLayers(ctx, root, manifest)
+			if err != nil {
+				return nil, err
 			}
+			layers = append(layers, manifestLayers...)
 		}
+		return layers, nil
+	}
+
+	manifest, err := r.FetchManifest(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	// Add the manifest config layer
+	layers = append(layers, root.Locate(filepath.Join(layout.ImagesBlobsDir, manifest.Config.Digest.Encoded())))
+
+	// Add all the layers from the manifest
+	for _, layer := range manifest.Layers {
+		layerPath := filepath.Join(layout.ImagesBlobsDir, layer.Digest.Encoded())
+		layers = append(layers, root.Locate(layerPath))
 	}
 	return layers, nil
 }
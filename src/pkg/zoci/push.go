@@ -83,11 +83,31 @@ func (r *Remote) PublishPackage(ctx context.Context, pkg *v1alpha1.ZarfPackage,
 	if err := r.UpdateIndex(ctx, r.Repo().Reference.Reference, publishedDesc); err != nil {
 		return err
 	}
+	if pkg.IsMultiArch() {
+		if err := r.updateIndexForArchitectures(ctx, r.Repo().Reference.Reference, publishedDesc, pkg.Metadata.Architectures); err != nil {
+			return err
+		}
+	}
 
 	progressBar.Successf("Published %s [%s]", r.Repo().Reference, ZarfLayerMediaTypeBlob)
 	return nil
 }
 
+// updateIndexForArchitectures adds a multi-architecture package to the index of the tag for each of its architectures
+// so the package is resolved when pulling it for any of them.
+func (r *Remote) updateIndexForArchitectures(ctx context.Context, tag string, desc ocispec.Descriptor, archs []string) error {
+	for _, arch := range archs {
+		archRemote, err := NewRemote(r.Repo().Reference.String(), oci.PlatformForArch(arch))
+		if err != nil {
+			return err
+		}
+		if err := archRemote.UpdateIndex(ctx, tag, desc); err != nil {
+			return fmt.Errorf("unable to add the package to the index for %s: %w", arch, err)
+		}
+	}
+	return nil
+}
+
 func annotationsFromMetadata(metadata *v1alpha1.ZarfMetadata) map[string]string {
 	annotations := map[string]string{
 		ocispec.AnnotationTitle:       metadata.Name,
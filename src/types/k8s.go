@@ -5,7 +5,12 @@
 package types
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
@@ -57,6 +62,27 @@ type GeneratedPKI struct {
 	Key  []byte `json:"key"`
 }
 
+// ExpiresAt returns the expiration date of the generated certificate.
+func (pki GeneratedPKI) ExpiresAt() (time.Time, error) {
+	block, _ := pem.Decode(pki.Cert)
+	if block == nil {
+		return time.Time{}, fmt.Errorf("unable to decode the certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse the certificate: %w", err)
+	}
+	return cert.NotAfter, nil
+}
+
+// CredentialExpirations tracks when the passwords Zarf generated for its internal services expire.
+type CredentialExpirations struct {
+	// Expiration date of the generated registry push and pull passwords
+	Registry *time.Time `json:"registry,omitempty"`
+	// Expiration date of the generated git server push and pull passwords
+	Git *time.Time `json:"git,omitempty"`
+}
+
 // ZarfState is maintained as a secret in the Zarf namespace to track Zarf init data.
 type ZarfState struct {
 	// Indicates if Zarf was initialized while deploying its own k8s cluster
@@ -76,6 +102,30 @@ type ZarfState struct {
 	RegistryInfo RegistryInfo `json:"registryInfo"`
 	// Information about the artifact registry Zarf is configured to use
 	ArtifactServer ArtifactServerInfo `json:"artifactServer"`
+	// Cosign signature verification the agent performs on pod images
+	ImageVerification ImageVerificationPolicy `json:"imageVerification,omitempty"`
+	// Resources and images the agent leaves untouched
+	AgentExclusions AgentExclusionRules `json:"agentExclusions,omitempty"`
+	// Custom resources whose image and git URL fields the agent rewrites
+	AgentCRDMutations []AgentCRDMutation `json:"agentCRDMutations,omitempty"`
+	// Expiration dates of the generated internal service passwords
+	CredentialExpirations CredentialExpirations `json:"credentialExpirations,omitempty"`
+}
+
+// ZarfStateBackup is a point in time copy of the Zarf state and the deployed package records of a cluster.
+type ZarfStateBackup struct {
+	// Version of the Zarf CLI that created the backup
+	CLIVersion string `json:"cliVersion"`
+	// Time the backup was created
+	CreatedAt time.Time `json:"createdAt"`
+	// Whether the credentials in the state were sanitized, which prevents restoring the backup
+	Redacted bool `json:"redacted,omitempty"`
+	// The Zarf state
+	State ZarfState `json:"state"`
+	// The packages deployed to the cluster
+	DeployedPackages []DeployedPackage `json:"deployedPackages"`
+	// The recorded revisions of each deployed package, keyed by the package name
+	PackageHistory map[string][]DeployedPackageRevision `json:"packageHistory,omitempty"`
 }
 
 // DeployedPackage contains information about a Zarf Package that has been deployed to a cluster
@@ -90,6 +140,17 @@ type DeployedPackage struct {
 	ConnectStrings     ConnectStrings                `json:"connectStrings,omitempty"`
 }
 
+// DeployedPackageRevision is a point in time record of a DeployedPackage kept in the package history.
+type DeployedPackageRevision struct {
+	Generation         int                  `json:"generation"`
+	CLIVersion         string               `json:"cliVersion"`
+	Timestamp          time.Time            `json:"timestamp"`
+	Data               v1alpha1.ZarfPackage `json:"data"`
+	DeployedComponents []DeployedComponent  `json:"deployedComponents"`
+	ConnectStrings     ConnectStrings       `json:"connectStrings,omitempty"`
+	Variables          map[string]string    `json:"variables,omitempty"`
+}
+
 // ConnectString contains information about a connection made with Zarf connect.
 type ConnectString struct {
 	// Descriptive text that explains what the resource you would be connecting to is used for
@@ -103,10 +164,21 @@ type ConnectStrings map[string]ConnectString
 
 // DeployedComponent contains information about a Zarf Package Component that has been deployed to a cluster.
 type DeployedComponent struct {
-	Name               string           `json:"name"`
-	InstalledCharts    []InstalledChart `json:"installedCharts"`
-	Status             ComponentStatus  `json:"status"`
-	ObservedGeneration int              `json:"observedGeneration"`
+	Name               string                  `json:"name"`
+	InstalledCharts    []InstalledChart        `json:"installedCharts"`
+	DataInjections     []DeployedDataInjection `json:"dataInjections,omitempty"`
+	Status             ComponentStatus         `json:"status"`
+	ObservedGeneration int                     `json:"observedGeneration"`
+}
+
+// DeployedDataInjection records data that was injected into a PersistentVolumeClaim.
+type DeployedDataInjection struct {
+	Namespace             string    `json:"namespace"`
+	PersistentVolumeClaim string    `json:"persistentVolumeClaim"`
+	Path                  string    `json:"path"`
+	Files                 int       `json:"files"`
+	Digest                string    `json:"digest"`
+	InjectedAt            time.Time `json:"injectedAt"`
 }
 
 // Webhook contains information about a Component Webhook operating on a Zarf package secret.
@@ -121,6 +193,8 @@ type Webhook struct {
 type InstalledChart struct {
 	Namespace string `json:"namespace"`
 	ChartName string `json:"chartName"`
+	// Revision is the Helm release revision installed by the package generation
+	Revision int `json:"revision,omitempty"`
 }
 
 // GitServerInfo contains information Zarf uses to communicate with a git repository to push/pull repositories to.
@@ -277,3 +351,118 @@ func (ri *RegistryInfo) FillInEmptyValues() error {
 
 	return nil
 }
+
+// Modes for the image signature verification performed by the Zarf agent.
+const (
+	ImageVerificationEnforce = "enforce"
+	ImageVerificationWarn    = "warn"
+)
+
+// ImageVerificationPolicy configures the cosign signature verification the Zarf agent performs on pod images.
+type ImageVerificationPolicy struct {
+	// PEM encoded cosign public key that image signatures must validate against, verification is disabled when empty
+	PublicKey string `json:"publicKey,omitempty"`
+	// Whether pods with images that fail verification are rejected (enforce) or admitted with a warning (warn)
+	Mode string `json:"mode,omitempty"`
+}
+
+// Enabled returns whether image signature verification is configured.
+func (ivp ImageVerificationPolicy) Enabled() bool {
+	return ivp.PublicKey != ""
+}
+
+// WarnOnly returns whether images that fail verification are admitted with a warning.
+func (ivp ImageVerificationPolicy) WarnOnly() bool {
+	return ivp.Mode == ImageVerificationWarn
+}
+
+// AgentExclusionRules are the namespaces, images and registries the Zarf agent does not redirect to the Zarf services.
+type AgentExclusionRules struct {
+	// Glob patterns of namespaces whose resources are not mutated
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Glob patterns of image references that are not rewritten, '*' also matches across '/'
+	Images []string `json:"images,omitempty"`
+	// Registries (optionally with a path prefix) already considered internal, images and repositories from them are not rewritten
+	Registries []string `json:"registries,omitempty"`
+}
+
+// IsEmpty returns whether no exclusion rules are configured.
+func (aer AgentExclusionRules) IsEmpty() bool {
+	return len(aer.Namespaces) == 0 && len(aer.Images) == 0 && len(aer.Registries) == 0
+}
+
+// AgentCRDMutation describes the image and git URL fields of a custom resource the Zarf agent rewrites.
+type AgentCRDMutation struct {
+	// API group of the custom resource
+	Group string `json:"group"`
+	// API version of the custom resource
+	Version string `json:"version"`
+	// Kind of the custom resource
+	Kind string `json:"kind"`
+	// Plural resource name the agent webhook is registered for
+	Resource string `json:"resource"`
+	// JSONPaths of image reference fields, '[*]' selects every item of a list (e.g. '.spec.steps[*].image')
+	ImagePaths []string `json:"imagePaths,omitempty"`
+	// JSONPaths of git repository URL fields
+	GitURLPaths []string `json:"gitURLPaths,omitempty"`
+}
+
+// Validate runs all validation checks on a custom resource mutation.
+func (acm AgentCRDMutation) Validate() error {
+	if acm.Version == "" || acm.Kind == "" || acm.Resource == "" {
+		return fmt.Errorf("custom resource mutations require a version, kind and resource: %+v", acm)
+	}
+	if len(acm.ImagePaths) == 0 && len(acm.GitURLPaths) == 0 {
+		return fmt.Errorf("custom resource mutation for %s has no image or git URL paths", acm.Kind)
+	}
+	for _, fieldPath := range append(slices.Clone(acm.ImagePaths), acm.GitURLPaths...) {
+		if _, err := ParseFieldPath(fieldPath); err != nil {
+			return fmt.Errorf("invalid field path %q in the custom resource mutation for %s: %w", fieldPath, acm.Kind, err)
+		}
+	}
+	return nil
+}
+
+// FieldPathSegment is a single step of a field path, a map key or a list selector that is an index or '*'.
+type FieldPathSegment struct {
+	Key   string
+	Index string
+}
+
+// ParseFieldPath parses a JSONPath subset of dot separated keys with optional list selectors, such as
+// '.spec.template.spec.containers[*].image' or '{.spec.source.url}'.
+func ParseFieldPath(fieldPath string) ([]FieldPathSegment, error) {
+	fieldPath = strings.TrimSuffix(strings.TrimPrefix(fieldPath, "{"), "}")
+	fieldPath = strings.TrimPrefix(fieldPath, "$")
+	if !strings.HasPrefix(fieldPath, ".") {
+		return nil, fmt.Errorf("field paths must start with '.'")
+	}
+
+	var segments []FieldPathSegment
+	for _, part := range strings.Split(strings.TrimPrefix(fieldPath, "."), ".") {
+		key, index, hasIndex := strings.Cut(part, "[")
+		if key == "" {
+			return nil, fmt.Errorf("empty key in field path")
+		}
+		segments = append(segments, FieldPathSegment{Key: key})
+		for hasIndex {
+			selector, rest, closed := strings.Cut(index, "]")
+			if !closed {
+				return nil, fmt.Errorf("unterminated list selector")
+			}
+			index = rest
+			if _, err := strconv.Atoi(selector); selector != "*" && err != nil {
+				return nil, fmt.Errorf("invalid list selector [%s]", selector)
+			}
+			segments = append(segments, FieldPathSegment{Index: selector})
+			if index == "" {
+				break
+			}
+			if !strings.HasPrefix(index, "[") {
+				return nil, fmt.Errorf("unexpected %q after list selector", index)
+			}
+			index = strings.TrimPrefix(index, "[")
+		}
+	}
+	return segments, nil
+}
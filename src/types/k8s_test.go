@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentCRDMutationValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mutation    AgentCRDMutation
+		expectedErr string
+	}{
+		{
+			name: "valid paths",
+			mutation: AgentCRDMutation{
+				Group:       "tekton.dev",
+				Version:     "v1",
+				Kind:        "Task",
+				Resource:    "tasks",
+				ImagePaths:  []string{".spec.steps[*].image", "{.spec.sidecars[0].image}"},
+				GitURLPaths: []string{"$.spec.source.url"},
+			},
+		},
+		{
+			name:        "missing resource",
+			mutation:    AgentCRDMutation{Version: "v1", Kind: "Task", ImagePaths: []string{".spec.image"}},
+			expectedErr: "custom resource mutations require a version, kind and resource",
+		},
+		{
+			name:        "no paths",
+			mutation:    AgentCRDMutation{Version: "v1", Kind: "Task", Resource: "tasks"},
+			expectedErr: "custom resource mutation for Task has no image or git URL paths",
+		},
+		{
+			name:        "image path without a leading dot",
+			mutation:    AgentCRDMutation{Version: "v1", Kind: "Task", Resource: "tasks", ImagePaths: []string{"spec.image"}},
+			expectedErr: `invalid field path "spec.image" in the custom resource mutation for Task: field paths must start with '.'`,
+		},
+		{
+			name:        "invalid git URL list selector",
+			mutation:    AgentCRDMutation{Version: "v1", Kind: "Task", Resource: "tasks", GitURLPaths: []string{".spec.sources[first].url"}},
+			expectedErr: `invalid field path ".spec.sources[first].url" in the custom resource mutation for Task: invalid list selector [first]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.mutation.Validate()
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
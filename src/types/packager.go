@@ -35,6 +35,15 @@ type PackagerConfig struct {
 	// FindImagesOpts tracks user-defined options used to find images
 	FindImagesOpts ZarfFindImagesOptions
 
+	// DiffOpts tracks user-defined options used to compare packages
+	DiffOpts ZarfDiffOptions
+
+	// RemoveOpts tracks user-defined options used to remove deployed packages
+	RemoveOpts ZarfRemoveOptions
+
+	// RollbackOpts tracks user-defined options used to roll back deployed packages
+	RollbackOpts ZarfRollbackOptions
+
 	// GenerateOpts tracks user-defined values for package generation.
 	GenerateOpts ZarfGenerateOptions
 
@@ -20,6 +20,8 @@ type ZarfCommonOptions struct {
 	TempDirectory string
 	// Number of concurrent layer operations to perform when interacting with a remote package
 	OCIConcurrency int
+	// Key reference (age identity, identity file or KMS URI) used to encrypt and decrypt the Zarf state
+	StateEncryptionKey string
 }
 
 // ZarfPackageOptions tracks the user-defined preferences during common package operations.
@@ -38,6 +40,8 @@ type ZarfPackageOptions struct {
 	PublicKeyPath string
 	// The number of retries to perform for Zarf deploy operations like image pushes or Helm installs
 	Retries int
+	// The number of images to push to the registry at the same time
+	PushConcurrency int
 }
 
 // ZarfInspectOptions tracks the user-defined preferences during a package inspection.
@@ -48,6 +52,8 @@ type ZarfInspectOptions struct {
 	SBOMOutputDir string
 	// ListImages will list the images in the package
 	ListImages bool
+	// Format used to print the package definition or image list (table|json|yaml)
+	OutputFormat string
 }
 
 // ZarfFindImagesOptions tracks the user-defined preferences during a prepare find-images search.
@@ -62,6 +68,8 @@ type ZarfFindImagesOptions struct {
 	Why string
 	// Optionally skip lookup of cosign artifacts when finding images
 	SkipCosign bool
+	// Format used to print the found images (table|json|yaml)
+	OutputFormat string
 }
 
 // ZarfDeployOptions tracks the user-defined preferences during a package deploy.
@@ -74,6 +82,34 @@ type ZarfDeployOptions struct {
 	Timeout time.Duration
 	// [Library Only] A map of component names to chart names containing Helm Chart values to override values on deploy
 	ValuesOverridesMap map[string]map[string]map[string]interface{}
+	// Whether to report the changes a deployment would make instead of modifying the cluster
+	DryRun bool
+	// Whether to roll back the Helm releases and package secret to the previous generation if the deployment fails
+	RollbackOnFailure bool
+	// [Library Only] Whether to skip checking that the package satisfies the deployed packages that depend on it
+	SkipDependentsCheck bool
+}
+
+// ZarfDiffOptions tracks the user-defined preferences during a package diff.
+type ZarfDiffOptions struct {
+	// Location of the older Zarf package that the package source is compared against
+	BasePackageSource string
+	// Format of the resulting report (text or json)
+	OutputFormat string
+	// Whether to fail when the packages differ
+	ExitCode bool
+}
+
+// ZarfRemoveOptions tracks the user-defined preferences during a package remove.
+type ZarfRemoveOptions struct {
+	// Whether to remove the package even if other deployed packages depend on it
+	Force bool
+}
+
+// ZarfRollbackOptions tracks the user-defined preferences during a package rollback.
+type ZarfRollbackOptions struct {
+	// Generation of the package to roll back to (defaults to the previous generation)
+	Generation int
 }
 
 // ZarfMirrorOptions tracks the user-defined preferences during a package mirror.
@@ -124,6 +160,14 @@ type ZarfInitOptions struct {
 	ArtifactServer ArtifactServerInfo
 	// StorageClass of the k8s cluster Zarf is initializing
 	StorageClass string
+	// How the seed image is delivered to the injector pod (configmap|port-forward)
+	InjectionMode string
+	// Cosign signature verification the agent performs on pod images
+	ImageVerification ImageVerificationPolicy
+	// Resources and images the agent leaves untouched
+	AgentExclusions AgentExclusionRules
+	// Custom resources whose image and git URL fields the agent rewrites
+	AgentCRDMutations []AgentCRDMutation
 }
 
 // ZarfCreateOptions tracks the user-defined options used to create the package.
@@ -152,6 +196,8 @@ type ZarfCreateOptions struct {
 	RegistryOverrides map[string]string
 	// An optional variant that controls which components will be included in a package
 	Flavor string
+	// The local image sources to load images from, in order, when they cannot be pulled from a registry
+	ImageSources []string
 	// Whether to create a skeleton package
 	IsSkeleton bool
 	// Whether to create a YOLO package